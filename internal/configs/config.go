@@ -2,16 +2,22 @@ package configs
 
 import (
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
-	natsAddress     string
-	etcdAddress     string
-	serverAddress   string
-	oortAddress     string
-	meridianAddress string
-	gravityAddress  string
-	tokenKey        string
+	natsAddress       string
+	etcdAddress       string
+	serverAddress     string
+	oortAddress       string
+	meridianAddress   string
+	gravityAddress    string
+	tokenKey          string
+	heartbeatTTL      time.Duration
+	reconcileInterval time.Duration
+	// set once every replica runs with the label index, until then the legacy query model is kept
+	legacyIndexCleanup bool
 }
 
 func (c *Config) NatsAddress() string {
@@ -42,14 +48,53 @@ func (c *Config) TokenKey() string {
 	return c.tokenKey
 }
 
+func (c *Config) HeartbeatTTL() time.Duration {
+	return c.heartbeatTTL
+}
+
+func (c *Config) ReconcileInterval() time.Duration {
+	return c.reconcileInterval
+}
+
+func (c *Config) LegacyIndexCleanup() bool {
+	return c.legacyIndexCleanup
+}
+
 func NewFromEnv() (*Config, error) {
+	heartbeatTTL := 30 * time.Second
+	if ttl := os.Getenv("NODE_HEARTBEAT_TTL"); ttl != "" {
+		parsed, err := time.ParseDuration(ttl)
+		if err != nil {
+			return nil, err
+		}
+		heartbeatTTL = parsed
+	}
+	reconcileInterval := 5 * time.Minute
+	if interval := os.Getenv("RECONCILE_INTERVAL"); interval != "" {
+		parsed, err := time.ParseDuration(interval)
+		if err != nil {
+			return nil, err
+		}
+		reconcileInterval = parsed
+	}
+	legacyIndexCleanup := false
+	if cleanup := os.Getenv("LEGACY_INDEX_CLEANUP"); cleanup != "" {
+		parsed, err := strconv.ParseBool(cleanup)
+		if err != nil {
+			return nil, err
+		}
+		legacyIndexCleanup = parsed
+	}
 	return &Config{
-		natsAddress:     os.Getenv("NATS_ADDRESS"),
-		etcdAddress:     os.Getenv("ETCD_ADDRESS"),
-		serverAddress:   os.Getenv("MAGNETAR_ADDRESS"),
-		oortAddress:     os.Getenv("OORT_ADDRESS"),
-		meridianAddress: os.Getenv("MERIDIAN_ADDRESS"),
-		gravityAddress:  os.Getenv("GRAVITY_ADDRESS"),
-		tokenKey:        os.Getenv("SECRET_KEY"),
+		natsAddress:        os.Getenv("NATS_ADDRESS"),
+		etcdAddress:        os.Getenv("ETCD_ADDRESS"),
+		serverAddress:      os.Getenv("MAGNETAR_ADDRESS"),
+		oortAddress:        os.Getenv("OORT_ADDRESS"),
+		meridianAddress:    os.Getenv("MERIDIAN_ADDRESS"),
+		gravityAddress:     os.Getenv("GRAVITY_ADDRESS"),
+		tokenKey:           os.Getenv("SECRET_KEY"),
+		heartbeatTTL:       heartbeatTTL,
+		reconcileInterval:  reconcileInterval,
+		legacyIndexCleanup: legacyIndexCleanup,
 	}, nil
 }
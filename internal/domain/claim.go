@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"sort"
+)
+
+type ClaimStrategy int8
+
+const (
+	// ClaimFirstFit takes candidates in node id order, skipping the ones that don't add to an unmet resource target
+	ClaimFirstFit ClaimStrategy = iota
+	// ClaimBestFit repeatedly takes the candidate covering the remaining resource targets with the least excess,
+	// or, if none covers them alone, the one covering the largest share of them
+	ClaimBestFit
+)
+
+var claimStrategyStrings = map[ClaimStrategy]string{
+	ClaimFirstFit: "first-fit",
+	ClaimBestFit:  "best-fit",
+}
+
+func (s ClaimStrategy) String() string {
+	return claimStrategyStrings[s]
+}
+
+// NewClaimStrategyFromString defaults to first-fit for an empty value
+func NewClaimStrategyFromString(value string) (ClaimStrategy, error) {
+	if value == "" {
+		return ClaimFirstFit, nil
+	}
+	for strategy, strategyString := range claimStrategyStrings {
+		if strategyString == value {
+			return strategy, nil
+		}
+	}
+	return 0, ErrInvalidQuery
+}
+
+// ClaimRequirements limit a claim to a subset of the matching nodes,
+// at least Count nodes whose resources add up to at least Resources are claimed,
+// without any requirements all matching nodes are claimed
+type ClaimRequirements struct {
+	Count     int64
+	Resources map[string]float64
+	Strategy  ClaimStrategy
+}
+
+func (r ClaimRequirements) Validate() error {
+	if r.Count < 0 {
+		return ErrInvalidQuery
+	}
+	for _, target := range r.Resources {
+		if target <= 0 {
+			return ErrInvalidQuery
+		}
+	}
+	return nil
+}
+
+// Empty reports whether every matching node should be claimed
+func (r ClaimRequirements) Empty() bool {
+	return r.Count == 0 && len(r.Resources) == 0
+}
+
+// Met reports whether the nodes satisfy the requirements
+func (r ClaimRequirements) Met(nodes []Node) bool {
+	return r.Remaining(nodes).satisfied()
+}
+
+// Remaining returns what is still required once the nodes are claimed
+func (r ClaimRequirements) Remaining(nodes []Node) ClaimRequirements {
+	remaining := ClaimRequirements{
+		Count:     max(r.Count-int64(len(nodes)), 0),
+		Resources: make(map[string]float64),
+		Strategy:  r.Strategy,
+	}
+	for resource, target := range r.Resources {
+		for _, node := range nodes {
+			target -= node.Resources[resource]
+		}
+		if target > 0 {
+			remaining.Resources[resource] = target
+		}
+	}
+	return remaining
+}
+
+func (r ClaimRequirements) satisfied() bool {
+	return r.Count == 0 && len(r.Resources) == 0
+}
+
+// SelectForClaim chooses the candidates to claim with the requirements' strategy,
+// the selection is greedy and deterministic for the same candidates,
+// it approximates the smallest selection meeting the requirements but doesn't guarantee it,
+// a node taken early isn't dropped even if the nodes taken after it meet the requirements on their own,
+// ErrInsufficientNodes is returned if all candidates together don't meet the requirements
+func SelectForClaim(candidates []Node, requirements ClaimRequirements) ([]Node, error) {
+	if requirements.Empty() {
+		return candidates, nil
+	}
+	if !requirements.Met(candidates) {
+		return nil, ErrInsufficientNodes
+	}
+	left := make([]Node, len(candidates))
+	copy(left, candidates)
+	sort.SliceStable(left, func(i, j int) bool {
+		return left[i].Id.Value < left[j].Id.Value
+	})
+	selected := make([]Node, 0)
+	// drops resource targets that are already met, so that every target is positive
+	remaining := requirements.Remaining(nil)
+	for !remaining.satisfied() {
+		i := remaining.next(left)
+		selected = append(selected, left[i])
+		left = append(left[:i], left[i+1:]...)
+		remaining = requirements.Remaining(selected)
+	}
+	return selected, nil
+}
+
+// next returns the index of the candidate to take next, there is always one since the candidates meet the requirements
+func (r ClaimRequirements) next(candidates []Node) int {
+	if len(r.Resources) == 0 {
+		return 0
+	}
+	if r.Strategy == ClaimFirstFit {
+		for i, node := range candidates {
+			if r.coverage(node) > 0 {
+				return i
+			}
+		}
+		return 0
+	}
+	best, bestCoverage, bestExcess := -1, 0.0, 0.0
+	for i, node := range candidates {
+		coverage := r.coverage(node)
+		if coverage == 0 {
+			continue
+		}
+		excess, covers := r.excess(node)
+		switch {
+		case best < 0:
+		case covers && bestCoverage == float64(len(r.Resources)):
+			if excess >= bestExcess {
+				continue
+			}
+		case coverage <= bestCoverage:
+			continue
+		}
+		best, bestCoverage, bestExcess = i, coverage, excess
+	}
+	return best
+}
+
+// coverage sums the shares of the remaining resource targets the node covers,
+// a node covering all of them has a coverage equal to their number
+func (r ClaimRequirements) coverage(node Node) float64 {
+	coverage := 0.0
+	for resource, target := range r.Resources {
+		coverage += min(node.Resources[resource], target) / target
+	}
+	return coverage
+}
+
+// excess sums the shares by which the node exceeds the remaining resource targets,
+// covers reports whether it meets all of them
+func (r ClaimRequirements) excess(node Node) (float64, bool) {
+	excess := 0.0
+	covers := true
+	for resource, target := range r.Resources {
+		value := node.Resources[resource]
+		if value < target {
+			covers = false
+			continue
+		}
+		excess += (value - target) / target
+	}
+	return excess, covers
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSelectForClaim(t *testing.T) {
+	candidates := []Node{
+		newClaimTestNode("n4", 32, 128),
+		newClaimTestNode("n1", 8, 32),
+		newClaimTestNode("n3", 16, 64),
+		newClaimTestNode("n2", 4, 16),
+		newClaimTestNode("n5", 0, 0),
+	}
+	tests := []struct {
+		name         string
+		requirements ClaimRequirements
+		want         []string
+		err          error
+	}{
+		{"all", ClaimRequirements{}, []string{"n4", "n1", "n3", "n2", "n5"}, nil},
+		{"count", ClaimRequirements{Count: 2}, []string{"n1", "n2"}, nil},
+		{"first fit", ClaimRequirements{Resources: map[string]float64{"cpu": 20}}, []string{"n1", "n2", "n3"}, nil},
+		{"best fit single node", ClaimRequirements{Resources: map[string]float64{"cpu": 12, "memory": 48}, Strategy: ClaimBestFit}, []string{"n3"}, nil},
+		{"best fit several nodes", ClaimRequirements{Resources: map[string]float64{"cpu": 40}, Strategy: ClaimBestFit}, []string{"n4", "n1"}, nil},
+		{"count and resources", ClaimRequirements{Count: 3, Resources: map[string]float64{"cpu": 30}, Strategy: ClaimBestFit}, []string{"n4", "n1", "n2"}, nil},
+		{"zero resources skipped", ClaimRequirements{Resources: map[string]float64{"memory": 1}}, []string{"n1"}, nil},
+		{"too many nodes", ClaimRequirements{Count: 6}, nil, ErrInsufficientNodes},
+		{"zero target met", ClaimRequirements{Count: 1, Resources: map[string]float64{"cpu": 0}, Strategy: ClaimBestFit}, []string{"n1"}, nil},
+		{"too many resources", ClaimRequirements{Resources: map[string]float64{"cpu": 61}}, nil, ErrInsufficientNodes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := SelectForClaim(candidates, tt.requirements)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got error %v, want %v", err, tt.err)
+			}
+			got := make([]string, len(selected))
+			for i, node := range selected {
+				got[i] = node.Id.Value
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) && tt.err == nil {
+				t.Errorf("got nodes %v, want %v", got, tt.want)
+			}
+			if tt.err == nil && !tt.requirements.Met(selected) {
+				t.Errorf("selected nodes don't meet the requirements")
+			}
+		})
+	}
+}
+
+// the greedy best-fit selection takes the node covering the largest share first,
+// which the two other nodes turn out not to need
+func TestSelectForClaimBestFitNotMinimal(t *testing.T) {
+	candidates := []Node{
+		newClaimTestNode("n1", 10, 2),
+		newClaimTestNode("n2", 2, 10),
+		newClaimTestNode("n3", 7, 7),
+	}
+	requirements := ClaimRequirements{Resources: map[string]float64{"cpu": 10, "memory": 10}, Strategy: ClaimBestFit}
+	selected, err := SelectForClaim(candidates, requirements)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, len(selected))
+	for i, node := range selected {
+		got[i] = node.Id.Value
+	}
+	if fmt.Sprint(got) != fmt.Sprint([]string{"n3", "n1", "n2"}) {
+		t.Errorf("got nodes %v, want [n3 n1 n2]", got)
+	}
+	if !requirements.Met(candidates[:2]) {
+		t.Errorf("expected n1 and n2 alone to meet the requirements")
+	}
+}
+
+func TestClaimRequirementsValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		requirements ClaimRequirements
+		err          error
+	}{
+		{"empty", ClaimRequirements{}, nil},
+		{"positive", ClaimRequirements{Count: 1, Resources: map[string]float64{"cpu": 0.5}}, nil},
+		{"negative count", ClaimRequirements{Count: -1}, ErrInvalidQuery},
+		{"zero target", ClaimRequirements{Resources: map[string]float64{"cpu": 0}}, ErrInvalidQuery},
+		{"negative target", ClaimRequirements{Resources: map[string]float64{"cpu": -1}}, ErrInvalidQuery},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.requirements.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("got error %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func newClaimTestNode(id string, cpu, memory float64) Node {
+	return Node{
+		Id:        NodeId{Value: id},
+		Resources: map[string]float64{"cpu": cpu, "memory": memory},
+	}
+}
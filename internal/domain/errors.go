@@ -3,7 +3,24 @@ package domain
 import "errors"
 
 var (
-	ErrNodeClaimed = errors.New("node has been already claimed and is not in the node pool anymore")
-	ErrServerSide  = errors.New("an unexpected server-side error occurred")
-	ErrForbidden   = errors.New("you are not authorized to perform this operation")
+	ErrNodeNotFound            = errors.New("node not found")
+	ErrInvalidNodeId           = errors.New("node id is invalid")
+	ErrNodeClaimed             = errors.New("node has been already claimed and is not in the node pool anymore")
+	ErrNodeExists              = errors.New("node already exists")
+	ErrServerSide              = errors.New("an unexpected server-side error occurred")
+	ErrForbidden               = errors.New("you are not authorized to perform this operation")
+	ErrResourceVersionMismatch = errors.New("node has been modified since the given resource version")
+	ErrInvalidPageToken        = errors.New("page token is invalid or has expired")
+	ErrInvalidPageSize         = errors.New("page size must not be negative")
+	ErrRevisionCompacted       = errors.New("requested revision has been compacted")
+	ErrInvalidQuery            = errors.New("query is invalid")
+	ErrInvalidLabelValue       = errors.New("label value is invalid")
+	ErrNodeTooLarge            = errors.New("node has too many labels, string set members and resources")
+	ErrInsufficientNodes       = errors.New("matching nodes don't meet the requested node count or resources")
+	ErrOperationNotFound       = errors.New("operation not found")
+	ErrOperationModified       = errors.New("operation has been modified concurrently")
+	ErrOperationFinished       = errors.New("operation has already finished")
+	ErrOperationOwned          = errors.New("operation is being run by another owner")
+	ErrShuttingDown            = errors.New("service is shutting down")
+	ErrOrgClusterModified      = errors.New("org cluster record has been modified concurrently")
 )
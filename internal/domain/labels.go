@@ -1,9 +1,16 @@
 package domain
 
 import (
+	"cmp"
 	"errors"
+	"fmt"
 	"math"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
 )
 
 type Label interface {
@@ -34,6 +41,55 @@ func NewStringLabel(key string, value string) Label {
 	}
 }
 
+func NewInt64Label(key string, value int64) Label {
+	return &int64Label{
+		key:   key,
+		value: value,
+	}
+}
+
+// NewTimestampLabel keeps the time in UTC
+func NewTimestampLabel(key string, value time.Time) Label {
+	return &timestampLabel{
+		key:   key,
+		value: value.UTC(),
+	}
+}
+
+func NewSemverLabel(key string, value Version) Label {
+	return &semverLabel{
+		key:   key,
+		value: value,
+	}
+}
+
+// NewStringSetLabel sorts the values and drops duplicates and empty strings
+func NewStringSetLabel(key string, values []string) Label {
+	set := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" {
+			set = append(set, value)
+		}
+	}
+	sort.Strings(set)
+	return &stringSetLabel{
+		key:    key,
+		values: slices.Compact(set),
+	}
+}
+
+// MaxStringSetSize bounds the members of a string set label, each member has its own index key
+// and all keys of a label are replaced in a single etcd txn, the members also count against MaxNodeIndexKeys
+const MaxStringSetSize = 32
+
+// ValidateLabel rejects string sets with more than MaxStringSetSize members
+func ValidateLabel(label Label) error {
+	if set, ok := label.Value().([]string); ok && len(set) > MaxStringSetSize {
+		return fmt.Errorf("%w: string set %s has %d members, at most %d are allowed", ErrInvalidLabelValue, label.Key(), len(set), MaxStringSetSize)
+	}
+	return nil
+}
+
 type boolLabel struct {
 	key   string
 	value bool
@@ -117,50 +173,180 @@ func (s stringLabel) Compare(value string) ([]ComparisonResult, error) {
 	return []ComparisonResult{CompResNeq}, nil
 }
 
+type int64Label struct {
+	key   string
+	value int64
+}
+
+func (i int64Label) Key() string {
+	return i.key
+}
+
+func (i int64Label) Value() interface{} {
+	return i.value
+}
+
+func (i int64Label) Compare(value string) ([]ComparisonResult, error) {
+	refValue, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, errors.New("incomparable")
+	}
+	return orderedComparison(cmp.Compare(i.value, refValue)), nil
+}
+
+func (i int64Label) StringValue() string {
+	return strconv.FormatInt(i.value, 10)
+}
+
+type timestampLabel struct {
+	key   string
+	value time.Time
+}
+
+func (t timestampLabel) Key() string {
+	return t.key
+}
+
+func (t timestampLabel) Value() interface{} {
+	return t.value
+}
+
+// Compare accepts RFC3339 timestamps, with or without fractional seconds
+func (t timestampLabel) Compare(value string) ([]ComparisonResult, error) {
+	refValue, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.New("incomparable")
+	}
+	return orderedComparison(t.value.Compare(refValue)), nil
+}
+
+func (t timestampLabel) StringValue() string {
+	return t.value.Format(time.RFC3339Nano)
+}
+
+type semverLabel struct {
+	key   string
+	value Version
+}
+
+func (s semverLabel) Key() string {
+	return s.key
+}
+
+func (s semverLabel) Value() interface{} {
+	return s.value
+}
+
+// Compare orders versions by semver precedence, so versions differing only in build metadata are equal
+func (s semverLabel) Compare(value string) ([]ComparisonResult, error) {
+	refValue, err := ParseVersion(value)
+	if err != nil {
+		return nil, errors.New("incomparable")
+	}
+	return orderedComparison(s.value.Compare(refValue)), nil
+}
+
+func (s semverLabel) StringValue() string {
+	return s.value.String()
+}
+
+// stringSetLabel holds multiple values, it equals every value it contains
+type stringSetLabel struct {
+	key    string
+	values []string
+}
+
+func (s stringSetLabel) Key() string {
+	return s.key
+}
+
+func (s stringSetLabel) Value() interface{} {
+	return slices.Clone(s.values)
+}
+
+func (s stringSetLabel) Compare(value string) ([]ComparisonResult, error) {
+	if _, found := slices.BinarySearch(s.values, value); found {
+		return []ComparisonResult{CompResEq}, nil
+	}
+	return []ComparisonResult{CompResNeq}, nil
+}
+
+func (s stringSetLabel) StringValue() string {
+	return strings.Join(s.values, ",")
+}
+
+// orderedComparison turns the result of a three-way comparison into the results returned by Label.Compare
+func orderedComparison(result int) []ComparisonResult {
+	switch {
+	case result > 0:
+		return []ComparisonResult{CompResGt, CompResNeq}
+	case result < 0:
+		return []ComparisonResult{CompResLt, CompResNeq}
+	default:
+		return []ComparisonResult{CompResEq}
+	}
+}
+
 type ComparisonResult int8
 
+// besides the results returned by Label.Compare,
+// comparison results double as selector operators
 const (
 	CompResEq = iota
 	CompResNeq
 	CompResGt
 	CompResLt
+	CompResGe
+	CompResLe
+	CompResIn
+	CompResNotIn
+	CompResExists
+	CompResDoesNotExist
+	CompResPrefix
+	CompResRegex
 )
 
+var compResStrings = map[ComparisonResult]string{
+	CompResEq:           eqString,
+	CompResNeq:          neqString,
+	CompResGt:           gtString,
+	CompResLt:           ltString,
+	CompResGe:           geString,
+	CompResLe:           leString,
+	CompResIn:           inString,
+	CompResNotIn:        notInString,
+	CompResExists:       existsString,
+	CompResDoesNotExist: doesNotExistString,
+	CompResPrefix:       prefixString,
+	CompResRegex:        regexString,
+}
+
 func (c ComparisonResult) String() string {
-	switch c {
-	case CompResEq:
-		return eqString
-	case CompResNeq:
-		return neqString
-	case CompResGt:
-		return gtString
-	case CompResLt:
-		return ltString
-	default:
-		return ""
-	}
+	return compResStrings[c]
 }
 
 func NewCompResultFromString(value string) (ComparisonResult, error) {
-	switch value {
-	case eqString:
-		return CompResEq, nil
-	case neqString:
-		return CompResNeq, nil
-	case ltString:
-		return CompResLt, nil
-	case gtString:
-		return CompResGt, nil
-	default:
-		return CompResEq, errors.New("invalid string")
+	for compRes, compResString := range compResStrings {
+		if compResString == value {
+			return compRes, nil
+		}
 	}
+	return CompResEq, errors.New("invalid string")
 }
 
 const (
-	eqString  = "="
-	neqString = "!="
-	ltString  = "<"
-	gtString  = ">"
+	eqString           = "="
+	neqString          = "!="
+	ltString           = "<"
+	gtString           = ">"
+	geString           = ">="
+	leString           = "<="
+	inString           = "in"
+	notInString        = "notin"
+	existsString       = "exists"
+	doesNotExistString = "doesnotexist"
+	prefixString       = "prefix"
+	regexString        = "regex"
 
 	defaultCompRes = CompResEq
 )
@@ -171,9 +357,10 @@ type LabelMarshaller interface {
 }
 
 type PutLabelReq struct {
-	NodeId NodeId
-	Org    string
-	Label  Label
+	NodeId          NodeId
+	Org             string
+	Label           Label
+	ResourceVersion int64
 }
 
 type PutLabelResp struct {
@@ -181,9 +368,10 @@ type PutLabelResp struct {
 }
 
 type DeleteLabelReq struct {
-	NodeId   NodeId
-	Org      string
-	LabelKey string
+	NodeId          NodeId
+	Org             string
+	LabelKey        string
+	ResourceVersion int64
 }
 
 type DeleteLabelResp struct {
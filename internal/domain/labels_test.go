@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestValidateLabel(t *testing.T) {
+	members := func(n int) []string {
+		values := make([]string, 0, n)
+		for i := 0; i < n; i++ {
+			values = append(values, strconv.Itoa(i))
+		}
+		return values
+	}
+	tests := []struct {
+		name  string
+		label Label
+		err   error
+	}{
+		{"String", NewStringLabel("zone", "a"), nil},
+		{"EmptySet", NewStringSetLabel("caps", nil), nil},
+		{"LargestSet", NewStringSetLabel("caps", members(MaxStringSetSize)), nil},
+		// duplicates are dropped before counting
+		{"LargestSetWithDuplicates", NewStringSetLabel("caps", append(members(MaxStringSetSize), "0")), nil},
+		{"TooLargeSet", NewStringSetLabel("caps", members(MaxStringSetSize+1)), ErrInvalidLabelValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateLabel(tt.label); !errors.Is(err, tt.err) {
+				t.Errorf("got error %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+type NodeStatus int8
+
+const (
+	NodeStatusUnknown NodeStatus = iota
+	NodeStatusReady
+	NodeStatusNotReady
+)
+
+func (s NodeStatus) String() string {
+	switch s {
+	case NodeStatusReady:
+		return "Ready"
+	case NodeStatusNotReady:
+		return "NotReady"
+	default:
+		return "Unknown"
+	}
+}
+
+type NodeLiveness struct {
+	Status   NodeStatus
+	LastSeen time.Time
+}
+
+// nodes that have never sent a heartbeat are left out of the result,
+// Heartbeat returns ErrNodeNotFound if the node is no longer stored as given
+type NodeLivenessRepo interface {
+	Heartbeat(node Node, ttl time.Duration) error
+	Get(nodeIds []NodeId) (map[NodeId]NodeLiveness, error)
+	Delete(nodeId NodeId) error
+}
+
+// HeartbeatReq is sent by agents periodically, only the agent holding the node's registration token can send it
+type HeartbeatReq struct {
+	NodeId            NodeId
+	RegistrationToken string
+}
@@ -1,17 +1,83 @@
 package domain
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
 type Node struct {
 	Id          NodeId
 	Org         string
 	Labels      []Label
 	Resources   map[string]float64
 	BindAddress string
+	// RegistrationTokenHash is the hash of the token issued to the node's agent when it was first registered
+	RegistrationTokenHash []byte
+	// ResourceVersion changes on every modification of the node,
+	// it is set by the repo and never stored with the node itself
+	ResourceVersion int64
+	// Status and LastSeen are tracked by node heartbeats
+	Status   NodeStatus
+	LastSeen time.Time
 }
 
 func (n Node) Claimed() bool {
 	return len(n.Org) > 0
 }
 
+// MaxNodeIndexKeys bounds the index keys of a node, there is one per label, string set member and resource,
+// claiming or releasing a node deletes and puts all of them along with the node itself in a single etcd txn,
+// which has to stay within etcd's default limit of 128 ops
+const MaxNodeIndexKeys = 63
+
+// IndexKeys counts the keys the node's labels and resources are indexed under, an empty string set has one
+func (n Node) IndexKeys() int {
+	keys := len(n.Resources)
+	for _, label := range n.Labels {
+		if set, ok := label.Value().([]string); ok && len(set) > 0 {
+			keys += len(set)
+		} else {
+			keys++
+		}
+	}
+	return keys
+}
+
+// ValidateNode rejects nodes with more than MaxNodeIndexKeys index keys
+func ValidateNode(node Node) error {
+	if keys := node.IndexKeys(); keys > MaxNodeIndexKeys {
+		return fmt.Errorf("%w: node %s has %d index keys, at most %d are allowed", ErrNodeTooLarge, node.Id.Value, keys, MaxNodeIndexKeys)
+	}
+	return nil
+}
+
+// WithLabel returns the node with the label added or replacing the one with the same key
+func (n Node) WithLabel(label Label) Node {
+	labels := make([]Label, 0, len(n.Labels)+1)
+	for _, nodeLabel := range n.Labels {
+		if nodeLabel.Key() != label.Key() {
+			labels = append(labels, nodeLabel)
+		}
+	}
+	n.Labels = append(labels, label)
+	return n
+}
+
+func (n Node) Label(key string) (Label, bool) {
+	for _, label := range n.Labels {
+		if label.Key() == key {
+			return label, true
+		}
+	}
+	return nil, false
+}
+
 type NodeId struct {
 	Value string
 }
@@ -20,21 +86,246 @@ type Query []Selector
 
 type Selector struct {
 	LabelKey string
+	// if set, the selector targets the node resource instead of a label,
+	// resource values are compared the same way as float64 labels
+	Resource string
 	ShouldBe ComparisonResult
 	Value    string
+	// Values are compared against by the in and notin operators
+	Values []string
+	// a selector with a group ignores the label fields and matches according to the group
+	Group *SelectorGroup
+}
+
+type GroupOp int8
+
+const (
+	GroupAnd GroupOp = iota
+	GroupOr
+	// GroupNot negates the conjunction of its selectors
+	GroupNot
+)
+
+var groupOpStrings = map[GroupOp]string{
+	GroupAnd: "and",
+	GroupOr:  "or",
+	GroupNot: "not",
+}
+
+func (o GroupOp) String() string {
+	return groupOpStrings[o]
+}
+
+func NewGroupOpFromString(value string) (GroupOp, error) {
+	for op, opString := range groupOpStrings {
+		if opString == value {
+			return op, nil
+		}
+	}
+	return GroupAnd, errors.New("invalid string")
+}
+
+type SelectorGroup struct {
+	Op        GroupOp
+	Selectors []Selector
+}
+
+// Matches evaluates the query against the node labels the same way
+// repos evaluate it against their label index
+func (q Query) Matches(node Node) bool {
+	for _, selector := range q {
+		if !selector.Matches(node) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate reports selectors that can't be evaluated, such as empty groups or invalid regular expressions
+func (q Query) Validate() error {
+	for _, selector := range q {
+		if selector.Group == nil {
+			if selector.LabelKey != "" && selector.Resource != "" {
+				return ErrInvalidQuery
+			}
+			if _, err := selector.Matcher(); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, ok := groupOpStrings[selector.Group.Op]; !ok || len(selector.Group.Selectors) == 0 {
+			return ErrInvalidQuery
+		}
+		if err := Query(selector.Group.Selectors).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s Selector) Matches(node Node) bool {
+	if s.Group != nil {
+		return s.Group.Matches(node)
+	}
+	matches, err := s.Matcher()
+	if err != nil {
+		return false
+	}
+	if s.Resource != "" {
+		if value, ok := node.Resources[s.Resource]; ok {
+			return matches(NewFloat64Label(s.Resource, value))
+		}
+		return s.ShouldBe == CompResDoesNotExist
+	}
+	if label, ok := node.Label(s.LabelKey); ok {
+		return matches(label)
+	}
+	return s.ShouldBe == CompResDoesNotExist
+}
+
+func (g SelectorGroup) Matches(node Node) bool {
+	switch g.Op {
+	case GroupOr:
+		for _, selector := range g.Selectors {
+			if selector.Matches(node) {
+				return true
+			}
+		}
+		return false
+	case GroupNot:
+		return !Query(g.Selectors).Matches(node)
+	default:
+		return Query(g.Selectors).Matches(node)
+	}
+}
+
+// Matcher returns a function evaluating the selector against a label with the selector's key,
+// nodes without such a label only match the doesnotexist operator
+func (s Selector) Matcher() (func(label Label) bool, error) {
+	switch s.ShouldBe {
+	case CompResExists:
+		return func(Label) bool { return true }, nil
+	case CompResDoesNotExist:
+		return func(Label) bool { return false }, nil
+	case CompResGe, CompResLe:
+		var strict ComparisonResult = CompResGt
+		if s.ShouldBe == CompResLe {
+			strict = CompResLt
+		}
+		return func(label Label) bool {
+			cmpResult, err := label.Compare(s.Value)
+			return err == nil && (slices.Contains(cmpResult, CompResEq) || slices.Contains(cmpResult, strict))
+		}, nil
+	case CompResIn, CompResNotIn:
+		if len(s.Values) == 0 {
+			return nil, ErrInvalidQuery
+		}
+		return func(label Label) bool {
+			in := false
+			for _, value := range s.Values {
+				cmpResult, err := label.Compare(value)
+				if err == nil && slices.Contains(cmpResult, CompResEq) {
+					in = true
+					break
+				}
+			}
+			return in == (s.ShouldBe == CompResIn)
+		}, nil
+	case CompResPrefix:
+		return func(label Label) bool {
+			return slices.ContainsFunc(stringValues(label), func(value string) bool {
+				return strings.HasPrefix(value, s.Value)
+			})
+		}, nil
+	case CompResRegex:
+		re, err := regexp.Compile(s.Value)
+		if err != nil {
+			return nil, ErrInvalidQuery
+		}
+		return func(label Label) bool {
+			return slices.ContainsFunc(stringValues(label), re.MatchString)
+		}, nil
+	default:
+		return func(label Label) bool {
+			cmpResult, err := label.Compare(s.Value)
+			return err == nil && slices.Contains(cmpResult, s.ShouldBe)
+		}, nil
+	}
+}
+
+// stringValues returns the value of a string label or the values of a string set label,
+// prefix and regex selectors match labels with any matching value
+func stringValues(label Label) []string {
+	switch value := label.Value().(type) {
+	case string:
+		return []string{value}
+	case []string:
+		return value
+	default:
+		return nil
+	}
 }
 
 type NodeRepo interface {
 	Put(node Node) error
+	// Create stores the node only if it isn't stored under the same key yet, ErrNodeExists is returned otherwise
+	Create(node Node) error
 	Get(nodeId NodeId, org string) (*Node, error)
+	// Find looks the node up in the pool and in all orgs
+	Find(nodeId NodeId) (*Node, error)
+	// Update replaces labels, resources and the bind address of prev with the ones of node,
+	// the node keeps its id and org
+	Update(prev Node, node Node) (*Node, error)
+	// Delete fails with ErrResourceVersionMismatch if the node has been modified, claimed or released since it was read,
+	// a zero resource version skips the check
 	Delete(node Node) error
-	ListNodePool() ([]Node, error)
-	ListOrgOwnedNodes(org string) ([]Node, error)
-	QueryNodePool(query Query) ([]Node, error)
-	QueryOrgOwnedNodes(query Query, org string) ([]Node, error)
+	Claim(nodeId NodeId, org string) (*Node, error)
+	Release(nodeId NodeId, org string) (*Node, error)
+	// list and query methods return the token of the next page,
+	// or an empty token if there are no more nodes
+	ListNodePool(page Page) ([]Node, string, error)
+	ListOrgOwnedNodes(org string, page Page) ([]Node, string, error)
+	QueryNodePool(query Query, page Page) ([]Node, string, error)
+	QueryOrgOwnedNodes(query Query, org string, page Page) ([]Node, string, error)
 	PutLabel(node Node, label Label) (*Node, error)
 	DeleteLabel(node Node, labelKey string) (*Node, error)
-	ListAllNodes() ([]Node, error)
+	ListAllNodes(page Page) ([]Node, string, error)
+	// Watch calls the handler for every change of pool nodes (empty org) or org owned nodes,
+	// starting from the given revision (zero means now), until ctx is done or the handler fails
+	Watch(ctx context.Context, org string, revision int64, handler func(event NodeEvent) error) error
+}
+
+// Page selects a part of a list or query result,
+// zero Size means that all remaining nodes are returned
+// OrderBy and Limit only apply to queries, they sort the whole filtered result and cap it before it is paged
+type Page struct {
+	Size    int64
+	Token   string
+	OrderBy []OrderBy
+	Limit   int64
+	// Filter drops nodes before the page is filled, e.g. the ones that aren't ready,
+	// it must keep the order of the nodes it is given
+	Filter func(nodes []Node) ([]Node, error)
+}
+
+// Sorted reports whether the result has to be sorted and capped as a whole before paging
+func (p Page) Sorted() bool {
+	return len(p.OrderBy) > 0 || p.Limit > 0
+}
+
+func (p Page) Validate() error {
+	if p.Size < 0 {
+		return ErrInvalidPageSize
+	}
+	if p.Limit < 0 {
+		return ErrInvalidQuery
+	}
+	for _, order := range p.OrderBy {
+		if err := order.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 type NodeMarshaller interface {
@@ -60,42 +351,144 @@ type GetFromOrgResp struct {
 }
 
 type ClaimOwnershipReq struct {
-	Query Query
-	Org   string
+	Query           Query
+	Org             string
+	IncludeNotReady bool
+	Requirements    ClaimRequirements
+	// DryRun only selects the nodes, nothing is claimed
+	DryRun bool
 }
 
+// ClaimOwnershipResp lists the nodes selected for the claim,
+// the claim itself is carried out by the operation in the background
 type ClaimOwnershipResp struct {
 	Nodes []Node
+	// not set by dry runs
+	Operation *Operation
+	// set by dry runs only, the quotas the org's default namespace would get and the address the nodes would join
+	DryRun          bool
+	NamespaceQuotas map[string]float64
+	JoinAddress     string
+}
+
+// ReleaseNodesReq returns org-owned nodes to the pool,
+// explicitly listed node ids take precedence over the query
+type ReleaseNodesReq struct {
+	Query   Query
+	NodeIds []NodeId
+	Org     string
+}
+
+// ReleaseNodesResp returns the operation releasing the nodes in the background
+type ReleaseNodesResp struct {
+	Operation Operation
+}
+
+// DeregisterNodeReq removes a node from the pool or, if org is set, from the org
+type DeregisterNodeReq struct {
+	Id  NodeId
+	Org string
+}
+
+type DeregisterNodeResp struct {
+	Node Node
 }
 
 type ListNodePoolReq struct {
+	Page Page
 }
 
 type ListNodePoolResp struct {
-	Nodes []Node
+	Nodes         []Node
+	NextPageToken string
 }
 
 type ListOrgOwnedNodesReq struct {
-	Org string
+	Org  string
+	Page Page
 }
 
 type ListOrgOwnedNodesResp struct {
-	Nodes []Node
+	Nodes         []Node
+	NextPageToken string
+}
+
+type ListAllNodesReq struct {
+	Page Page
+}
+
+type ListAllNodesResp struct {
+	Nodes         []Node
+	NextPageToken string
 }
 
 type QueryNodePoolReq struct {
-	Query Query
+	Query           Query
+	Page            Page
+	IncludeNotReady bool
 }
 
 type QueryNodePoolResp struct {
-	Nodes []Node
+	Nodes         []Node
+	NextPageToken string
 }
 
 type QueryOrgOwnedNodesReq struct {
 	Query Query
 	Org   string
+	Page  Page
 }
 
 type QueryOrgOwnedNodesResp struct {
-	Nodes []Node
+	Nodes         []Node
+	NextPageToken string
+}
+
+type NodeEventType int8
+
+const (
+	NodeAdded NodeEventType = iota
+	NodeModified
+	NodeDeleted
+)
+
+type NodeEvent struct {
+	Type NodeEventType
+	// the latest state of the node, for deleted nodes the last state before deletion
+	Node Node
+	// the previous state of modified nodes, if known
+	PrevNode *Node
+	Revision int64
+}
+
+// Filter narrows the event down to nodes matching the query,
+// so a node that stops matching is reported as deleted and one that starts matching as added
+func (e NodeEvent) Filter(query Query) (NodeEvent, bool) {
+	if len(query) == 0 {
+		return e, true
+	}
+	switch e.Type {
+	case NodeModified:
+		prevMatches := e.PrevNode == nil || query.Matches(*e.PrevNode)
+		currMatches := query.Matches(e.Node)
+		switch {
+		case prevMatches && currMatches:
+			return e, true
+		case currMatches:
+			e.Type = NodeAdded
+			return e, true
+		case prevMatches:
+			e.Type = NodeDeleted
+			return e, true
+		}
+		return e, false
+	default:
+		return e, query.Matches(e.Node)
+	}
+}
+
+type WatchNodesReq struct {
+	Org      string
+	Query    Query
+	Revision int64
 }
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestValidateNode(t *testing.T) {
+	resources := func(n int) map[string]float64 {
+		values := make(map[string]float64, n)
+		for i := 0; i < n; i++ {
+			values[strconv.Itoa(i)] = float64(i)
+		}
+		return values
+	}
+	largestSet := make([]string, 0, MaxStringSetSize)
+	for i := 0; i < MaxStringSetSize; i++ {
+		largestSet = append(largestSet, strconv.Itoa(i))
+	}
+	tests := []struct {
+		name string
+		node Node
+		err  error
+	}{
+		{"Empty", Node{}, nil},
+		{"Largest", Node{Resources: resources(MaxNodeIndexKeys)}, nil},
+		{"TooManyResources", Node{Resources: resources(MaxNodeIndexKeys + 1)}, ErrNodeTooLarge},
+		// every member of a string set counts against the same budget
+		{"LargestWithSet", Node{Labels: []Label{NewStringSetLabel("caps", largestSet)}, Resources: resources(MaxNodeIndexKeys - MaxStringSetSize)}, nil},
+		{"TooLargeWithSet", Node{Labels: []Label{NewStringSetLabel("caps", largestSet)}, Resources: resources(MaxNodeIndexKeys - MaxStringSetSize + 1)}, ErrNodeTooLarge},
+		// an empty set is indexed under a single key
+		{"TooLargeWithEmptySet", Node{Labels: []Label{NewStringSetLabel("caps", nil)}, Resources: resources(MaxNodeIndexKeys)}, ErrNodeTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateNode(tt.node); !errors.Is(err, tt.err) {
+				t.Errorf("got error %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
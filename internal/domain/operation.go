@@ -0,0 +1,275 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// claims and releases are carried out in the background as operations,
+// each step is recorded before moving on to the next one,
+// so an operation interrupted by a restart can be resumed and an aborted claim compensated
+
+type OperationKind int8
+
+const (
+	OperationClaim OperationKind = iota
+	OperationRelease
+)
+
+var operationKindStrings = map[OperationKind]string{
+	OperationClaim:   "Claim",
+	OperationRelease: "Release",
+}
+
+func (k OperationKind) String() string {
+	return operationKindStrings[k]
+}
+
+func NewOperationKindFromString(value string) (OperationKind, error) {
+	for kind, kindString := range operationKindStrings {
+		if kindString == value {
+			return kind, nil
+		}
+	}
+	return 0, ErrServerSide
+}
+
+type OperationStep int8
+
+const (
+	// OperationStepNone means no step has completed yet
+	OperationStepNone OperationStep = iota
+	// OperationStepClaim moves the node from the pool to the org
+	OperationStepClaim
+	// OperationStepRelate creates the org-node inheritance relation in oort
+	OperationStepRelate
+	// OperationStepQuotas sets the quotas of the org's default namespace in meridian, it is done once for all nodes
+	OperationStepQuotas
+	// OperationStepJoin joins the node to the org's gravity cluster
+	OperationStepJoin
+	// OperationStepRelease moves the node from the org back to the pool
+	OperationStepRelease
+	// OperationStepUnrelate deletes the org-node inheritance relation in oort
+	OperationStepUnrelate
+	// OperationStepLeave removes the node from the org's gravity cluster
+	OperationStepLeave
+)
+
+var operationStepStrings = map[OperationStep]string{
+	OperationStepNone:     "None",
+	OperationStepClaim:    "Claim",
+	OperationStepRelate:   "Relate",
+	OperationStepQuotas:   "Quotas",
+	OperationStepJoin:     "Join",
+	OperationStepRelease:  "Release",
+	OperationStepUnrelate: "Unrelate",
+	OperationStepLeave:    "Leave",
+}
+
+func (s OperationStep) String() string {
+	return operationStepStrings[s]
+}
+
+func NewOperationStepFromString(value string) (OperationStep, error) {
+	for step, stepString := range operationStepStrings {
+		if stepString == value {
+			return step, nil
+		}
+	}
+	return 0, ErrServerSide
+}
+
+type OperationState int8
+
+const (
+	OperationRunning OperationState = iota
+	// OperationCompensating undoes the completed steps of all nodes of an aborted claim
+	OperationCompensating
+	OperationSucceeded
+	OperationFailed
+	OperationCancelled
+)
+
+var operationStateStrings = map[OperationState]string{
+	OperationRunning:      "Running",
+	OperationCompensating: "Compensating",
+	OperationSucceeded:    "Succeeded",
+	OperationFailed:       "Failed",
+	OperationCancelled:    "Cancelled",
+}
+
+func (s OperationState) String() string {
+	return operationStateStrings[s]
+}
+
+func NewOperationStateFromString(value string) (OperationState, error) {
+	for state, stateString := range operationStateStrings {
+		if stateString == value {
+			return state, nil
+		}
+	}
+	return 0, ErrServerSide
+}
+
+type NodeOperationStatus int8
+
+const (
+	NodeOperationPending NodeOperationStatus = iota
+	NodeOperationClaimed
+	NodeOperationReleased
+	// NodeOperationFailed means the node couldn't be claimed or released, there was nothing to undo
+	NodeOperationFailed
+	// NodeOperationCompensated means the node was claimed, but its completed steps have been undone
+	NodeOperationCompensated
+	// NodeOperationCompensationFailed means undoing one of the node's completed steps kept failing,
+	// the node is left as it is with the steps up to Step completed
+	NodeOperationCompensationFailed
+	// NodeOperationCancelled means the operation was cancelled before it got to the node
+	NodeOperationCancelled
+)
+
+var nodeOperationStatusStrings = map[NodeOperationStatus]string{
+	NodeOperationPending:            "Pending",
+	NodeOperationClaimed:            "Claimed",
+	NodeOperationReleased:           "Released",
+	NodeOperationFailed:             "Failed",
+	NodeOperationCompensated:        "Compensated",
+	NodeOperationCompensationFailed: "CompensationFailed",
+	NodeOperationCancelled:          "Cancelled",
+}
+
+func (s NodeOperationStatus) String() string {
+	return nodeOperationStatusStrings[s]
+}
+
+func NewNodeOperationStatusFromString(value string) (NodeOperationStatus, error) {
+	for status, statusString := range nodeOperationStatusStrings {
+		if statusString == value {
+			return status, nil
+		}
+	}
+	return 0, ErrServerSide
+}
+
+// OperationNode is the progress of a single node,
+// Step is the last step completed for the node and the first one to undo when compensating a claim
+type OperationNode struct {
+	NodeId   NodeId
+	Step     OperationStep
+	Status   NodeOperationStatus
+	Attempts int32
+	Err      string
+}
+
+type Operation struct {
+	Id    string
+	Org   string
+	Kind  OperationKind
+	State OperationState
+	// Step is the last step completed for all nodes
+	Step  OperationStep
+	Nodes []OperationNode
+	// Requirements of a claim, kept so they can be checked after a restart
+	Requirements ClaimRequirements
+	JoinAddress  string
+	Err          string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// CancelRequested is set by CancelOperation, the operation stops at the next step
+	CancelRequested bool
+	// Revision is the storage revision the operation was read at, updates fail if it has changed since
+	Revision int64
+}
+
+func (o Operation) Finished() bool {
+	return o.State == OperationSucceeded || o.State == OperationFailed || o.State == OperationCancelled
+}
+
+// Node returns the progress of the node with the given id
+func (o *Operation) Node(nodeId NodeId) (*OperationNode, bool) {
+	for i := range o.Nodes {
+		if o.Nodes[i].NodeId == nodeId {
+			return &o.Nodes[i], true
+		}
+	}
+	return nil, false
+}
+
+// NodesWithStatus returns the ids of nodes with the given status
+func (o Operation) NodesWithStatus(status NodeOperationStatus) []NodeId {
+	nodeIds := make([]NodeId, 0)
+	for _, node := range o.Nodes {
+		if node.Status == status {
+			nodeIds = append(nodeIds, node.NodeId)
+		}
+	}
+	return nodeIds
+}
+
+type OperationRepo interface {
+	// Put creates the operation if its revision is 0 and updates it otherwise,
+	// ErrOperationModified is returned if it has been updated since it was read
+	Put(operation Operation) (*Operation, error)
+	Get(id, org string) (*Operation, error)
+	// List returns a page of the org's operations in descending id order,
+	// operation ids are time ordered, so the most recent operations come first
+	List(org string, page Page) ([]Operation, string, error)
+	// ListUnfinished returns the operations of all orgs that haven't finished yet
+	ListUnfinished() ([]Operation, error)
+	// HasUnfinished reports whether any of the org's operations hasn't finished yet
+	HasUnfinished(org string) (bool, error)
+	// Cancel records the cancellation request apart from the operation, so it doesn't conflict with its updates
+	Cancel(id, org string) error
+	// Own makes the caller the only one running the operation, ErrOperationOwned is returned if someone else owns it,
+	// the returned context is done once ctx is done, release is called or the ownership can't be kept up,
+	// the ownership ends with release or lapses shortly after the caller stops without calling it
+	Own(ctx context.Context, id, org string) (owned context.Context, release func(), err error)
+}
+
+type OperationMarshaller interface {
+	Marshal(operation Operation) ([]byte, error)
+	Unmarshal(operationMarshalled []byte) (*Operation, error)
+}
+
+// ClaimSagaUnmarshaller reads the sagas claims were stored as before they became claim operations
+type ClaimSagaUnmarshaller interface {
+	Unmarshal(sagaMarshalled []byte) (*Operation, error)
+}
+
+type GetOperationReq struct {
+	Id  string
+	Org string
+}
+
+type GetOperationResp struct {
+	Operation Operation
+}
+
+type ListOperationsReq struct {
+	Org  string
+	Page Page
+}
+
+type ListOperationsResp struct {
+	Operations    []Operation
+	NextPageToken string
+}
+
+type CancelOperationReq struct {
+	Id  string
+	Org string
+}
+
+type CancelOperationResp struct {
+	Operation Operation
+}
+
+type GetClaimSagaReq struct {
+	Id  string
+	Org string
+}
+
+// GetClaimSagaResp holds the claim operation, it is reported as a saga to the clients asking for one
+type GetClaimSagaResp struct {
+	Operation Operation
+}
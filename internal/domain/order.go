@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"cmp"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// OrderBy sorts nodes by a label or, if Resource is set, by a resource
+// labels of different types are ordered bool, float64, int64, timestamp, semver, string, string set,
+// nodes lacking the label or resource come last in both directions
+type OrderBy struct {
+	LabelKey   string
+	Resource   string
+	Descending bool
+}
+
+func (o OrderBy) Validate() error {
+	if (o.LabelKey == "") == (o.Resource == "") {
+		return ErrInvalidQuery
+	}
+	return nil
+}
+
+// Compare returns a negative number if a comes before b, a positive one if after and zero for ties
+func (o OrderBy) Compare(a, b Node) int {
+	var result int
+	var aOk, bOk bool
+	if o.Resource != "" {
+		var aValue, bValue float64
+		aValue, aOk = a.Resources[o.Resource]
+		bValue, bOk = b.Resources[o.Resource]
+		result = cmp.Compare(aValue, bValue)
+	} else {
+		var aLabel, bLabel Label
+		aLabel, aOk = a.Label(o.LabelKey)
+		bLabel, bOk = b.Label(o.LabelKey)
+		if aOk && bOk {
+			result = CompareLabelValues(aLabel, bLabel)
+		}
+	}
+	switch {
+	case !aOk && !bOk:
+		return 0
+	case !aOk:
+		return 1
+	case !bOk:
+		return -1
+	case o.Descending:
+		return -result
+	default:
+		return result
+	}
+}
+
+// SortNodes sorts by the first order, ties by the next one and finally by node id
+func SortNodes(nodes []Node, orderBy []OrderBy) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		for _, order := range orderBy {
+			if result := order.Compare(nodes[i], nodes[j]); result != 0 {
+				return result < 0
+			}
+		}
+		return nodes[i].Id.Value < nodes[j].Id.Value
+	})
+}
+
+// CompareLabelValues orders label values of the same type by value, and of different types by type
+func CompareLabelValues(a, b Label) int {
+	if result := cmp.Compare(labelTypeRank(a), labelTypeRank(b)); result != 0 {
+		return result
+	}
+	switch aValue := a.Value().(type) {
+	case bool:
+		bValue := b.Value().(bool)
+		if aValue == bValue {
+			return 0
+		}
+		if bValue {
+			return -1
+		}
+		return 1
+	case float64:
+		return cmp.Compare(aValue, b.Value().(float64))
+	case int64:
+		return cmp.Compare(aValue, b.Value().(int64))
+	case time.Time:
+		return aValue.Compare(b.Value().(time.Time))
+	case Version:
+		return aValue.Compare(b.Value().(Version))
+	case string:
+		return strings.Compare(aValue, b.Value().(string))
+	case []string:
+		return slices.Compare(aValue, b.Value().([]string))
+	default:
+		return 0
+	}
+}
+
+func labelTypeRank(label Label) int {
+	switch label.Value().(type) {
+	case bool:
+		return 0
+	case float64:
+		return 1
+	case int64:
+		return 2
+	case time.Time:
+		return 3
+	case Version:
+		return 4
+	case string:
+		return 5
+	case []string:
+		return 6
+	default:
+		return 7
+	}
+}
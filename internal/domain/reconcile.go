@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// oort and gravity can't be asked which relations and members magnetar has applied to them,
+// so what they last confirmed is recorded per org, the reconciler asserts again every relation and membership the org's nodes require,
+// which repairs those removed in oort or gravity directly, and uses the record to undo the ones nodes no longer require,
+// relations and members added in oort or gravity directly aren't recorded and are left as they are
+
+// OrgCluster is the state of an org's cluster in the external systems as last confirmed by them
+type OrgCluster struct {
+	Org string
+	// Quotas last set on the org's default namespace in meridian, nil if never set
+	Quotas map[string]float64
+	// Related nodes have an inheritance relation with the org in oort
+	Related []NodeId
+	// Joined nodes are members of the org's gravity cluster
+	Joined []NodeId
+	// Revision is the storage revision the record was read at, updates fail if it has changed since
+	Revision int64
+}
+
+// Recorded reports whether the record has been stored, orgs that claimed nodes before records were kept have none
+func (c OrgCluster) Recorded() bool {
+	return c.Revision > 0
+}
+
+func (c OrgCluster) Clone() OrgCluster {
+	c.Quotas = maps.Clone(c.Quotas)
+	c.Related = slices.Clone(c.Related)
+	c.Joined = slices.Clone(c.Joined)
+	return c
+}
+
+// Equal ignores the revision
+func (c OrgCluster) Equal(other OrgCluster) bool {
+	return c.Org == other.Org && (c.Quotas == nil) == (other.Quotas == nil) && maps.Equal(c.Quotas, other.Quotas) &&
+		slices.Equal(c.Related, other.Related) && slices.Equal(c.Joined, other.Joined)
+}
+
+func (c *OrgCluster) SetRelated(nodeId NodeId, related bool) {
+	c.Related = setNodeId(c.Related, nodeId, related)
+}
+
+func (c *OrgCluster) SetJoined(nodeId NodeId, joined bool) {
+	c.Joined = setNodeId(c.Joined, nodeId, joined)
+}
+
+func (c OrgCluster) IsRelated(nodeId NodeId) bool {
+	return slices.Contains(c.Related, nodeId)
+}
+
+func (c OrgCluster) IsJoined(nodeId NodeId) bool {
+	return slices.Contains(c.Joined, nodeId)
+}
+
+// setNodeId keeps the order of the ids, so that setting an id already present doesn't change the record
+func setNodeId(nodeIds []NodeId, nodeId NodeId, present bool) []NodeId {
+	if slices.Contains(nodeIds, nodeId) == present {
+		return nodeIds
+	}
+	if present {
+		return append(nodeIds, nodeId)
+	}
+	return slices.DeleteFunc(nodeIds, func(id NodeId) bool {
+		return id == nodeId
+	})
+}
+
+type OrgClusterRepo interface {
+	// Get returns an empty record for orgs that don't have one yet
+	Get(org string) (*OrgCluster, error)
+	// Put creates the record if its revision is 0 and updates it otherwise,
+	// ErrOrgClusterModified is returned if it has been updated since it was read
+	Put(cluster OrgCluster) (*OrgCluster, error)
+	ListOrgs() ([]string, error)
+}
+
+type OrgClusterMarshaller interface {
+	Marshal(cluster OrgCluster) ([]byte, error)
+	Unmarshal(clusterMarshalled []byte) (*OrgCluster, error)
+}
+
+type DriftKind int8
+
+const (
+	// DriftNamespaceMissing means the org's default namespace doesn't exist in meridian
+	DriftNamespaceMissing DriftKind = iota
+	// DriftQuotas means the namespace quotas meridian reports don't add up to the resources of the org's nodes
+	DriftQuotas
+	// DriftRelationMissing means an org node isn't recorded as related to the org in oort, or relating it again failed
+	DriftRelationMissing
+	// DriftStaleRelation means a node that left the org is still related to it in oort
+	DriftStaleRelation
+	// DriftNotJoined means an org node isn't recorded as a member of the org's gravity cluster, or joining it again failed
+	DriftNotJoined
+	// DriftStaleMember means a node that left the org is still a member of its gravity cluster
+	DriftStaleMember
+)
+
+var driftKindStrings = map[DriftKind]string{
+	DriftNamespaceMissing: "NamespaceMissing",
+	DriftQuotas:           "Quotas",
+	DriftRelationMissing:  "RelationMissing",
+	DriftStaleRelation:    "StaleRelation",
+	DriftNotJoined:        "NotJoined",
+	DriftStaleMember:      "StaleMember",
+}
+
+func (k DriftKind) String() string {
+	return driftKindStrings[k]
+}
+
+// Drift found while reconciling, Err is set if it couldn't be fixed
+type Drift struct {
+	Org    string
+	NodeId NodeId
+	Kind   DriftKind
+	Err    error
+}
+
+type ReconcileReport struct {
+	Reconciled []string
+	// Backfilled orgs had no record of their cluster, it was created from their nodes
+	Backfilled []string
+	// Skipped orgs have a claim or release in progress, or couldn't be checked
+	Skipped []string
+	Drift   []Drift
+}
@@ -1,11 +1,46 @@
 package domain
 
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+)
+
+// RegistrationReq can carry the identity of a node that has already been registered,
+// either as the node id issued before or as a machine id the node id is derived from,
+// along with the registration token issued with the node id
 type RegistrationReq struct {
-	Labels      []Label
-	Resources   map[string]float64
-	BindAddress string
+	Labels            []Label
+	Resources         map[string]float64
+	BindAddress       string
+	NodeId            string
+	MachineId         string
+	RegistrationToken string
 }
 
+// RegistrationResp carries the registration token only when the node is first registered
 type RegistrationResp struct {
-	NodeId string
+	NodeId            string
+	RegistrationToken string
+}
+
+// DeregistrationReq is sent by agents on shutdown, the node can be either in the pool or in an org,
+// only the agent holding the node's registration token can deregister it
+type DeregistrationReq struct {
+	NodeId            NodeId
+	RegistrationToken string
+}
+
+// HashRegistrationToken returns the hash the token is stored as
+func HashRegistrationToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
+
+// HasRegistrationToken reports whether the token is the one issued to the node's agent,
+// nodes registered before tokens were issued don't accept any
+func (n Node) HasRegistrationToken(token string) bool {
+	if len(n.RegistrationTokenHash) == 0 || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare(HashRegistrationToken(token), n.RegistrationTokenHash) == 1
 }
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version as specified by https://semver.org,
+// when parsing, a leading v is accepted, as in v1.2.3
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+	// Build metadata is kept, but ignored when comparing versions
+	Build string
+}
+
+func ParseVersion(value string) (Version, error) {
+	invalid := fmt.Errorf("%w: %q is not a semantic version", ErrInvalidLabelValue, value)
+	version := Version{}
+	rest := strings.TrimPrefix(value, "v")
+	rest, build, hasBuild := strings.Cut(rest, "+")
+	if hasBuild {
+		if !validIdentifiers(build, false) {
+			return Version{}, invalid
+		}
+		version.Build = build
+	}
+	core, prerelease, hasPrerelease := strings.Cut(rest, "-")
+	if hasPrerelease {
+		if !validIdentifiers(prerelease, true) {
+			return Version{}, invalid
+		}
+		version.Prerelease = strings.Split(prerelease, ".")
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return Version{}, invalid
+	}
+	numbers := []*uint64{&version.Major, &version.Minor, &version.Patch}
+	for i, part := range parts {
+		number, ok := parseNumericIdentifier(part)
+		if !ok {
+			return Version{}, invalid
+		}
+		*numbers[i] = number
+	}
+	return version, nil
+}
+
+// Compare returns a negative number if v precedes other, a positive one if it follows it and zero if they have the same precedence
+func (v Version) Compare(other Version) int {
+	if result := cmp.Compare(v.Major, other.Major); result != 0 {
+		return result
+	}
+	if result := cmp.Compare(v.Minor, other.Minor); result != 0 {
+		return result
+	}
+	if result := cmp.Compare(v.Patch, other.Patch); result != 0 {
+		return result
+	}
+	// a pre-release precedes the release
+	switch {
+	case len(v.Prerelease) == 0 && len(other.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(other.Prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.Prerelease) && i < len(other.Prerelease); i++ {
+		if result := compareIdentifiers(v.Prerelease[i], other.Prerelease[i]); result != 0 {
+			return result
+		}
+	}
+	return cmp.Compare(len(v.Prerelease), len(other.Prerelease))
+}
+
+func (v Version) String() string {
+	value := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		value += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if v.Build != "" {
+		value += "+" + v.Build
+	}
+	return value
+}
+
+// compareIdentifiers orders numeric identifiers numerically and before alphanumeric ones, which are ordered lexically
+func compareIdentifiers(a, b string) int {
+	aNumber, aNumeric := parseNumericIdentifier(a)
+	bNumber, bNumeric := parseNumericIdentifier(b)
+	switch {
+	case aNumeric && bNumeric:
+		return cmp.Compare(aNumber, bNumber)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// parseNumericIdentifier accepts numbers without leading zeros
+func parseNumericIdentifier(identifier string) (uint64, bool) {
+	if identifier == "" || (len(identifier) > 1 && identifier[0] == '0') {
+		return 0, false
+	}
+	for _, c := range identifier {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+	number, err := strconv.ParseUint(identifier, 10, 64)
+	return number, err == nil
+}
+
+// validIdentifiers checks dot separated identifiers of alphanumerics and hyphens,
+// numeric pre-release identifiers can't have leading zeros
+func validIdentifiers(identifiers string, prerelease bool) bool {
+	for _, identifier := range strings.Split(identifiers, ".") {
+		if identifier == "" {
+			return false
+		}
+		numeric := true
+		for _, c := range identifier {
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+				numeric = false
+			default:
+				return false
+			}
+		}
+		if prerelease && numeric {
+			if _, ok := parseNumericIdentifier(identifier); !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
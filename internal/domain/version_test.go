@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		err   error
+	}{
+		{"1.2.3", "1.2.3", nil},
+		{"v1.2.3", "1.2.3", nil},
+		{"5.15.0-91-generic", "5.15.0-91-generic", nil},
+		{"1.0.0-rc.1+build.5", "1.0.0-rc.1+build.5", nil},
+		{"1.0.0+20240101", "1.0.0+20240101", nil},
+		{"1.2", "", ErrInvalidLabelValue},
+		{"1.2.3.4", "", ErrInvalidLabelValue},
+		{"01.2.3", "", ErrInvalidLabelValue},
+		{"1.2.3-01", "", ErrInvalidLabelValue},
+		{"1.2.3-", "", ErrInvalidLabelValue},
+		{"1.2.3-a..b", "", ErrInvalidLabelValue},
+		{"1.2.3+b_1", "", ErrInvalidLabelValue},
+		{"latest", "", ErrInvalidLabelValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			version, err := ParseVersion(tt.value)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got error %v, want %v", err, tt.err)
+			}
+			if tt.err == nil && version.String() != tt.want {
+				t.Errorf("got %s, want %s", version, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	// in order of precedence, as listed by the semver spec
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"1.10.0",
+		"2.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, _ := ParseVersion(ordered[i])
+			b, _ := ParseVersion(ordered[j])
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if got := a.Compare(b); got != want {
+				t.Errorf("%s compared to %s: got %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+	a, _ := ParseVersion("1.0.0+build.1")
+	b, _ := ParseVersion("1.0.0+build.2")
+	if a.Compare(b) != 0 {
+		t.Error("expected build metadata to be ignored")
+	}
+}
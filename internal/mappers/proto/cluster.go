@@ -0,0 +1,48 @@
+package proto
+
+import (
+	"github.com/c12s/magnetar/internal/domain"
+	"github.com/c12s/magnetar/pkg/api"
+)
+
+func OrgClusterFromDomain(cluster domain.OrgCluster) (*api.OrgCluster, error) {
+	return &api.OrgCluster{
+		Org:       cluster.Org,
+		Quotas:    cluster.Quotas,
+		QuotasSet: cluster.Quotas != nil,
+		Related:   nodeIdsFromDomain(cluster.Related),
+		Joined:    nodeIdsFromDomain(cluster.Joined),
+	}, nil
+}
+
+func OrgClusterToDomain(cluster *api.OrgCluster) (*domain.OrgCluster, error) {
+	var quotas map[string]float64
+	if cluster.QuotasSet {
+		quotas = make(map[string]float64)
+		for resource, quota := range cluster.Quotas {
+			quotas[resource] = quota
+		}
+	}
+	return &domain.OrgCluster{
+		Org:     cluster.Org,
+		Quotas:  quotas,
+		Related: nodeIdsToDomain(cluster.Related),
+		Joined:  nodeIdsToDomain(cluster.Joined),
+	}, nil
+}
+
+func nodeIdsFromDomain(nodeIds []domain.NodeId) []string {
+	ids := make([]string, 0, len(nodeIds))
+	for _, nodeId := range nodeIds {
+		ids = append(ids, nodeId.Value)
+	}
+	return ids
+}
+
+func nodeIdsToDomain(ids []string) []domain.NodeId {
+	nodeIds := make([]domain.NodeId, 0, len(ids))
+	for _, id := range ids {
+		nodeIds = append(nodeIds, domain.NodeId{Value: id})
+	}
+	return nodeIds
+}
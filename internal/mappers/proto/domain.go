@@ -2,10 +2,13 @@ package proto
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/c12s/magnetar/internal/domain"
 	"github.com/c12s/magnetar/pkg/api"
 	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func LabelFromDomain(label domain.Label) (*api.Label, error) {
@@ -41,12 +44,59 @@ func LabelToDomain(l *api.Label) (domain.Label, error) {
 		if err == nil {
 			label = domain.NewStringLabel(l.Key, protoValue.Value)
 		}
+	case api.Value_Int64:
+		protoValue := &api.Int64Value{}
+		err = proto.Unmarshal(l.Value.Marshalled, protoValue)
+		if err == nil {
+			label = domain.NewInt64Label(l.Key, protoValue.Value)
+		}
+	case api.Value_Timestamp:
+		protoValue := &api.TimestampValue{}
+		err = proto.Unmarshal(l.Value.Marshalled, protoValue)
+		if err == nil {
+			label, err = timestampLabelToDomain(l.Key, protoValue.Value)
+		}
+	case api.Value_Semver:
+		protoValue := &api.SemverValue{}
+		err = proto.Unmarshal(l.Value.Marshalled, protoValue)
+		if err == nil {
+			label, err = semverLabelToDomain(l.Key, protoValue.Value)
+		}
+	case api.Value_StringSet:
+		protoValue := &api.StringSetValue{}
+		err = proto.Unmarshal(l.Value.Marshalled, protoValue)
+		if err == nil {
+			label, err = stringSetLabelToDomain(l.Key, protoValue.Values)
+		}
 	default:
 		err = errors.New("unsupported data type")
 	}
 	return label, err
 }
 
+func timestampLabelToDomain(key string, value *timestamppb.Timestamp) (domain.Label, error) {
+	if err := value.CheckValid(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidLabelValue, err)
+	}
+	return domain.NewTimestampLabel(key, value.AsTime()), nil
+}
+
+func semverLabelToDomain(key string, value string) (domain.Label, error) {
+	version, err := domain.ParseVersion(value)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewSemverLabel(key, version), nil
+}
+
+func stringSetLabelToDomain(key string, values []string) (domain.Label, error) {
+	label := domain.NewStringSetLabel(key, values)
+	if err := domain.ValidateLabel(label); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
 func ValueFromDomain(value interface{}) (*api.Value, error) {
 	var marshalled []byte
 	var valueType api.Value_ValueTYpe
@@ -61,6 +111,18 @@ func ValueFromDomain(value interface{}) (*api.Value, error) {
 	case string:
 		marshalled, err = proto.Marshal(&api.StringValue{Value: value})
 		valueType = api.Value_String
+	case int64:
+		marshalled, err = proto.Marshal(&api.Int64Value{Value: value})
+		valueType = api.Value_Int64
+	case time.Time:
+		marshalled, err = proto.Marshal(&api.TimestampValue{Value: timestamppb.New(value)})
+		valueType = api.Value_Timestamp
+	case domain.Version:
+		marshalled, err = proto.Marshal(&api.SemverValue{Value: value.String()})
+		valueType = api.Value_Semver
+	case []string:
+		marshalled, err = proto.Marshal(&api.StringSetValue{Values: value})
+		valueType = api.Value_StringSet
 	default:
 		err = errors.New("unsupported data type")
 	}
@@ -81,13 +143,23 @@ func NodeStringifiedFromDomain(node domain.Node) (*api.NodeStringified, error) {
 		labels[i] = labelProto
 	}
 	return &api.NodeStringified{
-		Id:        node.Id.Value,
-		Org:       node.Org,
-		Labels:    labels,
-		Resources: node.Resources,
+		Id:              node.Id.Value,
+		Org:             node.Org,
+		Labels:          labels,
+		Resources:       node.Resources,
+		ResourceVersion: node.ResourceVersion,
+		Status:          node.Status.String(),
+		LastSeen:        lastSeenFromDomain(node.LastSeen),
 	}, nil
 }
 
+func lastSeenFromDomain(lastSeen time.Time) *timestamppb.Timestamp {
+	if lastSeen.IsZero() {
+		return nil
+	}
+	return timestamppb.New(lastSeen)
+}
+
 func LabelStringifiedFromDomain(label domain.Label) (*api.LabelStringified, error) {
 	return &api.LabelStringified{
 		Key:   label.Key(),
@@ -97,11 +169,12 @@ func LabelStringifiedFromDomain(label domain.Label) (*api.LabelStringified, erro
 
 func NodeFromDomain(node domain.Node) (*api.Node, error) {
 	resp := &api.Node{
-		Id:          node.Id.Value,
-		Org:         node.Org,
-		Labels:      make([]*api.Label, len(node.Labels)),
-		Resources:   node.Resources,
-		BindAddress: node.BindAddress,
+		Id:                    node.Id.Value,
+		Org:                   node.Org,
+		Labels:                make([]*api.Label, len(node.Labels)),
+		Resources:             node.Resources,
+		BindAddress:           node.BindAddress,
+		RegistrationTokenHash: node.RegistrationTokenHash,
 	}
 	for i, label := range node.Labels {
 		protoLabel, err := LabelFromDomain(label)
@@ -118,10 +191,11 @@ func NodeToDomain(node *api.Node) (*domain.Node, error) {
 		Id: domain.NodeId{
 			Value: node.Id,
 		},
-		Org:       node.Org,
-		Labels:    make([]domain.Label, len(node.Labels)),
-		Resources: node.Resources,
-		BindAddress: node.BindAddress,
+		Org:                   node.Org,
+		Labels:                make([]domain.Label, len(node.Labels)),
+		Resources:             node.Resources,
+		BindAddress:           node.BindAddress,
+		RegistrationTokenHash: node.RegistrationTokenHash,
 	}
 	for i, protoLabel := range node.Labels {
 		label, err := LabelToDomain(protoLabel)
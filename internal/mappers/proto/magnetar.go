@@ -1,6 +1,7 @@
 package proto
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/c12s/magnetar/internal/domain"
@@ -47,13 +48,28 @@ func GetFromOrgRespFromDomain(resp domain.GetFromOrgResp) (*api.GetFromOrgResp,
 }
 
 func ClaimOwnershipReqToDomain(req *api.ClaimOwnershipReq) (*domain.ClaimOwnershipReq, error) {
-	query, err := queryToDomain(req.Query)
+	query, err := queryStringToDomain(req.Query, req.QueryString)
+	if err != nil {
+		return nil, err
+	}
+	strategy, err := domain.NewClaimStrategyFromString(req.Strategy)
 	if err != nil {
+		return nil, fmt.Errorf("%w: unknown claim strategy %q", err, req.Strategy)
+	}
+	requirements := domain.ClaimRequirements{
+		Count:     req.Count,
+		Resources: req.Resources,
+		Strategy:  strategy,
+	}
+	if err := requirements.Validate(); err != nil {
 		return nil, err
 	}
 	return &domain.ClaimOwnershipReq{
-		Query: query,
-		Org:   req.Org,
+		Query:           query,
+		Org:             req.Org,
+		IncludeNotReady: req.IncludeNotReady,
+		Requirements:    requirements,
+		DryRun:          req.DryRun,
 	}, nil
 }
 
@@ -67,13 +83,158 @@ func ClaimOwnershipRespFromDomain(resp domain.ClaimOwnershipResp) (*api.ClaimOwn
 		}
 		nodesProto = append(nodesProto, nodeProto)
 	}
+	var operation *api.Operation
+	sagaId, state := "", ""
+	if resp.Operation != nil {
+		var err error
+		operation, err = OperationFromDomain(*resp.Operation)
+		if err != nil {
+			log.Println(err)
+			return nil, domain.ErrServerSide
+		}
+		sagaId, state = resp.Operation.Id, claimSagaStateFromDomain(resp.Operation.State)
+	}
 	return &api.ClaimOwnershipResp{
-		Node: nodesProto,
+		Node:            nodesProto,
+		DryRun:          resp.DryRun,
+		NamespaceQuotas: resp.NamespaceQuotas,
+		JoinAddress:     resp.JoinAddress,
+		SagaId:          sagaId,
+		State:           state,
+		Operation:       operation,
+	}, nil
+}
+
+func GetClaimSagaReqToDomain(req *api.GetClaimSagaReq) (*domain.GetClaimSagaReq, error) {
+	return &domain.GetClaimSagaReq{
+		Id:  req.Id,
+		Org: req.Org,
+	}, nil
+}
+
+func GetClaimSagaRespFromDomain(resp domain.GetClaimSagaResp) (*api.GetClaimSagaResp, error) {
+	saga, err := ClaimSagaFromDomain(resp.Operation)
+	if err != nil {
+		log.Println(err)
+		return nil, domain.ErrServerSide
+	}
+	return &api.GetClaimSagaResp{
+		Saga: saga,
+	}, nil
+}
+
+func ReleaseNodesReqToDomain(req *api.ReleaseNodesReq) (*domain.ReleaseNodesReq, error) {
+	query, err := queryToDomain(req.Query)
+	if err != nil {
+		return nil, err
+	}
+	nodeIds := make([]domain.NodeId, 0, len(req.NodeIds))
+	for _, nodeId := range req.NodeIds {
+		nodeIds = append(nodeIds, domain.NodeId{
+			Value: nodeId,
+		})
+	}
+	return &domain.ReleaseNodesReq{
+		Query:   query,
+		NodeIds: nodeIds,
+		Org:     req.Org,
+	}, nil
+}
+
+func ReleaseNodesRespFromDomain(resp domain.ReleaseNodesResp) (*api.ReleaseNodesResp, error) {
+	operation, err := OperationFromDomain(resp.Operation)
+	if err != nil {
+		log.Println(err)
+		return nil, domain.ErrServerSide
+	}
+	return &api.ReleaseNodesResp{
+		Operation: operation,
+	}, nil
+}
+
+func GetOperationReqToDomain(req *api.GetOperationReq) (*domain.GetOperationReq, error) {
+	return &domain.GetOperationReq{
+		Id:  req.Id,
+		Org: req.Org,
+	}, nil
+}
+
+func GetOperationRespFromDomain(resp domain.GetOperationResp) (*api.GetOperationResp, error) {
+	operation, err := OperationFromDomain(resp.Operation)
+	if err != nil {
+		log.Println(err)
+		return nil, domain.ErrServerSide
+	}
+	return &api.GetOperationResp{
+		Operation: operation,
+	}, nil
+}
+
+func ListOperationsReqToDomain(req *api.ListOperationsReq) (*domain.ListOperationsReq, error) {
+	return &domain.ListOperationsReq{
+		Org:  req.Org,
+		Page: pageToDomain(req.PageSize, req.PageToken),
+	}, nil
+}
+
+func ListOperationsRespFromDomain(resp domain.ListOperationsResp) (*api.ListOperationsResp, error) {
+	operations := make([]*api.Operation, 0, len(resp.Operations))
+	for _, operation := range resp.Operations {
+		operationProto, err := OperationFromDomain(operation)
+		if err != nil {
+			log.Println(err)
+			return nil, domain.ErrServerSide
+		}
+		operations = append(operations, operationProto)
+	}
+	return &api.ListOperationsResp{
+		Operations:    operations,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+func CancelOperationReqToDomain(req *api.CancelOperationReq) (*domain.CancelOperationReq, error) {
+	return &domain.CancelOperationReq{
+		Id:  req.Id,
+		Org: req.Org,
+	}, nil
+}
+
+func CancelOperationRespFromDomain(resp domain.CancelOperationResp) (*api.CancelOperationResp, error) {
+	operation, err := OperationFromDomain(resp.Operation)
+	if err != nil {
+		log.Println(err)
+		return nil, domain.ErrServerSide
+	}
+	return &api.CancelOperationResp{
+		Operation: operation,
+	}, nil
+}
+
+func DeregisterNodeReqToDomain(req *api.DeregisterNodeReq) (*domain.DeregisterNodeReq, error) {
+	return &domain.DeregisterNodeReq{
+		Id: domain.NodeId{
+			Value: req.NodeId,
+		},
+		Org: req.Org,
+	}, nil
+}
+
+func DeregisterNodeRespFromDomain(resp domain.DeregisterNodeResp) (*api.DeregisterNodeResp, error) {
+	nodeProto, err := NodeStringifiedFromDomain(resp.Node)
+	if err != nil {
+		log.Println(err)
+		return nil, domain.ErrServerSide
+	}
+	return &api.DeregisterNodeResp{
+		Node: nodeProto,
 	}, nil
 }
 
 func ListNodePoolReqToDomain(req *api.ListNodePoolReq) (*domain.ListNodePoolReq, error) {
-	return &domain.ListNodePoolReq{}, nil
+	return &domain.ListNodePoolReq{
+		Page: pageToDomain(req.PageSize, req.PageToken),
+	}, nil
 }
 
 func ListNodePoolRespFromDomain(resp domain.ListNodePoolResp) (*api.ListNodePoolResp, error) {
@@ -87,13 +248,15 @@ func ListNodePoolRespFromDomain(resp domain.ListNodePoolResp) (*api.ListNodePool
 		nodesProto[i] = nodeProto
 	}
 	return &api.ListNodePoolResp{
-		Nodes: nodesProto,
+		Nodes:         nodesProto,
+		NextPageToken: resp.NextPageToken,
 	}, nil
 }
 
 func ListOrgOwnedReqToDomain(req *api.ListOrgOwnedNodesReq) (*domain.ListOrgOwnedNodesReq, error) {
 	return &domain.ListOrgOwnedNodesReq{
-		Org: req.Org,
+		Org:  req.Org,
+		Page: pageToDomain(req.PageSize, req.PageToken),
 	}, nil
 }
 
@@ -108,13 +271,20 @@ func ListOrgOwnedNodesRespFromDomain(resp domain.ListOrgOwnedNodesResp) (*api.Li
 		nodesProto[i] = nodeProto
 	}
 	return &api.ListOrgOwnedNodesResp{
-		Nodes: nodesProto,
+		Nodes:         nodesProto,
+		NextPageToken: resp.NextPageToken,
 	}, nil
 }
 
-func ListAlldNodesRespFromDomain(nodes []domain.Node) (*api.ListAllNodesResp, error) {
-	nodesProto := make([]*api.NodeStringified, len(nodes))
-	for i, node := range nodes {
+func ListAllNodesReqToDomain(req *api.ListAllNodesReq) (*domain.ListAllNodesReq, error) {
+	return &domain.ListAllNodesReq{
+		Page: pageToDomain(req.PageSize, req.PageToken),
+	}, nil
+}
+
+func ListAlldNodesRespFromDomain(resp domain.ListAllNodesResp) (*api.ListAllNodesResp, error) {
+	nodesProto := make([]*api.NodeStringified, len(resp.Nodes))
+	for i, node := range resp.Nodes {
 		nodeProto, err := NodeStringifiedFromDomain(node)
 		if err != nil {
 			log.Println(err)
@@ -123,42 +293,98 @@ func ListAlldNodesRespFromDomain(nodes []domain.Node) (*api.ListAllNodesResp, er
 		nodesProto[i] = nodeProto
 	}
 	return &api.ListAllNodesResp{
-		Nodes: nodesProto,
+		Nodes:         nodesProto,
+		NextPageToken: resp.NextPageToken,
 	}, nil
 }
 
 func QueryNodePoolReqToDomain(req *api.QueryNodePoolReq) (*domain.QueryNodePoolReq, error) {
-	query, err := queryToDomain(req.Query)
+	query, err := queryStringToDomain(req.Query, req.QueryString)
+	if err != nil {
+		return nil, err
+	}
+	page, err := queryPageToDomain(req.PageSize, req.PageToken, req.OrderBy, req.Limit)
 	if err != nil {
 		return nil, err
 	}
 	return &domain.QueryNodePoolReq{
-		Query: query,
+		Query:           query,
+		Page:            page,
+		IncludeNotReady: req.IncludeNotReady,
 	}, nil
 }
 
 func queryToDomain(query []*api.Selector) (domain.Query, error) {
+	queryDomain, err := selectorsToDomain(query)
+	if err != nil {
+		return nil, err
+	}
+	if err := queryDomain.Validate(); err != nil {
+		return nil, err
+	}
+	return queryDomain, nil
+}
+
+func selectorsToDomain(selectors []*api.Selector) (domain.Query, error) {
 	queryDomain := make([]domain.Selector, 0)
-	for _, selector := range query {
+	for _, selector := range selectors {
 		selectorDomain, err := selectorToDomain(selector)
 		if err != nil {
-			log.Println(err)
-			return nil, domain.ErrServerSide
+			return nil, err
 		}
 		queryDomain = append(queryDomain, *selectorDomain)
 	}
 	return queryDomain, nil
 }
 
+// queryStringToDomain combines the structured query with the parsed query string
+func queryStringToDomain(query []*api.Selector, queryString string) (domain.Query, error) {
+	parsed, err := api.ParseQuery(queryString)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidQuery, err)
+	}
+	// appending to the request's selectors could overwrite the ones past their length
+	selectors := make([]*api.Selector, 0, len(query)+len(parsed))
+	selectors = append(append(selectors, query...), parsed...)
+	return queryToDomain(selectors)
+}
+
+func pageToDomain(size int64, token string) domain.Page {
+	return domain.Page{
+		Size:  size,
+		Token: token,
+	}
+}
+
+func queryPageToDomain(size int64, token string, orderBy []*api.OrderBy, limit int64) (domain.Page, error) {
+	page := pageToDomain(size, token)
+	page.Limit = limit
+	for _, order := range orderBy {
+		page.OrderBy = append(page.OrderBy, domain.OrderBy{
+			LabelKey:   order.LabelKey,
+			Resource:   order.Resource,
+			Descending: order.Descending,
+		})
+	}
+	if err := page.Validate(); err != nil {
+		return domain.Page{}, err
+	}
+	return page, nil
+}
+
 func QueryNodePoolRespFromDomain(resp domain.QueryNodePoolResp) (*api.QueryNodePoolResp, error) {
 	protoResp := &api.QueryNodePoolResp{
-		Nodes: make([]*api.NodeStringified, 0),
+		Nodes:         make([]*api.NodeStringified, 0),
+		NextPageToken: resp.NextPageToken,
 	}
 	for _, node := range resp.Nodes {
 		protoNode := &api.NodeStringified{
-			Id:        node.Id.Value,
-			Labels:    make([]*api.LabelStringified, 0),
-			Resources: node.Resources,
+			Id:              node.Id.Value,
+			Labels:          make([]*api.LabelStringified, 0),
+			Resources:       node.Resources,
+			ResourceVersion: node.ResourceVersion,
+			Status:          node.Status.String(),
+			LastSeen:        lastSeenFromDomain(node.LastSeen),
 		}
 		for _, label := range node.Labels {
 			protoLabel := &api.LabelStringified{
@@ -173,25 +399,34 @@ func QueryNodePoolRespFromDomain(resp domain.QueryNodePoolResp) (*api.QueryNodeP
 }
 
 func QueryOrgOwnedNodesReqToDomain(req *api.QueryOrgOwnedNodesReq) (*domain.QueryOrgOwnedNodesReq, error) {
-	query, err := queryToDomain(req.Query)
+	query, err := queryStringToDomain(req.Query, req.QueryString)
+	if err != nil {
+		return nil, err
+	}
+	page, err := queryPageToDomain(req.PageSize, req.PageToken, req.OrderBy, req.Limit)
 	if err != nil {
 		return nil, err
 	}
 	return &domain.QueryOrgOwnedNodesReq{
 		Org:   req.Org,
 		Query: query,
+		Page:  page,
 	}, nil
 }
 
 func QueryOrgOwnedNodesRespFromDomain(resp domain.QueryOrgOwnedNodesResp) (*api.QueryOrgOwnedNodesResp, error) {
 	protoResp := &api.QueryOrgOwnedNodesResp{
-		Nodes: make([]*api.NodeStringified, 0),
+		Nodes:         make([]*api.NodeStringified, 0),
+		NextPageToken: resp.NextPageToken,
 	}
 	for _, node := range resp.Nodes {
 		protoNode := &api.NodeStringified{
-			Id:        node.Id.Value,
-			Labels:    make([]*api.LabelStringified, 0),
-			Resources: node.Resources,
+			Id:              node.Id.Value,
+			Labels:          make([]*api.LabelStringified, 0),
+			Resources:       node.Resources,
+			ResourceVersion: node.ResourceVersion,
+			Status:          node.Status.String(),
+			LastSeen:        lastSeenFromDomain(node.LastSeen),
 		}
 		for _, label := range node.Labels {
 			protoLabel := &api.LabelStringified{
@@ -210,8 +445,9 @@ func PutBoolLabelReqToDomain(req *api.PutBoolLabelReq) (*domain.PutLabelReq, err
 		NodeId: domain.NodeId{
 			Value: req.NodeId,
 		},
-		Label: domain.NewBoolLabel(req.Label.Key, req.Label.Value),
-		Org:   req.Org,
+		Label:           domain.NewBoolLabel(req.Label.Key, req.Label.Value),
+		Org:             req.Org,
+		ResourceVersion: req.ResourceVersion,
 	}, nil
 }
 
@@ -220,8 +456,9 @@ func PutFloat64LabelReqToDomain(req *api.PutFloat64LabelReq) (*domain.PutLabelRe
 		NodeId: domain.NodeId{
 			Value: req.NodeId,
 		},
-		Label: domain.NewFloat64Label(req.Label.Key, req.Label.Value),
-		Org:   req.Org,
+		Label:           domain.NewFloat64Label(req.Label.Key, req.Label.Value),
+		Org:             req.Org,
+		ResourceVersion: req.ResourceVersion,
 	}, nil
 }
 
@@ -230,8 +467,65 @@ func PutStringLabelReqToDomain(req *api.PutStringLabelReq) (*domain.PutLabelReq,
 		NodeId: domain.NodeId{
 			Value: req.NodeId,
 		},
-		Label: domain.NewStringLabel(req.Label.Key, req.Label.Value),
-		Org:   req.Org,
+		Label:           domain.NewStringLabel(req.Label.Key, req.Label.Value),
+		Org:             req.Org,
+		ResourceVersion: req.ResourceVersion,
+	}, nil
+}
+
+func PutInt64LabelReqToDomain(req *api.PutInt64LabelReq) (*domain.PutLabelReq, error) {
+	return &domain.PutLabelReq{
+		NodeId: domain.NodeId{
+			Value: req.NodeId,
+		},
+		Label:           domain.NewInt64Label(req.Label.Key, req.Label.Value),
+		Org:             req.Org,
+		ResourceVersion: req.ResourceVersion,
+	}, nil
+}
+
+func PutTimestampLabelReqToDomain(req *api.PutTimestampLabelReq) (*domain.PutLabelReq, error) {
+	label, err := timestampLabelToDomain(req.Label.Key, req.Label.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PutLabelReq{
+		NodeId: domain.NodeId{
+			Value: req.NodeId,
+		},
+		Label:           label,
+		Org:             req.Org,
+		ResourceVersion: req.ResourceVersion,
+	}, nil
+}
+
+func PutSemverLabelReqToDomain(req *api.PutSemverLabelReq) (*domain.PutLabelReq, error) {
+	label, err := semverLabelToDomain(req.Label.Key, req.Label.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PutLabelReq{
+		NodeId: domain.NodeId{
+			Value: req.NodeId,
+		},
+		Label:           label,
+		Org:             req.Org,
+		ResourceVersion: req.ResourceVersion,
+	}, nil
+}
+
+func PutStringSetLabelReqToDomain(req *api.PutStringSetLabelReq) (*domain.PutLabelReq, error) {
+	label, err := stringSetLabelToDomain(req.Label.Key, req.Label.Values)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PutLabelReq{
+		NodeId: domain.NodeId{
+			Value: req.NodeId,
+		},
+		Label:           label,
+		Org:             req.Org,
+		ResourceVersion: req.ResourceVersion,
 	}, nil
 }
 
@@ -251,8 +545,9 @@ func DeleteLabelReqToDomain(req *api.DeleteLabelReq) (*domain.DeleteLabelReq, er
 		NodeId: domain.NodeId{
 			Value: req.NodeId,
 		},
-		LabelKey: req.LabelKey,
-		Org:      req.Org,
+		LabelKey:        req.LabelKey,
+		Org:             req.Org,
+		ResourceVersion: req.ResourceVersion,
 	}, nil
 }
 
@@ -267,15 +562,68 @@ func DeleteLabelRespFromDomain(resp domain.DeleteLabelResp) (*api.DeleteLabelRes
 	}, nil
 }
 
-func selectorToDomain(query *api.Selector) (*domain.Selector, error) {
-	shouldBe, err := domain.NewCompResultFromString(query.ShouldBe)
+func WatchNodesReqToDomain(req *api.WatchNodesReq) (*domain.WatchNodesReq, error) {
+	query, err := queryToDomain(req.Query)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.WatchNodesReq{
+		Org:      req.Org,
+		Query:    query,
+		Revision: req.Revision,
+	}, nil
+}
+
+func WatchNodesRespFromDomain(event domain.NodeEvent) (*api.WatchNodesResp, error) {
+	node, err := NodeStringifiedFromDomain(event.Node)
 	if err != nil {
 		log.Println(err)
 		return nil, domain.ErrServerSide
 	}
+	var eventType api.WatchNodesResp_EventType
+	switch event.Type {
+	case domain.NodeAdded:
+		eventType = api.WatchNodesResp_Added
+	case domain.NodeModified:
+		eventType = api.WatchNodesResp_Modified
+	case domain.NodeDeleted:
+		eventType = api.WatchNodesResp_Deleted
+	default:
+		return nil, domain.ErrServerSide
+	}
+	return &api.WatchNodesResp{
+		Type:     eventType,
+		Node:     node,
+		Revision: event.Revision,
+	}, nil
+}
+
+func selectorToDomain(query *api.Selector) (*domain.Selector, error) {
+	if query.Group != nil {
+		op, err := domain.NewGroupOpFromString(query.Group.Op)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unknown group operator %q", domain.ErrInvalidQuery, query.Group.Op)
+		}
+		selectors, err := selectorsToDomain(query.Group.Selectors)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.Selector{
+			Group: &domain.SelectorGroup{
+				Op:        op,
+				Selectors: selectors,
+			},
+		}, nil
+	}
+	shouldBe, err := domain.NewCompResultFromString(query.ShouldBe)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown operator %q", domain.ErrInvalidQuery, query.ShouldBe)
+	}
 	return &domain.Selector{
 		LabelKey: query.LabelKey,
+		Resource: query.Resource,
 		ShouldBe: shouldBe,
 		Value:    query.Value,
+		Values:   query.Values,
 	}, nil
 }
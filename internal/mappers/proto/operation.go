@@ -0,0 +1,91 @@
+package proto
+
+import (
+	"github.com/c12s/magnetar/internal/domain"
+	"github.com/c12s/magnetar/pkg/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func OperationFromDomain(operation domain.Operation) (*api.Operation, error) {
+	nodes := make([]*api.OperationNode, 0, len(operation.Nodes))
+	for _, node := range operation.Nodes {
+		nodes = append(nodes, &api.OperationNode{
+			NodeId:   node.NodeId.Value,
+			Step:     node.Step.String(),
+			Status:   node.Status.String(),
+			Attempts: node.Attempts,
+			Error:    node.Err,
+		})
+	}
+	return &api.Operation{
+		Id:              operation.Id,
+		Org:             operation.Org,
+		Kind:            operation.Kind.String(),
+		State:           operation.State.String(),
+		Step:            operation.Step.String(),
+		Nodes:           nodes,
+		Count:           operation.Requirements.Count,
+		Resources:       operation.Requirements.Resources,
+		Strategy:        operation.Requirements.Strategy.String(),
+		JoinAddress:     operation.JoinAddress,
+		Error:           operation.Err,
+		CreatedAt:       timestamppb.New(operation.CreatedAt),
+		UpdatedAt:       timestamppb.New(operation.UpdatedAt),
+		CancelRequested: operation.CancelRequested,
+	}, nil
+}
+
+func OperationToDomain(operation *api.Operation) (*domain.Operation, error) {
+	kind, err := domain.NewOperationKindFromString(operation.Kind)
+	if err != nil {
+		return nil, err
+	}
+	state, err := domain.NewOperationStateFromString(operation.State)
+	if err != nil {
+		return nil, err
+	}
+	step, err := domain.NewOperationStepFromString(operation.Step)
+	if err != nil {
+		return nil, err
+	}
+	strategy, err := domain.NewClaimStrategyFromString(operation.Strategy)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]domain.OperationNode, 0, len(operation.Nodes))
+	for _, node := range operation.Nodes {
+		nodeStep, err := domain.NewOperationStepFromString(node.Step)
+		if err != nil {
+			return nil, err
+		}
+		status, err := domain.NewNodeOperationStatusFromString(node.Status)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, domain.OperationNode{
+			NodeId:   domain.NodeId{Value: node.NodeId},
+			Step:     nodeStep,
+			Status:   status,
+			Attempts: node.Attempts,
+			Err:      node.Error,
+		})
+	}
+	return &domain.Operation{
+		Id:    operation.Id,
+		Org:   operation.Org,
+		Kind:  kind,
+		State: state,
+		Step:  step,
+		Nodes: nodes,
+		Requirements: domain.ClaimRequirements{
+			Count:     operation.Count,
+			Resources: operation.Resources,
+			Strategy:  strategy,
+		},
+		JoinAddress:     operation.JoinAddress,
+		Err:             operation.Error,
+		CreatedAt:       operation.CreatedAt.AsTime(),
+		UpdatedAt:       operation.UpdatedAt.AsTime(),
+		CancelRequested: operation.CancelRequested,
+	}, nil
+}
@@ -15,14 +15,36 @@ func RegistrationReqToDomain(req *api.RegistrationReq) (*domain.RegistrationReq,
 		labels = append(labels, label)
 	}
 	return &domain.RegistrationReq{
-		Labels:      labels,
-		Resources:   req.Resources,
-		BindAddress: req.BindAddress,
+		Labels:            labels,
+		Resources:         req.Resources,
+		BindAddress:       req.BindAddress,
+		NodeId:            req.NodeId,
+		MachineId:         req.MachineId,
+		RegistrationToken: req.RegistrationToken,
 	}, nil
 }
 
 func RegistrationRespFromDomain(resp domain.RegistrationResp) (*api.RegistrationResp, error) {
 	return &api.RegistrationResp{
-		NodeId: resp.NodeId,
+		NodeId:            resp.NodeId,
+		RegistrationToken: resp.RegistrationToken,
+	}, nil
+}
+
+func HeartbeatReqToDomain(req *api.HeartbeatReq) (*domain.HeartbeatReq, error) {
+	return &domain.HeartbeatReq{
+		NodeId: domain.NodeId{
+			Value: req.NodeId,
+		},
+		RegistrationToken: req.RegistrationToken,
+	}, nil
+}
+
+func DeregistrationReqToDomain(req *api.DeregistrationReq) (*domain.DeregistrationReq, error) {
+	return &domain.DeregistrationReq{
+		NodeId: domain.NodeId{
+			Value: req.NodeId,
+		},
+		RegistrationToken: req.RegistrationToken,
 	}, nil
 }
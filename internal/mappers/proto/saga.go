@@ -0,0 +1,137 @@
+package proto
+
+import (
+	"github.com/c12s/magnetar/internal/domain"
+	"github.com/c12s/magnetar/pkg/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// claims used to run as sagas with their own states and node statuses, claim operations are reported in their terms
+
+var claimSagaStates = map[domain.OperationState]string{
+	domain.OperationRunning:      "Running",
+	domain.OperationCompensating: "Compensating",
+	domain.OperationSucceeded:    "Completed",
+	domain.OperationFailed:       "Aborted",
+	domain.OperationCancelled:    "Aborted",
+}
+
+var claimSagaNodeStatuses = map[domain.NodeOperationStatus]string{
+	domain.NodeOperationPending:            "Pending",
+	domain.NodeOperationClaimed:            "Claimed",
+	domain.NodeOperationFailed:             "Failed",
+	domain.NodeOperationCompensated:        "Compensated",
+	domain.NodeOperationCompensationFailed: "CompensationFailed",
+	domain.NodeOperationCancelled:          "Failed",
+}
+
+func ClaimSagaFromDomain(operation domain.Operation) (*api.ClaimSaga, error) {
+	if operation.Kind != domain.OperationClaim {
+		return nil, domain.ErrServerSide
+	}
+	nodes := make([]*api.ClaimSagaNode, 0, len(operation.Nodes))
+	for _, node := range operation.Nodes {
+		nodes = append(nodes, &api.ClaimSagaNode{
+			NodeId:   node.NodeId.Value,
+			Step:     node.Step.String(),
+			Status:   claimSagaNodeStatuses[node.Status],
+			Attempts: node.Attempts,
+			Error:    node.Err,
+		})
+	}
+	return &api.ClaimSaga{
+		Id:          operation.Id,
+		Org:         operation.Org,
+		State:       claimSagaStateFromDomain(operation.State),
+		Step:        operation.Step.String(),
+		Nodes:       nodes,
+		Count:       operation.Requirements.Count,
+		Resources:   operation.Requirements.Resources,
+		Strategy:    operation.Requirements.Strategy.String(),
+		JoinAddress: operation.JoinAddress,
+		Error:       operation.Err,
+		CreatedAt:   timestamppb.New(operation.CreatedAt),
+		UpdatedAt:   timestamppb.New(operation.UpdatedAt),
+	}, nil
+}
+
+func claimSagaStateFromDomain(state domain.OperationState) string {
+	return claimSagaStates[state]
+}
+
+// ClaimSagaToDomain reads a saga stored before claims became operations, the stored sagas share the reported format
+func ClaimSagaToDomain(saga *api.ClaimSaga) (*domain.Operation, error) {
+	state, err := claimSagaStateToDomain(saga.State)
+	if err != nil {
+		return nil, err
+	}
+	step, err := domain.NewOperationStepFromString(saga.Step)
+	if err != nil {
+		return nil, err
+	}
+	strategy, err := domain.NewClaimStrategyFromString(saga.Strategy)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]domain.OperationNode, 0, len(saga.Nodes))
+	for _, node := range saga.Nodes {
+		nodeStep, err := domain.NewOperationStepFromString(node.Step)
+		if err != nil {
+			return nil, err
+		}
+		status, err := claimSagaNodeStatusToDomain(node.Status)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, domain.OperationNode{
+			NodeId:   domain.NodeId{Value: node.NodeId},
+			Step:     nodeStep,
+			Status:   status,
+			Attempts: node.Attempts,
+			Err:      node.Error,
+		})
+	}
+	return &domain.Operation{
+		Id:    saga.Id,
+		Org:   saga.Org,
+		Kind:  domain.OperationClaim,
+		State: state,
+		Step:  step,
+		Nodes: nodes,
+		Requirements: domain.ClaimRequirements{
+			Count:     saga.Count,
+			Resources: saga.Resources,
+			Strategy:  strategy,
+		},
+		JoinAddress: saga.JoinAddress,
+		Err:         saga.Error,
+		CreatedAt:   saga.CreatedAt.AsTime(),
+		UpdatedAt:   saga.UpdatedAt.AsTime(),
+	}, nil
+}
+
+// an aborted saga has failed, sagas couldn't be cancelled
+func claimSagaStateToDomain(state string) (domain.OperationState, error) {
+	if state == "Aborted" {
+		return domain.OperationFailed, nil
+	}
+	for operationState, sagaState := range claimSagaStates {
+		if sagaState == state {
+			return operationState, nil
+		}
+	}
+	return 0, domain.ErrServerSide
+}
+
+// a failed node has failed, sagas couldn't be cancelled
+func claimSagaNodeStatusToDomain(status string) (domain.NodeOperationStatus, error) {
+	if status == "Failed" {
+		return domain.NodeOperationFailed, nil
+	}
+	for nodeStatus, sagaStatus := range claimSagaNodeStatuses {
+		if sagaStatus == status {
+			return nodeStatus, nil
+		}
+	}
+	return 0, domain.ErrServerSide
+}
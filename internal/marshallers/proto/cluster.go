@@ -0,0 +1,32 @@
+package proto
+
+import (
+	"github.com/c12s/magnetar/internal/domain"
+	mapper "github.com/c12s/magnetar/internal/mappers/proto"
+	"github.com/c12s/magnetar/pkg/api"
+	"github.com/golang/protobuf/proto"
+)
+
+type protoOrgClusterMarshaller struct {
+}
+
+func NewProtoOrgClusterMarshaller() domain.OrgClusterMarshaller {
+	return &protoOrgClusterMarshaller{}
+}
+
+func (p protoOrgClusterMarshaller) Marshal(cluster domain.OrgCluster) ([]byte, error) {
+	protoCluster, err := mapper.OrgClusterFromDomain(cluster)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(protoCluster)
+}
+
+func (p protoOrgClusterMarshaller) Unmarshal(clusterMarshalled []byte) (*domain.OrgCluster, error) {
+	protoCluster := &api.OrgCluster{}
+	err := proto.Unmarshal(clusterMarshalled, protoCluster)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.OrgClusterToDomain(protoCluster)
+}
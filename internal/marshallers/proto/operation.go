@@ -0,0 +1,32 @@
+package proto
+
+import (
+	"github.com/c12s/magnetar/internal/domain"
+	mapper "github.com/c12s/magnetar/internal/mappers/proto"
+	"github.com/c12s/magnetar/pkg/api"
+	"github.com/golang/protobuf/proto"
+)
+
+type protoOperationMarshaller struct {
+}
+
+func NewProtoOperationMarshaller() domain.OperationMarshaller {
+	return &protoOperationMarshaller{}
+}
+
+func (p protoOperationMarshaller) Marshal(operation domain.Operation) ([]byte, error) {
+	protoOperation, err := mapper.OperationFromDomain(operation)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(protoOperation)
+}
+
+func (p protoOperationMarshaller) Unmarshal(operationMarshalled []byte) (*domain.Operation, error) {
+	protoOperation := &api.Operation{}
+	err := proto.Unmarshal(operationMarshalled, protoOperation)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.OperationToDomain(protoOperation)
+}
@@ -0,0 +1,24 @@
+package proto
+
+import (
+	"github.com/c12s/magnetar/internal/domain"
+	mapper "github.com/c12s/magnetar/internal/mappers/proto"
+	"github.com/c12s/magnetar/pkg/api"
+	"github.com/golang/protobuf/proto"
+)
+
+type protoClaimSagaUnmarshaller struct {
+}
+
+func NewProtoClaimSagaUnmarshaller() domain.ClaimSagaUnmarshaller {
+	return &protoClaimSagaUnmarshaller{}
+}
+
+func (p protoClaimSagaUnmarshaller) Unmarshal(sagaMarshalled []byte) (*domain.Operation, error) {
+	protoSaga := &api.ClaimSaga{}
+	err := proto.Unmarshal(sagaMarshalled, protoSaga)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ClaimSagaToDomain(protoSaga)
+}
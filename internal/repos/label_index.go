@@ -0,0 +1,418 @@
+package repos
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/c12s/magnetar/internal/domain"
+	etcd "go.etcd.io/etcd/client/v3"
+	"golang.org/x/exp/slices"
+)
+
+// label index keys hold an order-preserving encoding of the label value
+// key - index/pool/{labelKey}/{encodedValue}/{nodeId} | index/orgs/{orgId}/{labelKey}/{encodedValue}/{nodeId}
+// the encoded value starts with a type tag followed by
+// b - 0 or 1
+// f - big-endian hex of the float bits, with the sign bit flipped for positive and all bits flipped for negative numbers,
+// the value is rounded to two decimals first, the same way float labels are compared
+// i - big-endian hex of the integer with the sign bit flipped
+// t - the unix seconds encoded like an integer, followed by 8 hex digits of nanoseconds
+// v - the major, minor and patch versions as 16 hex digits each, followed by 1 for releases,
+// or by 0 and a :-prefixed encoding of each pre-release identifier for pre-releases,
+// 0 and 16 hex digits for numeric identifiers, 1, the hex of the bytes and a 00 terminator for alphanumeric ones,
+// build metadata is dropped, since it doesn't affect precedence
+// s - hex of the string bytes, which keeps both the byte order and prefixes
+// m - hex of a string set member, string set labels have a key per member, an empty set has a single key with the bare tag
+// values of the same type sort the way they compare, so selectors are answered by range reads
+
+const (
+	boolTag      = "b"
+	float64Tag   = "f"
+	int64Tag     = "i"
+	timestampTag = "t"
+	semverTag    = "v"
+	stringTag    = "s"
+	stringSetTag = "m"
+)
+
+// orderedTags are the tags of the types whose labels compare as greater or less than a value
+var orderedTags = []string{float64Tag, int64Tag, timestampTag, semverTag}
+
+// encodeLabelValue returns the encodings of all index keys of the label
+func encodeLabelValue(label domain.Label) ([]string, error) {
+	switch value := label.Value().(type) {
+	case bool:
+		return []string{encodeBool(value)}, nil
+	case float64:
+		return []string{encodeFloat64(value)}, nil
+	case int64:
+		return []string{encodeInt64(value)}, nil
+	case time.Time:
+		return []string{encodeTimestamp(value)}, nil
+	case domain.Version:
+		return []string{encodeSemver(value)}, nil
+	case string:
+		return []string{encodeString(value)}, nil
+	case []string:
+		if len(value) == 0 {
+			return []string{stringSetTag}, nil
+		}
+		encoded := make([]string, 0, len(value))
+		for _, member := range value {
+			encoded = append(encoded, encodeStringSetMember(member))
+		}
+		return encoded, nil
+	default:
+		return nil, fmt.Errorf("label %s has a value of unsupported type %T", label.Key(), value)
+	}
+}
+
+// decodeLabel recovers the label from the encodings of all its index keys
+func decodeLabel(labelKey string, encoded []string) (domain.Label, error) {
+	if len(encoded) == 0 || len(encoded[0]) == 0 {
+		return nil, errors.New("empty label value encoding")
+	}
+	tag, value := encoded[0][:1], encoded[0][1:]
+	if tag != stringSetTag && len(encoded) > 1 {
+		return nil, fmt.Errorf("label %s has multiple index keys", labelKey)
+	}
+	switch tag {
+	case boolTag:
+		return domain.NewBoolLabel(labelKey, value == "1"), nil
+	case float64Tag:
+		bits, err := strconv.ParseUint(value, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if bits&(1<<63) != 0 {
+			bits &^= 1 << 63
+		} else {
+			bits = ^bits
+		}
+		return domain.NewFloat64Label(labelKey, math.Float64frombits(bits)), nil
+	case int64Tag:
+		integer, err := decodeInt64(value)
+		if err != nil {
+			return nil, err
+		}
+		return domain.NewInt64Label(labelKey, integer), nil
+	case timestampTag:
+		if len(value) != 24 {
+			return nil, fmt.Errorf("invalid timestamp encoding %q", value)
+		}
+		seconds, err := decodeInt64(value[:16])
+		if err != nil {
+			return nil, err
+		}
+		nanos, err := strconv.ParseInt(value[16:], 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		return domain.NewTimestampLabel(labelKey, time.Unix(seconds, nanos)), nil
+	case semverTag:
+		version, err := decodeSemver(value)
+		if err != nil {
+			return nil, err
+		}
+		return domain.NewSemverLabel(labelKey, version), nil
+	case stringTag:
+		decoded, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, err
+		}
+		return domain.NewStringLabel(labelKey, string(decoded)), nil
+	case stringSetTag:
+		members := make([]string, 0, len(encoded))
+		for _, memberEncoded := range encoded {
+			if !strings.HasPrefix(memberEncoded, stringSetTag) {
+				return nil, fmt.Errorf("label %s has index keys of different types", labelKey)
+			}
+			decoded, err := hex.DecodeString(memberEncoded[1:])
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, string(decoded))
+		}
+		return domain.NewStringSetLabel(labelKey, members), nil
+	default:
+		return nil, fmt.Errorf("unknown label value type tag %q", tag)
+	}
+}
+
+func encodeBool(value bool) string {
+	if value {
+		return boolTag + "1"
+	}
+	return boolTag + "0"
+}
+
+func encodeFloat64(value float64) string {
+	rounded := math.Round(value*100) / 100
+	if rounded == 0 {
+		// -0 and 0 compare equal
+		rounded = 0
+	}
+	bits := math.Float64bits(rounded)
+	if bits&(1<<63) != 0 {
+		bits = ^bits
+	} else {
+		bits |= 1 << 63
+	}
+	return fmt.Sprintf("%s%016x", float64Tag, bits)
+}
+
+func encodeInt64(value int64) string {
+	return int64Tag + hexInt64(value)
+}
+
+func encodeTimestamp(value time.Time) string {
+	return fmt.Sprintf("%s%s%08x", timestampTag, hexInt64(value.Unix()), value.Nanosecond())
+}
+
+func encodeSemver(value domain.Version) string {
+	encoded := fmt.Sprintf("%s%016x%016x%016x", semverTag, value.Major, value.Minor, value.Patch)
+	if len(value.Prerelease) == 0 {
+		return encoded + "1"
+	}
+	encoded += "0"
+	for _, identifier := range value.Prerelease {
+		if number, err := strconv.ParseUint(identifier, 10, 64); err == nil && (identifier == "0" || identifier[0] != '0') {
+			encoded += fmt.Sprintf(":0%016x", number)
+		} else {
+			encoded += ":1" + hex.EncodeToString([]byte(identifier)) + "00"
+		}
+	}
+	return encoded
+}
+
+func encodeString(value string) string {
+	return stringTag + hex.EncodeToString([]byte(value))
+}
+
+func encodeStringSetMember(value string) string {
+	return stringSetTag + hex.EncodeToString([]byte(value))
+}
+
+func hexInt64(value int64) string {
+	return fmt.Sprintf("%016x", uint64(value)^(1<<63))
+}
+
+func decodeInt64(value string) (int64, error) {
+	bits, err := strconv.ParseUint(value, 16, 64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(bits ^ (1 << 63)), nil
+}
+
+func decodeSemver(value string) (domain.Version, error) {
+	invalid := fmt.Errorf("invalid semver encoding %q", value)
+	if len(value) < 49 {
+		return domain.Version{}, invalid
+	}
+	version := domain.Version{}
+	for i, number := range []*uint64{&version.Major, &version.Minor, &version.Patch} {
+		decoded, err := strconv.ParseUint(value[i*16:(i+1)*16], 16, 64)
+		if err != nil {
+			return domain.Version{}, invalid
+		}
+		*number = decoded
+	}
+	if value[48:] == "1" {
+		return version, nil
+	}
+	if value[48] != '0' {
+		return domain.Version{}, invalid
+	}
+	for _, identifier := range strings.Split(value[49:], ":")[1:] {
+		if len(identifier) == 0 {
+			return domain.Version{}, invalid
+		}
+		if identifier[0] == '0' {
+			number, err := strconv.ParseUint(identifier[1:], 16, 64)
+			if err != nil {
+				return domain.Version{}, invalid
+			}
+			version.Prerelease = append(version.Prerelease, strconv.FormatUint(number, 10))
+			continue
+		}
+		decoded, err := hex.DecodeString(strings.TrimSuffix(identifier[1:], "00"))
+		if err != nil {
+			return domain.Version{}, invalid
+		}
+		version.Prerelease = append(version.Prerelease, string(decoded))
+	}
+	return version, nil
+}
+
+// keyRange is the half-open range [start, end)
+type keyRange struct {
+	start string
+	end   string
+}
+
+func prefixRange(prefix string) keyRange {
+	return keyRange{
+		start: prefix,
+		end:   etcd.GetPrefixRangeEnd(prefix),
+	}
+}
+
+// labelIndexRanges returns the index key ranges holding every node that can match the selector,
+// labelPrefix being {scope}/{labelKey}/
+// unless exact is returned, nodes in the ranges still have to be checked with the selector's matcher
+func labelIndexRanges(selector domain.Selector, labelPrefix string) (ranges []keyRange, exact bool) {
+	switch selector.ShouldBe {
+	case domain.CompResExists, domain.CompResDoesNotExist:
+		return []keyRange{prefixRange(labelPrefix)}, true
+	case domain.CompResEq:
+		return equalRanges(selector.Value, labelPrefix), true
+	case domain.CompResIn:
+		for _, value := range selector.Values {
+			ranges = append(ranges, equalRanges(value, labelPrefix)...)
+		}
+		return ranges, true
+	case domain.CompResGt, domain.CompResGe, domain.CompResLt, domain.CompResLe:
+		points := orderedEncodings(selector.Value)
+		for _, tag := range orderedTags {
+			encoded, ok := points[tag]
+			if !ok {
+				continue
+			}
+			typeRange := prefixRange(labelPrefix + tag)
+			point := labelPrefix + encoded + "/"
+			switch selector.ShouldBe {
+			case domain.CompResGt:
+				ranges = append(ranges, keyRange{start: etcd.GetPrefixRangeEnd(point), end: typeRange.end})
+			case domain.CompResGe:
+				ranges = append(ranges, keyRange{start: point, end: typeRange.end})
+			case domain.CompResLt:
+				ranges = append(ranges, keyRange{start: typeRange.start, end: point})
+			case domain.CompResLe:
+				ranges = append(ranges, keyRange{start: typeRange.start, end: etcd.GetPrefixRangeEnd(point)})
+			}
+		}
+		if selector.ShouldBe == domain.CompResGe || selector.ShouldBe == domain.CompResLe {
+			// labels of other types only compare as equal or not
+			for _, r := range equalRanges(selector.Value, labelPrefix) {
+				if !slices.ContainsFunc(orderedTags, func(tag string) bool {
+					return strings.HasPrefix(r.start, labelPrefix+tag)
+				}) {
+					ranges = append(ranges, r)
+				}
+			}
+		}
+		return ranges, true
+	case domain.CompResPrefix:
+		return []keyRange{
+			prefixRange(labelPrefix + encodeString(selector.Value)),
+			stringSetPrefixRange(selector.Value, labelPrefix),
+		}, true
+	default:
+		return []keyRange{prefixRange(labelPrefix)}, false
+	}
+}
+
+// equalRanges covers labels of every type the value can be parsed as, and string sets containing the value,
+// string sets never contain an empty string, and the member key of one would be the key of an empty set
+func equalRanges(value, labelPrefix string) []keyRange {
+	ranges := []keyRange{prefixRange(labelPrefix + encodeString(value) + "/")}
+	if value != "" {
+		ranges = append(ranges, prefixRange(labelPrefix+encodeStringSetMember(value)+"/"))
+	}
+	if boolValue, err := strconv.ParseBool(value); err == nil {
+		ranges = append(ranges, prefixRange(labelPrefix+encodeBool(boolValue)+"/"))
+	}
+	points := orderedEncodings(value)
+	for _, tag := range orderedTags {
+		if encoded, ok := points[tag]; ok {
+			ranges = append(ranges, prefixRange(labelPrefix+encoded+"/"))
+		}
+	}
+	return ranges
+}
+
+// orderedEncodings returns the encodings of the value for every ordered type it can be parsed as, by type tag
+func orderedEncodings(value string) map[string]string {
+	encodings := make(map[string]string)
+	if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+		encodings[float64Tag] = encodeFloat64(floatValue)
+	}
+	if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
+		encodings[int64Tag] = encodeInt64(intValue)
+	}
+	if timeValue, err := time.Parse(time.RFC3339, value); err == nil {
+		encodings[timestampTag] = encodeTimestamp(timeValue)
+	}
+	if version, err := domain.ParseVersion(value); err == nil {
+		encodings[semverTag] = encodeSemver(version)
+	}
+	return encodings
+}
+
+// stringSetPrefixRange covers the members of string sets starting with the prefix,
+// the key of an empty set is left out, even for an empty prefix
+func stringSetPrefixRange(prefix, labelPrefix string) keyRange {
+	members := prefixRange(labelPrefix + encodeStringSetMember(prefix))
+	if prefix == "" {
+		// hex digits follow the tag in member keys, a slash in the key of an empty set
+		members.start = labelPrefix + stringSetTag + "0"
+	}
+	return members
+}
+
+// matchIndexKeys returns the nodes whose labels match, decoding them from the index keys unless exact is set,
+// along with all nodes that have any of the keys,
+// the keys of a string set label are matched together, so all of them have to be among the keys
+func matchIndexKeys(keys []string, labelPrefix, labelKey string, exact bool, matches func(label domain.Label) bool) (nodeIds, labeled []domain.NodeId, err error) {
+	encodings := make(map[domain.NodeId][]string)
+	labeled = make([]domain.NodeId, 0)
+	for _, key := range keys {
+		encoded, nodeId, ok := splitIndexKey(key, labelPrefix)
+		if !ok {
+			continue
+		}
+		if _, seen := encodings[nodeId]; !seen {
+			labeled = append(labeled, nodeId)
+		}
+		encodings[nodeId] = append(encodings[nodeId], encoded)
+	}
+	nodeIds = make([]domain.NodeId, 0, len(labeled))
+	for _, nodeId := range labeled {
+		if !exact {
+			nodeLabel, err := decodeLabel(labelKey, encodings[nodeId])
+			if err != nil {
+				return nil, nil, err
+			}
+			if !matches(nodeLabel) {
+				continue
+			}
+		}
+		nodeIds = append(nodeIds, nodeId)
+	}
+	return nodeIds, labeled, nil
+}
+
+// selectorIndexPrefix returns the prefix of the index keys of the label or the resource the selector targets,
+// keyPrefix being the label index prefix of the pool or the org
+func selectorIndexPrefix(keyPrefix string, selector domain.Selector) string {
+	if selector.Resource != "" {
+		return fmt.Sprintf("%s%s/%s/", resourceKeyPrefix, strings.TrimPrefix(keyPrefix, queryKeyPrefix), selector.Resource)
+	}
+	return fmt.Sprintf("%s/%s/", keyPrefix, selector.LabelKey)
+}
+
+// splitIndexKey returns the encoded value and the node id of an index key under labelPrefix,
+// ok is false for keys of other labels whose keys merely start with the same characters
+func splitIndexKey(key, labelPrefix string) (encoded string, nodeId domain.NodeId, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(key, labelPrefix), "/")
+	if len(parts) != 2 {
+		return "", domain.NodeId{}, false
+	}
+	return parts[0], domain.NodeId{Value: parts[1]}, true
+}
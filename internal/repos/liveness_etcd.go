@@ -0,0 +1,164 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/c12s/magnetar/internal/domain"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	etcd "go.etcd.io/etcd/client/v3"
+)
+
+// data model
+// key - liveness/ready/{nodeId}, attached to a lease per node that expires unless the node keeps sending heartbeats
+// key - liveness/seen/{nodeId}, outlives the lease, but not the node
+// value - time of the last heartbeat (RFC3339)
+// both keys are written only while the node's get model key exists and are deleted along with the node
+
+type nodeLivenessEtcdRepo struct {
+	etcd *etcd.Client
+}
+
+func NewNodeLivenessEtcdRepo(etcd *etcd.Client) (domain.NodeLivenessRepo, error) {
+	return &nodeLivenessEtcdRepo{
+		etcd: etcd,
+	}, nil
+}
+
+// Heartbeat renews the lease the ready key is attached to,
+// a new lease is granted only if the node has none or the previous one has expired
+func (n nodeLivenessEtcdRepo) Heartbeat(node domain.Node, ttl time.Duration) error {
+	nodeId := node.Id
+	// a heartbeat racing with the node's deletion must not leave its keys behind
+	registered := etcd.Compare(etcd.CreateRevision(getKey(node)), ">", 0)
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	resp, err := n.etcd.Get(context.TODO(), readyKey(nodeId))
+	if err != nil {
+		return err
+	}
+	prevModRevision := int64(0)
+	if resp.Count > 0 {
+		_, err := n.etcd.KeepAliveOnce(context.TODO(), etcd.LeaseID(resp.Kvs[0].Lease))
+		if err == nil {
+			txnResp, err := n.etcd.Txn(context.TODO()).
+				If(registered).
+				Then(
+					etcd.OpPut(readyKey(nodeId), now, etcd.WithIgnoreLease()),
+					etcd.OpPut(lastSeenKey(nodeId), now),
+				).
+				Commit()
+			if err != nil {
+				return err
+			}
+			if !txnResp.Succeeded {
+				return domain.ErrNodeNotFound
+			}
+			return nil
+		}
+		if !errors.Is(err, rpctypes.ErrLeaseNotFound) {
+			return err
+		}
+		prevModRevision = resp.Kvs[0].ModRevision
+	}
+	lease, err := n.etcd.Grant(context.TODO(), leaseTTL(ttl))
+	if err != nil {
+		return err
+	}
+	// concurrent heartbeats of the same node must not leave a lease behind each
+	txnResp, err := n.etcd.Txn(context.TODO()).
+		If(etcd.Compare(etcd.ModRevision(readyKey(nodeId)), "=", prevModRevision), registered).
+		Then(
+			etcd.OpPut(readyKey(nodeId), now, etcd.WithLease(lease.ID)),
+			etcd.OpPut(lastSeenKey(nodeId), now),
+		).
+		Else(etcd.OpGet(getKey(node), etcd.WithCountOnly())).
+		Commit()
+	if err == nil && txnResp.Succeeded {
+		return nil
+	}
+	if _, revokeErr := n.etcd.Revoke(context.TODO(), lease.ID); revokeErr != nil {
+		log.Println(revokeErr)
+	}
+	if err != nil {
+		return err
+	}
+	// otherwise a concurrent heartbeat has already been recorded
+	if txnResp.Responses[0].GetResponseRange().Count == 0 {
+		return domain.ErrNodeNotFound
+	}
+	return nil
+}
+
+func (n nodeLivenessEtcdRepo) Get(nodeIds []domain.NodeId) (map[domain.NodeId]domain.NodeLiveness, error) {
+	liveness := make(map[domain.NodeId]domain.NodeLiveness)
+	// stay within the default limit of 128 ops per txn
+	const chunkSize = 64
+	for start := 0; start < len(nodeIds); start += chunkSize {
+		end := min(start+chunkSize, len(nodeIds))
+		ops := make([]etcd.Op, 0, 2*(end-start))
+		for _, nodeId := range nodeIds[start:end] {
+			ops = append(ops, etcd.OpGet(readyKey(nodeId)), etcd.OpGet(lastSeenKey(nodeId)))
+		}
+		resp, err := n.etcd.Txn(context.TODO()).Then(ops...).Commit()
+		if err != nil {
+			return nil, err
+		}
+		for i, nodeId := range nodeIds[start:end] {
+			seen := resp.Responses[2*i+1].GetResponseRange()
+			if seen == nil || len(seen.Kvs) == 0 {
+				continue
+			}
+			lastSeen, err := time.Parse(time.RFC3339Nano, string(seen.Kvs[0].Value))
+			if err != nil {
+				return nil, err
+			}
+			status := domain.NodeStatusNotReady
+			if ready := resp.Responses[2*i].GetResponseRange(); ready != nil && len(ready.Kvs) > 0 {
+				status = domain.NodeStatusReady
+			}
+			liveness[nodeId] = domain.NodeLiveness{
+				Status:   status,
+				LastSeen: lastSeen,
+			}
+		}
+	}
+	return liveness, nil
+}
+
+func (n nodeLivenessEtcdRepo) Delete(nodeId domain.NodeId) error {
+	resp, err := n.etcd.Txn(context.TODO()).
+		Then(
+			etcd.OpDelete(readyKey(nodeId), etcd.WithPrevKV()),
+			etcd.OpDelete(lastSeenKey(nodeId)),
+		).
+		Commit()
+	if err != nil {
+		return err
+	}
+	// the lease would expire on its own, it is revoked so it doesn't linger until then
+	if prev := resp.Responses[0].GetResponseDeleteRange().PrevKvs; len(prev) > 0 && prev[0].Lease != 0 {
+		if _, err := n.etcd.Revoke(context.TODO(), etcd.LeaseID(prev[0].Lease)); err != nil && !errors.Is(err, rpctypes.ErrLeaseNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+const livenessKeyPrefix = "liveness"
+
+// leaseTTL rounds the ttl up to whole seconds, the granularity of etcd leases
+func leaseTTL(ttl time.Duration) int64 {
+	return max(int64(math.Ceil(ttl.Seconds())), 1)
+}
+
+func readyKey(nodeId domain.NodeId) string {
+	return fmt.Sprintf("%s/ready/%s", livenessKeyPrefix, nodeId.Value)
+}
+
+func lastSeenKey(nodeId domain.NodeId) string {
+	return fmt.Sprintf("%s/seen/%s", livenessKeyPrefix, nodeId.Value)
+}
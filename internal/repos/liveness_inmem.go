@@ -0,0 +1,63 @@
+package repos
+
+import (
+	"sync"
+	"time"
+
+	"github.com/c12s/magnetar/internal/domain"
+)
+
+type nodeLivenessInMemRepo struct {
+	heartbeats map[domain.NodeId]inMemHeartbeat
+	mu         sync.RWMutex
+}
+
+func NewNodeLivenessInMemRepo() (domain.NodeLivenessRepo, error) {
+	return &nodeLivenessInMemRepo{
+		heartbeats: make(map[domain.NodeId]inMemHeartbeat),
+	}, nil
+}
+
+func (n *nodeLivenessInMemRepo) Heartbeat(node domain.Node, ttl time.Duration) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	now := time.Now().UTC()
+	n.heartbeats[node.Id] = inMemHeartbeat{
+		lastSeen: now,
+		expires:  now.Add(ttl),
+	}
+	return nil
+}
+
+func (n *nodeLivenessInMemRepo) Get(nodeIds []domain.NodeId) (map[domain.NodeId]domain.NodeLiveness, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	liveness := make(map[domain.NodeId]domain.NodeLiveness)
+	for _, nodeId := range nodeIds {
+		heartbeat, ok := n.heartbeats[nodeId]
+		if !ok {
+			continue
+		}
+		status := domain.NodeStatusNotReady
+		if time.Now().Before(heartbeat.expires) {
+			status = domain.NodeStatusReady
+		}
+		liveness[nodeId] = domain.NodeLiveness{
+			Status:   status,
+			LastSeen: heartbeat.lastSeen,
+		}
+	}
+	return liveness, nil
+}
+
+func (n *nodeLivenessInMemRepo) Delete(nodeId domain.NodeId) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.heartbeats, nodeId)
+	return nil
+}
+
+type inMemHeartbeat struct {
+	lastSeen time.Time
+	expires  time.Time
+}
@@ -0,0 +1,152 @@
+package repos_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/c12s/magnetar/internal/domain"
+	"github.com/c12s/magnetar/internal/repos"
+	etcd "go.etcd.io/etcd/client/v3"
+)
+
+func TestNodeLivenessInMemRepo(t *testing.T) {
+	repo, err := repos.NewNodeLivenessInMemRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testNodeLivenessRepo(t, repo, func(domain.NodeId) {})
+
+	expiring := domain.NodeId{Value: "expiring"}
+	if err := repo.Heartbeat(domain.Node{Id: expiring}, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	liveness, err := repo.Get([]domain.NodeId{expiring})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if liveness[expiring].Status != domain.NodeStatusNotReady {
+		t.Errorf("expected expired node to be NotReady, got %s", liveness[expiring].Status)
+	}
+}
+
+// requires a running etcd instance, e.g. ETCD_ADDRESS=localhost:2379
+func TestNodeLivenessEtcdRepo(t *testing.T) {
+	address := os.Getenv("ETCD_ADDRESS")
+	if address == "" {
+		t.Skip("ETCD_ADDRESS not set")
+	}
+	client, err := etcd.New(etcd.Config{
+		Endpoints: []string{fmt.Sprintf("http://%s", address)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	for _, prefix := range []string{"liveness/", "nodes/"} {
+		if _, err := client.Delete(context.TODO(), prefix, etcd.WithPrefix()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo, err := repos.NewNodeLivenessEtcdRepo(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// heartbeats are recorded only for nodes stored in the pool
+	register := func(nodeId domain.NodeId) {
+		if _, err := client.Put(context.TODO(), "nodes/pool/"+nodeId.Value, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testNodeLivenessRepo(t, repo, register)
+
+	unregistered := domain.NodeId{Value: "unregistered"}
+	if err := repo.Heartbeat(domain.Node{Id: unregistered}, time.Minute); !errors.Is(err, domain.ErrNodeNotFound) {
+		t.Errorf("expected an unregistered node to be rejected, got %v", err)
+	}
+	resp, err := client.Get(context.TODO(), "liveness/", etcd.WithPrefix(), etcd.WithCountOnly())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Count != 0 {
+		t.Errorf("expected no liveness keys for an unregistered node, got %d", resp.Count)
+	}
+
+	// sub-second ttls are rounded up and every heartbeat renews the same lease
+	renewed := domain.NodeId{Value: "renewed"}
+	register(renewed)
+	leases := make([]int64, 0)
+	for i := 0; i < 2; i++ {
+		if err := repo.Heartbeat(domain.Node{Id: renewed}, 500*time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Get(context.TODO(), "liveness/ready/renewed")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Count == 0 {
+			t.Fatal("expected the ready key to be set")
+		}
+		leases = append(leases, resp.Kvs[0].Lease)
+	}
+	if leases[0] != leases[1] {
+		t.Errorf("expected a single lease, got %d and %d", leases[0], leases[1])
+	}
+	liveness, err := repo.Get([]domain.NodeId{renewed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if liveness[renewed].Status != domain.NodeStatusReady {
+		t.Errorf("expected node to be Ready, got %s", liveness[renewed].Status)
+	}
+	if err := repo.Delete(renewed); err != nil {
+		t.Fatal(err)
+	}
+	ttl, err := client.TimeToLive(context.TODO(), etcd.LeaseID(leases[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ttl.TTL != -1 {
+		t.Errorf("expected the lease to be revoked, got ttl %d", ttl.TTL)
+	}
+}
+
+func testNodeLivenessRepo(t *testing.T, repo domain.NodeLivenessRepo, register func(nodeId domain.NodeId)) {
+	ready := domain.NodeId{Value: "ready"}
+	unknown := domain.NodeId{Value: "unknown"}
+	register(ready)
+	before := time.Now().Add(-time.Second)
+	if err := repo.Heartbeat(domain.Node{Id: ready}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	liveness, err := repo.Get([]domain.NodeId{ready, unknown})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if liveness[ready].Status != domain.NodeStatusReady {
+		t.Errorf("expected node to be Ready, got %s", liveness[ready].Status)
+	}
+	if liveness[ready].LastSeen.Before(before) {
+		t.Errorf("expected last seen after %s, got %s", before, liveness[ready].LastSeen)
+	}
+	if _, ok := liveness[unknown]; ok {
+		t.Errorf("expected no liveness for a node that never sent a heartbeat")
+	}
+
+	if err := repo.Delete(ready); err != nil {
+		t.Fatal(err)
+	}
+	liveness, err = repo.Get([]domain.NodeId{ready})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := liveness[ready]; ok {
+		t.Errorf("expected no liveness for a deleted node")
+	}
+}
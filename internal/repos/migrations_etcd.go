@@ -0,0 +1,309 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/c12s/magnetar/internal/domain"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	etcd "go.etcd.io/etcd/client/v3"
+	"golang.org/x/exp/slices"
+)
+
+// legacy query model, labels were looked up by key only
+// key - labels/pool/{labelKey}/{nodeId} | labels/orgs/{orgId}/{labelKey}/{nodeId}
+const legacyQueryKeyPrefix = "labels"
+
+// MigrateNodeEtcdLabelIndex rebuilds the value-encoded label index from the get model while legacy keys exist,
+// replicas that haven't been upgraded yet keep reading and writing the legacy query model during a rolling upgrade,
+// so the legacy keys are only removed once deleteLegacy confirms that every replica has moved to the label index,
+// the index is rebuilt right before, so it also covers the nodes written by the replicas upgraded last
+func MigrateNodeEtcdLabelIndex(client *etcd.Client, nodeMarshaller domain.NodeMarshaller, labelMarshaller domain.LabelMarshaller, deleteLegacy bool) error {
+	legacyPrefix := legacyQueryKeyPrefix + "/"
+	legacy, err := client.Get(context.TODO(), legacyPrefix, etcd.WithPrefix(), etcd.WithCountOnly())
+	if err != nil {
+		return err
+	}
+	if legacy.Count == 0 {
+		return nil
+	}
+	log.Printf("migrating %d legacy label keys to the label index\n", legacy.Count)
+	err = rebuildNodeEtcdIndex(client, nodeMarshaller, labelMarshaller)
+	if err != nil {
+		return err
+	}
+	if !deleteLegacy {
+		log.Println("keeping the legacy label keys until every replica uses the label index")
+		return nil
+	}
+	_, err = client.Delete(context.TODO(), legacyPrefix, etcd.WithPrefix())
+	return err
+}
+
+// completed migrations without legacy keys to look for are marked by a key
+// key - migrations/{name}
+const migrationsKeyPrefix = "migrations"
+
+// MigrateNodeEtcdResourceIndex builds the resource index for nodes stored before resources were indexed,
+// it does nothing once the migration is marked as done
+func MigrateNodeEtcdResourceIndex(client *etcd.Client, nodeMarshaller domain.NodeMarshaller, labelMarshaller domain.LabelMarshaller) error {
+	marker := fmt.Sprintf("%s/resource-index", migrationsKeyPrefix)
+	resp, err := client.Get(context.TODO(), marker, etcd.WithCountOnly())
+	if err != nil {
+		return err
+	}
+	if resp.Count > 0 {
+		return nil
+	}
+	log.Println("building the resource index")
+	err = rebuildNodeEtcdIndex(client, nodeMarshaller, labelMarshaller)
+	if err != nil {
+		return err
+	}
+	_, err = client.Put(context.TODO(), marker, time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+// nodes are read in pages, so the migration doesn't depend on how many nodes fit in a single response
+const migrationPageSize = 100
+
+// rebuildNodeEtcdIndex puts the whole query model of every stored node and deletes the index keys it no longer has,
+// replicas still on the legacy query model change labels without updating the index, which leaves stale keys behind
+func rebuildNodeEtcdIndex(client *etcd.Client, nodeMarshaller domain.NodeMarshaller, labelMarshaller domain.LabelMarshaller) error {
+	repo := nodeEtcdRepo{
+		etcd:            client,
+		nodeMarshaller:  nodeMarshaller,
+		labelMarshaller: labelMarshaller,
+	}
+	indexed, err := indexedNodeKeys(client)
+	if err != nil {
+		return err
+	}
+	keyPrefix := getKeyPrefix + "/"
+	startKey := keyPrefix
+	for {
+		resp, err := client.Get(context.TODO(), startKey, etcd.WithRange(etcd.GetPrefixRangeEnd(keyPrefix)), etcd.WithLimit(migrationPageSize))
+		if err != nil {
+			return err
+		}
+		for _, kv := range resp.Kvs {
+			nodeId := nodeFromGetKey(string(kv.Key)).Id
+			if err := repo.rebuildNodeIndex(kv, indexed[nodeId]); err != nil {
+				return fmt.Errorf("migrating %s: %w", kv.Key, err)
+			}
+			delete(indexed, nodeId)
+			startKey = string(kv.Key) + "\x00"
+		}
+		if !resp.More {
+			break
+		}
+	}
+	// the keys left belong to nodes deleted by legacy replicas,
+	// a key written again since it was read belongs to a node registered in the meantime
+	for _, keys := range indexed {
+		for key, modRevision := range keys {
+			_, err := client.Txn(context.TODO()).
+				If(etcd.Compare(etcd.ModRevision(key), "=", modRevision)).
+				Then(etcd.OpDelete(key)).
+				Commit()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// indexedNodeKeys returns the mod revisions of the label and resource index keys by node id, the last segment of every key
+func indexedNodeKeys(client *etcd.Client) (map[domain.NodeId]map[string]int64, error) {
+	indexed := make(map[domain.NodeId]map[string]int64)
+	for _, keyPrefix := range []string{queryKeyPrefix + "/", resourceKeyPrefix + "/"} {
+		startKey := keyPrefix
+		for {
+			resp, err := client.Get(context.TODO(), startKey, etcd.WithRange(etcd.GetPrefixRangeEnd(keyPrefix)), etcd.WithLimit(migrationPageSize), etcd.WithKeysOnly())
+			if err != nil {
+				return nil, err
+			}
+			for _, kv := range resp.Kvs {
+				key := string(kv.Key)
+				nodeId := domain.NodeId{Value: key[strings.LastIndex(key, "/")+1:]}
+				if indexed[nodeId] == nil {
+					indexed[nodeId] = make(map[string]int64)
+				}
+				indexed[nodeId][key] = kv.ModRevision
+				startKey = key + "\x00"
+			}
+			if !resp.More {
+				break
+			}
+		}
+	}
+	return indexed, nil
+}
+
+// rebuildNodeIndex puts the query model of the node and deletes its indexed keys the query model doesn't have,
+// as long as the node is unchanged, replicas still on the legacy query model don't maintain the index,
+// so a node changed in the meantime is read again
+func (n nodeEtcdRepo) rebuildNodeIndex(kv *mvccpb.KeyValue, indexed map[string]int64) error {
+	for {
+		node, err := n.unmarshalNode(kv.Value, kv.ModRevision)
+		if err != nil {
+			return err
+		}
+		ops, err := n.putNodeQueryModel(*node)
+		if err != nil {
+			return err
+		}
+		keys, err := queryModelKeys(*node)
+		if err != nil {
+			return err
+		}
+		// a txn can't touch a key twice, the puts cover the keys the node still has
+		stale := make([]etcd.Op, 0)
+		for key := range indexed {
+			if !slices.Contains(keys, key) {
+				stale = append(stale, etcd.OpDelete(key))
+			}
+		}
+		unchanged := etcd.Compare(etcd.ModRevision(string(kv.Key)), "=", kv.ModRevision)
+		// stale keys beyond what fits next to the puts are deleted first, each txn checking the node is unchanged
+		for len(stale) > 0 && len(stale)+len(ops) > txnOpsLimit {
+			chunk := min(len(stale), txnOpsLimit)
+			resp, err := n.etcd.Txn(context.TODO()).If(unchanged).Then(stale[:chunk]...).Commit()
+			if err != nil {
+				return err
+			}
+			if !resp.Succeeded {
+				break
+			}
+			stale = stale[chunk:]
+		}
+		resp, err := n.etcd.Txn(context.TODO()).
+			If(unchanged).
+			Then(append(stale, ops...)...).
+			Else(etcd.OpGet(string(kv.Key))).
+			Commit()
+		if err != nil {
+			return err
+		}
+		if resp.Succeeded {
+			return nil
+		}
+		kvs := resp.Responses[0].GetResponseRange().Kvs
+		// a node deleted or moved to another key in the meantime is indexed under that key, if any
+		if len(kvs) == 0 {
+			return nil
+		}
+		kv = kvs[0]
+	}
+}
+
+// MigrateOperationEtcdUnfinishedIndex adds the unfinished operation keys of operations stored before they were indexed,
+// it does nothing once the migration is marked as done
+func MigrateOperationEtcdUnfinishedIndex(client *etcd.Client, marshaller domain.OperationMarshaller) error {
+	marker := fmt.Sprintf("%s/unfinished-operation-index", migrationsKeyPrefix)
+	resp, err := client.Get(context.TODO(), marker, etcd.WithCountOnly())
+	if err != nil {
+		return err
+	}
+	if resp.Count > 0 {
+		return nil
+	}
+	log.Println("building the unfinished operation index")
+	keyPrefix := operationKeyPrefix + "/"
+	startKey := keyPrefix
+	for {
+		resp, err := client.Get(context.TODO(), startKey, etcd.WithRange(etcd.GetPrefixRangeEnd(keyPrefix)), etcd.WithLimit(migrationPageSize))
+		if err != nil {
+			return err
+		}
+		for _, kv := range resp.Kvs {
+			operation, err := marshaller.Unmarshal(kv.Value)
+			if err != nil {
+				return fmt.Errorf("migrating %s: %w", kv.Key, err)
+			}
+			startKey = string(kv.Key) + "\x00"
+			if operation.Finished() {
+				continue
+			}
+			// an operation that has finished in the meantime isn't indexed
+			_, err = client.Txn(context.TODO()).
+				If(etcd.Compare(etcd.ModRevision(string(kv.Key)), "=", kv.ModRevision)).
+				Then(etcd.OpPut(unfinishedOperationKey(operation.Id, operation.Org), "")).
+				Commit()
+			if err != nil {
+				return err
+			}
+		}
+		if !resp.More {
+			break
+		}
+	}
+	_, err = client.Put(context.TODO(), marker, time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+// sagas claims were stored as before they became claim operations
+// key - sagas/claims/{sagaId}
+// value - the saga, finished sagas are attached to a lease so they are kept around for a while only
+const legacyClaimSagaKeyPrefix = "sagas/claims"
+
+// MigrateEtcdClaimSagas stores the sagas left from before claims became operations as claim operations,
+// unfinished ones are resumed along with the other unfinished operations and finished ones keep the lease they expire with,
+// each saga is deleted in the txn that stores its operation, so a saga still updated by a replica that hasn't been upgraded
+// is migrated on the next start, that replica stops running it once the saga is gone
+func MigrateEtcdClaimSagas(client *etcd.Client, sagaUnmarshaller domain.ClaimSagaUnmarshaller, operationMarshaller domain.OperationMarshaller) error {
+	keyPrefix := legacyClaimSagaKeyPrefix + "/"
+	legacy, err := client.Get(context.TODO(), keyPrefix, etcd.WithPrefix(), etcd.WithCountOnly())
+	if err != nil {
+		return err
+	}
+	if legacy.Count == 0 {
+		return nil
+	}
+	log.Printf("migrating %d claim sagas to operations\n", legacy.Count)
+	startKey := keyPrefix
+	for {
+		resp, err := client.Get(context.TODO(), startKey, etcd.WithRange(etcd.GetPrefixRangeEnd(keyPrefix)), etcd.WithLimit(migrationPageSize))
+		if err != nil {
+			return err
+		}
+		for _, kv := range resp.Kvs {
+			startKey = string(kv.Key) + "\x00"
+			operation, err := sagaUnmarshaller.Unmarshal(kv.Value)
+			if err != nil {
+				return fmt.Errorf("migrating %s: %w", kv.Key, err)
+			}
+			operationMarshalled, err := operationMarshaller.Marshal(*operation)
+			if err != nil {
+				return fmt.Errorf("migrating %s: %w", kv.Key, err)
+			}
+			key := operationKey(operation.Id, operation.Org)
+			ops := []etcd.Op{etcd.OpDelete(string(kv.Key))}
+			if operation.Finished() {
+				ops = append(ops, etcd.OpPut(key, string(operationMarshalled), etcd.WithLease(etcd.LeaseID(kv.Lease))))
+			} else {
+				ops = append(ops, etcd.OpPut(key, string(operationMarshalled)), etcd.OpPut(unfinishedOperationKey(operation.Id, operation.Org), ""))
+			}
+			// a saga updated since it was read is left for the next start, one that has expired
+			// or has been migrated by another replica starting at the same time is gone already
+			txnResp, err := client.Txn(context.TODO()).
+				If(etcd.Compare(etcd.ModRevision(string(kv.Key)), "=", kv.ModRevision), etcd.Compare(etcd.CreateRevision(key), "=", 0)).
+				Then(ops...).
+				Commit()
+			if err != nil {
+				return fmt.Errorf("migrating %s: %w", kv.Key, err)
+			}
+			if !txnResp.Succeeded {
+				log.Printf("skipping claim saga %s, it has been updated or deleted since it was read\n", kv.Key)
+			}
+		}
+		if !resp.More {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+package repos_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/c12s/magnetar/internal/domain"
+	mapper "github.com/c12s/magnetar/internal/mappers/proto"
+	"github.com/c12s/magnetar/internal/marshallers/proto"
+	"github.com/c12s/magnetar/internal/repos"
+	protobuf "github.com/golang/protobuf/proto"
+	etcd "go.etcd.io/etcd/client/v3"
+)
+
+// requires a running etcd instance, e.g. ETCD_ADDRESS=localhost:2379
+func TestMigrateNodeEtcdLabelIndex(t *testing.T) {
+	address := os.Getenv("ETCD_ADDRESS")
+	if address == "" {
+		t.Skip("ETCD_ADDRESS not set")
+	}
+	client, err := etcd.New(etcd.Config{
+		Endpoints: []string{fmt.Sprintf("http://%s", address)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	for _, prefix := range []string{"nodes/", "labels/", "index/", "resources/", "migrations/"} {
+		if _, err := client.Delete(context.TODO(), prefix, etcd.WithPrefix()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	nodeMarshaller := proto.NewProtoNodeMarshaller()
+	labelMarshaller := proto.NewProtoLabelMarshaller()
+	repo, err := repos.NewNodeEtcdRepo(client, nodeMarshaller, labelMarshaller)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// index keys left behind by legacy replicas, which changed n1's label and deleted n3 without updating the index
+	mustPut(t, repo, newTestNode("n1", "", domain.NewStringLabel("os", "windows")))
+	mustPut(t, repo, newTestNode("n3", "", domain.NewStringLabel("os", "windows")))
+	if _, err := client.Delete(context.TODO(), "nodes/pool/n3"); err != nil {
+		t.Fatal(err)
+	}
+
+	// nodes written with the legacy layout
+	n1 := newTestNode("n1", "", domain.NewStringLabel("os", "linux"), domain.NewFloat64Label("cpu", 8))
+	n1.Resources = map[string]float64{"memory": 64}
+	legacy := map[string]domain.Node{
+		"nodes/pool/n1":      n1,
+		"nodes/orgs/org1/n2": newTestNode("n2", "org1", domain.NewStringLabel("os", "linux")),
+	}
+	// more nodes than fit in a single page of the migration
+	for i := 0; i < 150; i++ {
+		id := fmt.Sprintf("m%03d", i)
+		legacy["nodes/pool/"+id] = newTestNode(id, "", domain.NewStringLabel("zone", "a"))
+	}
+	for key, node := range legacy {
+		nodeMarshalled, err := nodeMarshaller.Marshal(node)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.Put(context.TODO(), key, string(nodeMarshalled)); err != nil {
+			t.Fatal(err)
+		}
+		for _, label := range node.Labels {
+			labelMarshalled, err := labelMarshaller.Marshal(label)
+			if err != nil {
+				t.Fatal(err)
+			}
+			labelKey := fmt.Sprintf("labels/pool/%s/%s", label.Key(), node.Id.Value)
+			if node.Claimed() {
+				labelKey = fmt.Sprintf("labels/orgs/%s/%s/%s", node.Org, label.Key(), node.Id.Value)
+			}
+			if _, err := client.Put(context.TODO(), labelKey, string(labelMarshalled)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// legacy keys are kept until every replica has moved to the label index,
+	// the last run has nothing left to migrate
+	for _, deleteLegacy := range []bool{false, true, true} {
+		if err := repos.MigrateNodeEtcdLabelIndex(client, nodeMarshaller, labelMarshaller, deleteLegacy); err != nil {
+			t.Fatal(err)
+		}
+		if err := repos.MigrateNodeEtcdResourceIndex(client, nodeMarshaller, labelMarshaller); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Get(context.TODO(), "labels/", etcd.WithPrefix(), etcd.WithCountOnly())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deleteLegacy != (resp.Count == 0) {
+			t.Errorf("got %d legacy keys after a migration deleting them: %t", resp.Count, deleteLegacy)
+		}
+	}
+
+	nodes, _, err := repo.QueryNodePool(domain.Query{{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "windows"}}, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes)
+	resp, err := client.Get(context.TODO(), "index/", etcd.WithPrefix(), etcd.WithKeysOnly())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, kv := range resp.Kvs {
+		if strings.HasSuffix(string(kv.Key), "/n3") {
+			t.Errorf("expected the index keys of a deleted node to be removed, got %s", kv.Key)
+		}
+	}
+	nodes, _, err = repo.QueryNodePool(domain.Query{{LabelKey: "cpu", ShouldBe: domain.CompResGe, Value: "8"}}, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n1")
+	nodes, _, err = repo.QueryNodePool(domain.Query{{LabelKey: "zone", ShouldBe: domain.CompResEq, Value: "a"}}, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 150 {
+		t.Errorf("expected all 150 nodes to be indexed, got %d", len(nodes))
+	}
+	nodes, _, err = repo.QueryOrgOwnedNodes(domain.Query{{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "linux"}}, "org1", domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n2")
+	nodes, _, err = repo.QueryNodePool(domain.Query{{Resource: "memory", ShouldBe: domain.CompResGt, Value: "32"}}, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n1")
+}
+
+// requires a running etcd instance, e.g. ETCD_ADDRESS=localhost:2379
+func TestMigrateOperationEtcdUnfinishedIndex(t *testing.T) {
+	address := os.Getenv("ETCD_ADDRESS")
+	if address == "" {
+		t.Skip("ETCD_ADDRESS not set")
+	}
+	client, err := etcd.New(etcd.Config{
+		Endpoints: []string{fmt.Sprintf("http://%s", address)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	for _, prefix := range []string{"operations/", "cancellations/", "unfinished_operations/", "migrations/"} {
+		if _, err := client.Delete(context.TODO(), prefix, etcd.WithPrefix()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// operations written before unfinished operations were indexed
+	marshaller := proto.NewProtoOperationMarshaller()
+	for _, operation := range []domain.Operation{
+		{Id: "op1", Org: "org1", Kind: domain.OperationClaim, State: domain.OperationRunning},
+		{Id: "op2", Org: "org1", Kind: domain.OperationRelease, State: domain.OperationSucceeded},
+		{Id: "op3", Org: "org2", Kind: domain.OperationClaim, State: domain.OperationCompensating},
+	} {
+		operationMarshalled, err := marshaller.Marshal(operation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.Put(context.TODO(), fmt.Sprintf("operations/%s/%s", operation.Org, operation.Id), string(operationMarshalled)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := repos.MigrateOperationEtcdUnfinishedIndex(client, marshaller); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo, err := repos.NewOperationEtcdRepo(client, marshaller)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unfinished, err := repo.ListUnfinished()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, 0)
+	for _, operation := range unfinished {
+		ids = append(ids, operation.Id)
+	}
+	if fmt.Sprint(ids) != "[op1 op3]" {
+		t.Errorf("got unfinished operations %v, want [op1 op3]", ids)
+	}
+}
+
+// requires a running etcd instance, e.g. ETCD_ADDRESS=localhost:2379
+func TestMigrateEtcdClaimSagas(t *testing.T) {
+	address := os.Getenv("ETCD_ADDRESS")
+	if address == "" {
+		t.Skip("ETCD_ADDRESS not set")
+	}
+	client, err := etcd.New(etcd.Config{
+		Endpoints: []string{fmt.Sprintf("http://%s", address)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	for _, prefix := range []string{"sagas/", "operations/", "cancellations/", "unfinished_operations/"} {
+		if _, err := client.Delete(context.TODO(), prefix, etcd.WithPrefix()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// sagas stored before claims became operations, they were stored in the format GetClaimSaga reports
+	lease, err := client.Grant(context.TODO(), 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	running := domain.Operation{
+		Id:    "s1",
+		Org:   "org1",
+		Kind:  domain.OperationClaim,
+		State: domain.OperationRunning,
+		Step:  domain.OperationStepClaim,
+		Nodes: []domain.OperationNode{
+			{NodeId: domain.NodeId{Value: "n1"}, Step: domain.OperationStepRelate, Status: domain.NodeOperationClaimed},
+			{NodeId: domain.NodeId{Value: "n2"}, Status: domain.NodeOperationFailed, Attempts: 3, Err: "unavailable"},
+		},
+		Requirements: domain.ClaimRequirements{Count: 2, Resources: map[string]float64{"cpu": 4}, Strategy: domain.ClaimBestFit},
+	}
+	aborted := domain.Operation{Id: "s2", Org: "org2", Kind: domain.OperationClaim, State: domain.OperationFailed, Err: "insufficient nodes"}
+	for _, saga := range []domain.Operation{running, aborted} {
+		protoSaga, err := mapper.ClaimSagaFromDomain(saga)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sagaMarshalled, err := protobuf.Marshal(protoSaga)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts := make([]etcd.OpOption, 0)
+		if saga.Finished() {
+			opts = append(opts, etcd.WithLease(lease.ID))
+		}
+		if _, err := client.Put(context.TODO(), "sagas/claims/"+saga.Id, string(sagaMarshalled), opts...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	marshaller := proto.NewProtoOperationMarshaller()
+	for i := 0; i < 2; i++ {
+		if err := repos.MigrateEtcdClaimSagas(client, proto.NewProtoClaimSagaUnmarshaller(), marshaller); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resp, err := client.Get(context.TODO(), "sagas/", etcd.WithPrefix(), etcd.WithCountOnly())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Count != 0 {
+		t.Errorf("expected the sagas to be deleted, got %d keys", resp.Count)
+	}
+	repo, err := repos.NewOperationEtcdRepo(client, marshaller)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unfinished, err := repo.ListUnfinished()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unfinished) != 1 || fmt.Sprint(unfinished[0].Nodes) != fmt.Sprint(running.Nodes) ||
+		unfinished[0].Step != running.Step || unfinished[0].Requirements.Count != 2 || unfinished[0].Requirements.Resources["cpu"] != 4 {
+		t.Errorf("expected the running saga to be resumable as an operation, got %+v", unfinished)
+	}
+	operation, err := repo.Get("s2", "org2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if operation.Kind != domain.OperationClaim || operation.State != domain.OperationFailed || operation.Err != aborted.Err {
+		t.Errorf("unexpected operation %+v", operation)
+	}
+	resp, err = client.Get(context.TODO(), "operations/org2/s2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if etcd.LeaseID(resp.Kvs[0].Lease) != lease.ID {
+		t.Errorf("expected the finished operation to keep the lease of the saga")
+	}
+}
@@ -4,11 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"strconv"
 	"strings"
 
 	"github.com/c12s/magnetar/internal/domain"
 	"github.com/juliangruber/go-intersect"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	etcd "go.etcd.io/etcd/client/v3"
 	"golang.org/x/exp/slices"
 )
@@ -17,9 +18,13 @@ import (
 // for get operations
 // key - nodes/pool/{nodeId} | nodes/orgs/{orgId}/{nodeId}
 // value - protobuf node (id + org + labels)
+// the node resource version is the mod revision of its get key
 // for query operations
-// key - labels/pool/{labelKey}/{nodeId} | labels/orgs/{orgId}/{labelKey}/{nodeId}
+// key - index/pool/{labelKey}/{encodedValue}/{nodeId} | index/orgs/{orgId}/{labelKey}/{encodedValue}/{nodeId}
 // value - protobuf label (key + value)
+// key - resources/pool/{resource}/{encodedValue}/{nodeId} | resources/orgs/{orgId}/{resource}/{encodedValue}/{nodeId}
+// value - resource value
+// see label_index.go for the value encoding, resources are encoded as float64 labels
 
 type nodeEtcdRepo struct {
 	etcd            *etcd.Client
@@ -36,111 +41,469 @@ func NewNodeEtcdRepo(etcd *etcd.Client, nodeMarshaller domain.NodeMarshaller, la
 }
 
 func (n nodeEtcdRepo) Put(node domain.Node) error {
-	err := n.putNodeGetModel(node)
+	getModelOp, err := n.putNodeGetModel(node)
 	if err != nil {
 		return err
 	}
-	return n.putNodeQueryModel(node)
+	queryModelOps, err := n.putNodeQueryModel(node)
+	if err != nil {
+		return err
+	}
+	return n.commit(append(queryModelOps, getModelOp)...)
+}
+
+func (n nodeEtcdRepo) Create(node domain.Node) error {
+	getModelOp, err := n.putNodeGetModel(node)
+	if err != nil {
+		return err
+	}
+	queryModelOps, err := n.putNodeQueryModel(node)
+	if err != nil {
+		return err
+	}
+	ops := append(queryModelOps, getModelOp)
+	if err := checkTxnOps(ops); err != nil {
+		return err
+	}
+	resp, err := n.etcd.Txn(context.TODO()).
+		If(etcd.Compare(etcd.CreateRevision(getKey(node)), "=", 0)).
+		Then(ops...).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return domain.ErrNodeExists
+	}
+	return nil
+}
+
+func (n nodeEtcdRepo) Update(prev domain.Node, node domain.Node) (*domain.Node, error) {
+	node.Id = prev.Id
+	node.Org = prev.Org
+	ops, err := n.deleteStaleQueryModel(prev, node)
+	if err != nil {
+		return nil, err
+	}
+	getModelOp, err := n.putNodeGetModel(node)
+	if err != nil {
+		return nil, err
+	}
+	queryModelOps, err := n.putNodeQueryModel(node)
+	if err != nil {
+		return nil, err
+	}
+	ops = append(ops, queryModelOps...)
+	err = n.commitIfUnchanged(prev, append(ops, getModelOp)...)
+	if err != nil {
+		return nil, err
+	}
+	return n.Get(node.Id, node.Org)
 }
 
 func (n nodeEtcdRepo) Delete(node domain.Node) error {
-	err := n.deleteNodeGetModel(node)
+	ops, err := n.deleteNodeQueryModel(node)
 	if err != nil {
 		return err
 	}
-	return n.deleteNodeQueryModel(node)
+	return n.commitIfUnchanged(node, append(ops, n.deleteNodeGetModel(node))...)
 }
 
-func (n nodeEtcdRepo) Get(nodeId domain.NodeId, org string) (*domain.Node, error) {
-	key := getKey(domain.Node{Id: nodeId, Org: org})
-	resp, err := n.etcd.Get(context.TODO(), key)
+// Claim moves a node from the pool to the org,
+// failing with domain.ErrNodeClaimed if someone else claimed it in the meantime
+func (n nodeEtcdRepo) Claim(nodeId domain.NodeId, org string) (*domain.Node, error) {
+	return n.move(nodeId, "", org, domain.ErrNodeClaimed)
+}
+
+// Release moves a node from the org back to the pool,
+// failing with domain.ErrNodeNotFound if the org doesn't own it anymore
+func (n nodeEtcdRepo) Release(nodeId domain.NodeId, org string) (*domain.Node, error) {
+	return n.move(nodeId, org, "", domain.ErrNodeNotFound)
+}
+
+// move rewrites the node and its labels under the new org in a single transaction
+// that only succeeds if the node hasn't been modified since it was read
+func (n nodeEtcdRepo) move(nodeId domain.NodeId, fromOrg, toOrg string, errMoved error) (*domain.Node, error) {
+	fromKey := getKey(domain.Node{Id: nodeId, Org: fromOrg})
+	resp, err := n.etcd.Get(context.TODO(), fromKey)
 	if err != nil {
 		return nil, err
 	}
 	if resp.Count == 0 {
-		return nil, errors.New("node not found")
+		return nil, errMoved
 	}
-	return n.nodeMarshaller.Unmarshal(resp.Kvs[0].Value)
+	node, err := n.nodeMarshaller.Unmarshal(resp.Kvs[0].Value)
+	if err != nil {
+		return nil, err
+	}
+	ops, err := n.deleteNodeQueryModel(*node)
+	if err != nil {
+		return nil, err
+	}
+	ops = append(ops, n.deleteNodeGetModel(*node))
+	node.Org = toOrg
+	getModelOp, err := n.putNodeGetModel(*node)
+	if err != nil {
+		return nil, err
+	}
+	queryModelOps, err := n.putNodeQueryModel(*node)
+	if err != nil {
+		return nil, err
+	}
+	ops = append(ops, queryModelOps...)
+	ops = append(ops, getModelOp)
+	if err := checkTxnOps(ops); err != nil {
+		return nil, err
+	}
+	txnResp, err := n.etcd.Txn(context.TODO()).
+		If(etcd.Compare(etcd.ModRevision(fromKey), "=", resp.Kvs[0].ModRevision)).
+		Then(ops...).
+		Commit()
+	if err != nil {
+		return nil, err
+	}
+	if !txnResp.Succeeded {
+		return nil, errMoved
+	}
+	node.ResourceVersion = txnResp.Header.Revision
+	return node, nil
+}
+
+func (n nodeEtcdRepo) Get(nodeId domain.NodeId, org string) (*domain.Node, error) {
+	return n.getAt(nodeId, org, 0)
+}
+
+func (n nodeEtcdRepo) Find(nodeId domain.NodeId) (*domain.Node, error) {
+	node, err := n.Get(nodeId, "")
+	if !errors.Is(err, domain.ErrNodeNotFound) {
+		return node, err
+	}
+	keyPrefix := fmt.Sprintf("%s/orgs/", getKeyPrefix)
+	resp, err := n.etcd.Get(context.TODO(), keyPrefix, etcd.WithPrefix(), etcd.WithKeysOnly())
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range resp.Kvs {
+		if owned := nodeFromGetKey(string(kv.Key)); owned.Id == nodeId {
+			return n.getAt(nodeId, owned.Org, resp.Header.Revision)
+		}
+	}
+	return nil, domain.ErrNodeNotFound
 }
 
-func (n nodeEtcdRepo) ListNodePool() ([]domain.Node, error) {
+func (n nodeEtcdRepo) ListNodePool(page domain.Page) ([]domain.Node, string, error) {
 	keyPrefix := fmt.Sprintf("%s/pool", getKeyPrefix)
-	return n.listNodes(keyPrefix)
+	return n.listNodes(keyPrefix, page)
 }
 
-func (n nodeEtcdRepo) ListOrgOwnedNodes(org string) ([]domain.Node, error) {
+func (n nodeEtcdRepo) ListOrgOwnedNodes(org string, page domain.Page) ([]domain.Node, string, error) {
 	keyPrefix := fmt.Sprintf("%s/orgs/%s", getKeyPrefix, org)
-	return n.listNodes(keyPrefix)
+	return n.listNodes(keyPrefix, page)
 }
 
-func (n nodeEtcdRepo) ListAllNodes() ([]domain.Node, error) {
-	return n.listNodes(getKeyPrefix)
+func (n nodeEtcdRepo) ListAllNodes(page domain.Page) ([]domain.Node, string, error) {
+	return n.listNodes(getKeyPrefix, page)
 }
 
-func (n nodeEtcdRepo) QueryNodePool(query domain.Query) ([]domain.Node, error) {
+func (n nodeEtcdRepo) QueryNodePool(query domain.Query, page domain.Page) ([]domain.Node, string, error) {
+	if len(query) == 0 && !page.Sorted() {
+		return n.ListNodePool(page)
+	}
 	keyPrefix := fmt.Sprintf("%s/pool", queryKeyPrefix)
-	if len(query) == 0 {
-		return n.listNodes(keyPrefix)
+	nodes, nextPageToken, err := n.queryNodePage(query, keyPrefix, "", page)
+	return nodes, nextPageToken, pageReadErr(err)
+}
+
+func (n nodeEtcdRepo) QueryOrgOwnedNodes(query domain.Query, org string, page domain.Page) ([]domain.Node, string, error) {
+	if len(query) == 0 && !page.Sorted() {
+		return n.ListOrgOwnedNodes(org, page)
+	}
+	keyPrefix := fmt.Sprintf("%s/orgs/%s", queryKeyPrefix, org)
+	nodes, nextPageToken, err := n.queryNodePage(query, keyPrefix, org, page)
+	return nodes, nextPageToken, pageReadErr(err)
+}
+
+func (n nodeEtcdRepo) Watch(ctx context.Context, org string, revision int64, handler func(event domain.NodeEvent) error) error {
+	keyPrefix := getKey(domain.Node{Org: org})
+	watchChan := n.etcd.Watch(etcd.WithRequireLeader(ctx), keyPrefix, etcd.WithPrefix(), etcd.WithPrevKV(), etcd.WithRev(revision))
+	for resp := range watchChan {
+		if resp.CompactRevision != 0 {
+			return domain.ErrRevisionCompacted
+		}
+		if err := resp.Err(); err != nil {
+			return err
+		}
+		for _, ev := range resp.Events {
+			event, err := n.nodeEvent(ev)
+			if err != nil {
+				return err
+			}
+			err = handler(*event)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+func (n nodeEtcdRepo) nodeEvent(ev *etcd.Event) (*domain.NodeEvent, error) {
+	event := &domain.NodeEvent{
+		Revision: ev.Kv.ModRevision,
 	}
-	nodeIds, err := n.queryNodes(query, keyPrefix)
+	var prevNode *domain.Node
+	if ev.PrevKv != nil {
+		node, err := n.unmarshalNode(ev.PrevKv.Value, ev.PrevKv.ModRevision)
+		if err != nil {
+			return nil, err
+		}
+		prevNode = node
+	}
+	if ev.Type == etcd.EventTypeDelete {
+		event.Type = domain.NodeDeleted
+		if prevNode != nil {
+			event.Node = *prevNode
+		} else {
+			event.Node = nodeFromGetKey(string(ev.Kv.Key))
+		}
+		event.Node.ResourceVersion = ev.Kv.ModRevision
+		return event, nil
+	}
+	node, err := n.unmarshalNode(ev.Kv.Value, ev.Kv.ModRevision)
 	if err != nil {
 		return nil, err
 	}
-	nodes := make([]domain.Node, 0)
-	for _, nodeId := range nodeIds {
-		node, err := n.Get(nodeId, "")
+	event.Node = *node
+	if ev.IsCreate() {
+		event.Type = domain.NodeAdded
+	} else {
+		event.Type = domain.NodeModified
+		event.PrevNode = prevNode
+	}
+	return event, nil
+}
+
+// getAt reads the node at the given revision, zero revision means the latest one
+func (n nodeEtcdRepo) getAt(nodeId domain.NodeId, org string, revision int64) (*domain.Node, error) {
+	key := getKey(domain.Node{Id: nodeId, Org: org})
+	resp, err := n.etcd.Get(context.TODO(), key, etcd.WithRev(revision))
+	if err != nil {
+		return nil, err
+	}
+	if resp.Count == 0 {
+		return nil, domain.ErrNodeNotFound
+	}
+	return n.unmarshalNode(resp.Kvs[0].Value, resp.Kvs[0].ModRevision)
+}
+
+// getManyAt reads the nodes in batched txns at the given revision, which must not be zero,
+// the query model is evaluated at the same revision, so every node found in it is still there,
+// the ones that are not are left out instead of failing the whole read
+func (n nodeEtcdRepo) getManyAt(nodeIds []domain.NodeId, org string, revision int64) ([]domain.Node, error) {
+	nodes := make([]domain.Node, 0, len(nodeIds))
+	for start := 0; start < len(nodeIds); start += txnOpsLimit {
+		end := min(start+txnOpsLimit, len(nodeIds))
+		ops := make([]etcd.Op, 0, end-start)
+		for _, nodeId := range nodeIds[start:end] {
+			ops = append(ops, etcd.OpGet(getKey(domain.Node{Id: nodeId, Org: org}), etcd.WithRev(revision)))
+		}
+		resp, err := n.etcd.Txn(context.TODO()).Then(ops...).Commit()
 		if err != nil {
-			log.Println(err)
-			continue
+			return nil, err
+		}
+		for _, opResp := range resp.Responses {
+			kvs := opResp.GetResponseRange().Kvs
+			if len(kvs) == 0 {
+				continue
+			}
+			node, err := n.unmarshalNode(kvs[0].Value, kvs[0].ModRevision)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, *node)
 		}
-		nodes = append(nodes, *node)
 	}
 	return nodes, nil
 }
 
-func (n nodeEtcdRepo) QueryOrgOwnedNodes(query domain.Query, org string) ([]domain.Node, error) {
-	keyPrefix := fmt.Sprintf("%s/orgs/%s", queryKeyPrefix, org)
-	if len(query) == 0 {
-		return n.listNodes(keyPrefix)
+// listNodes reads a page of the key range, all pages are read at the revision of the first one
+func (n nodeEtcdRepo) listNodes(keyPrefix string, page domain.Page) ([]domain.Node, string, error) {
+	if err := page.Validate(); err != nil {
+		return nil, "", err
 	}
-	nodeIds, err := n.queryNodes(query, keyPrefix)
+	token, err := decodePageToken(page.Token, keyPrefix)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	nodes := make([]domain.Node, 0)
-	for _, nodeId := range nodeIds {
-		node, err := n.Get(nodeId, org)
+	startKey := keyPrefix
+	revision := int64(0)
+	if token != nil {
+		startKey = token.Key + "\x00"
+		revision = token.Revision
+	}
+	nodes, more, err := fillPage(page, func(limit int64) ([]domain.Node, bool, error) {
+		resp, err := n.etcd.Get(context.TODO(), startKey, etcd.WithRange(etcd.GetPrefixRangeEnd(keyPrefix)), etcd.WithLimit(limit), etcd.WithRev(revision))
 		if err != nil {
-			log.Println(err)
-			continue
+			return nil, false, pageReadErr(err)
 		}
-		nodes = append(nodes, *node)
+		if revision == 0 {
+			revision = resp.Header.Revision
+		}
+		batch := make([]domain.Node, 0, len(resp.Kvs))
+		for _, kv := range resp.Kvs {
+			node, err := n.unmarshalNode(kv.Value, kv.ModRevision)
+			if err != nil {
+				return nil, false, err
+			}
+			batch = append(batch, *node)
+			startKey = string(kv.Key) + "\x00"
+		}
+		return batch, resp.More, nil
+	})
+	if err != nil {
+		return nil, "", err
 	}
-	return nodes, nil
+	if !more {
+		return nodes, "", nil
+	}
+	return nodes, encodePageToken(getKey(nodes[len(nodes)-1]), revision), nil
+}
+
+// pageReadErr reports a page read at a compacted revision as an expired token
+func pageReadErr(err error) error {
+	if errors.Is(err, rpctypes.ErrCompacted) {
+		return domain.ErrInvalidPageToken
+	}
+	return err
 }
 
-func (n nodeEtcdRepo) listNodes(keyPrefix string) ([]domain.Node, error) {
-	nodes := make([]domain.Node, 0)
-	resp, err := n.etcd.Get(context.TODO(), keyPrefix, etcd.WithPrefix())
+// queryNodePage evaluates the query and reads a page of matching nodes,
+// all pages are evaluated and read at the revision of the first one
+func (n nodeEtcdRepo) queryNodePage(query domain.Query, keyPrefix, org string, page domain.Page) ([]domain.Node, string, error) {
+	// selectors left to the planner's candidate checks are never compiled by the index reads
+	if err := query.Validate(); err != nil {
+		return nil, "", err
+	}
+	if err := page.Validate(); err != nil {
+		return nil, "", err
+	}
+	token, err := decodePageToken(page.Token, getKey(domain.Node{Org: org}))
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	revision := int64(0)
+	if token != nil {
+		revision = token.Revision
+	}
+	if page.Sorted() {
+		return n.querySortedNodePage(query, keyPrefix, org, page, token, revision)
+	}
+	nodeIds, revision, err := n.queryNodes(query, keyPrefix, revision)
+	if err != nil {
+		return nil, "", err
+	}
+	nodeIds = nodeIdsAfterToken(nodeIds, org, token)
+	nodes, more, err := fillPage(page, func(limit int64) ([]domain.Node, bool, error) {
+		batch := nodeIds
+		if limit > 0 && int64(len(batch)) > limit {
+			batch = batch[:limit]
+		}
+		nodeIds = nodeIds[len(batch):]
+		nodes, err := n.getManyAt(batch, org, revision)
+		return nodes, len(nodeIds) > 0, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if !more {
+		return nodes, "", nil
+	}
+	return nodes, encodePageToken(getKey(nodes[len(nodes)-1]), revision), nil
+}
+
+// querySortedNodePage reads all matching nodes, since the order depends on their labels and resources,
+// the result is sorted, filtered and capped the same way at the revision of the first page, so pages continue after the token node
+func (n nodeEtcdRepo) querySortedNodePage(query domain.Query, keyPrefix, org string, page domain.Page, token *pageToken, revision int64) ([]domain.Node, string, error) {
+	var nodes []domain.Node
+	if len(query) == 0 {
+		var err error
+		nodes, revision, err = n.nodesInScopeAt(keyPrefix, revision)
+		if err != nil {
+			return nil, "", err
+		}
+	} else {
+		nodeIds, queryRevision, err := n.queryNodes(query, keyPrefix, revision)
+		if err != nil {
+			return nil, "", err
+		}
+		revision = queryRevision
+		nodes, err = n.getManyAt(nodeIds, org, revision)
+		if err != nil {
+			return nil, "", err
+		}
 	}
+	domain.SortNodes(nodes, page.OrderBy)
+	nodes, more, err := pageSortedNodes(nodes, token, page)
+	if err != nil {
+		return nil, "", err
+	}
+	if !more {
+		return nodes, "", nil
+	}
+	return nodes, encodePageToken(getKey(nodes[len(nodes)-1]), revision), nil
+}
+
+// nodesInScopeAt reads all nodes of the pool or the org the query model key prefix belongs to
+func (n nodeEtcdRepo) nodesInScopeAt(keyPrefix string, revision int64) ([]domain.Node, int64, error) {
+	resp, err := n.etcd.Get(context.TODO(), getKeyPrefixOf(keyPrefix)+"/", etcd.WithPrefix(), etcd.WithRev(revision))
+	if err != nil {
+		return nil, 0, err
+	}
+	nodes := make([]domain.Node, 0, len(resp.Kvs))
 	for _, kv := range resp.Kvs {
-		node, err := n.nodeMarshaller.Unmarshal(kv.Value)
+		node, err := n.unmarshalNode(kv.Value, kv.ModRevision)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		nodes = append(nodes, *node)
 	}
-	return nodes, nil
+	if revision == 0 {
+		revision = resp.Header.Revision
+	}
+	return nodes, revision, nil
 }
 
 func (n nodeEtcdRepo) PutLabel(node domain.Node, label domain.Label) (*domain.Node, error) {
-	err := n.putLabelGetModel(node, label)
+	// the query model ops are built first, the get model ops modify the labels in place
+	ops := make([]etcd.Op, 0)
+	for _, prevLabel := range node.Labels {
+		if prevLabel.Key() != label.Key() {
+			continue
+		}
+		prevKeys, err := queryKeys(node, prevLabel)
+		if err != nil {
+			return nil, err
+		}
+		keys, err := queryKeys(node, label)
+		if err != nil {
+			return nil, err
+		}
+		// a txn can't touch a key twice, the put alone covers an unchanged value
+		for _, prevKey := range prevKeys {
+			if !slices.Contains(keys, prevKey) {
+				ops = append(ops, etcd.OpDelete(prevKey))
+			}
+		}
+	}
+	queryModelOps, err := n.putLabelQueryModel(node, label)
 	if err != nil {
 		return nil, err
 	}
-	err = n.putLabelQueryModel(node, label)
+	getModelOp, err := n.putLabelGetModel(node, label)
+	if err != nil {
+		return nil, err
+	}
+	err = n.commitIfUnchanged(node, append(append(ops, queryModelOps...), getModelOp)...)
 	if err != nil {
 		return nil, err
 	}
@@ -148,43 +511,127 @@ func (n nodeEtcdRepo) PutLabel(node domain.Node, label domain.Label) (*domain.No
 }
 
 func (n nodeEtcdRepo) DeleteLabel(node domain.Node, labelKey string) (*domain.Node, error) {
-	err := n.deleteLabelGetModel(node, labelKey)
+	// the query model ops are built first, the get model ops modify the labels in place
+	ops := make([]etcd.Op, 0)
+	for _, label := range node.Labels {
+		if label.Key() != labelKey {
+			continue
+		}
+		labelOps, err := n.deleteLabelQueryModel(node, label)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, labelOps...)
+	}
+	getModelOps, err := n.deleteLabelGetModel(node, labelKey)
 	if err != nil {
 		return nil, err
 	}
-	err = n.deleteLabelQueryModel(node, labelKey)
+	err = n.commitIfUnchanged(node, append(ops, getModelOps...)...)
 	if err != nil {
 		return nil, err
 	}
 	return n.Get(node.Id, node.Org)
 }
 
-func (n nodeEtcdRepo) deleteNodeGetModel(node domain.Node) error {
-	key := getKey(node)
-	_, err := n.etcd.Delete(context.TODO(), key)
+// commit applies all ops of a single logical mutation atomically,
+// so the get and the query model can never diverge
+func (n nodeEtcdRepo) commit(ops ...etcd.Op) error {
+	if err := checkTxnOps(ops); err != nil {
+		return err
+	}
+	_, err := n.etcd.Txn(context.TODO()).Then(ops...).Commit()
 	return err
 }
 
-func (n nodeEtcdRepo) deleteNodeQueryModel(node domain.Node) (err error) {
+// commitIfUnchanged commits ops only if the node still has the resource version
+// the caller has read, a zero resource version skips the check
+func (n nodeEtcdRepo) commitIfUnchanged(node domain.Node, ops ...etcd.Op) error {
+	if node.ResourceVersion == 0 {
+		return n.commit(ops...)
+	}
+	if err := checkTxnOps(ops); err != nil {
+		return err
+	}
+	resp, err := n.etcd.Txn(context.TODO()).
+		If(etcd.Compare(etcd.ModRevision(getKey(node)), "=", node.ResourceVersion)).
+		Then(ops...).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return domain.ErrResourceVersionMismatch
+	}
+	return nil
+}
+
+// checkTxnOps rejects mutations etcd would reject for exceeding its limit of ops per txn,
+// services validate nodes against domain.MaxNodeIndexKeys, so this only catches nodes stored before the limit
+func checkTxnOps(ops []etcd.Op) error {
+	if len(ops) > maxTxnOps {
+		return fmt.Errorf("%w: %d ops exceed the limit of %d per txn", domain.ErrNodeTooLarge, len(ops), maxTxnOps)
+	}
+	return nil
+}
+
+func (n nodeEtcdRepo) unmarshalNode(nodeMarshalled []byte, modRevision int64) (*domain.Node, error) {
+	node, err := n.nodeMarshaller.Unmarshal(nodeMarshalled)
+	if err != nil {
+		return nil, err
+	}
+	node.ResourceVersion = modRevision
+	return node, nil
+}
+
+func (n nodeEtcdRepo) deleteNodeGetModel(node domain.Node) etcd.Op {
+	return etcd.OpDelete(getKey(node))
+}
+
+func (n nodeEtcdRepo) deleteNodeQueryModel(node domain.Node) ([]etcd.Op, error) {
+	ops := make([]etcd.Op, 0, len(node.Labels))
 	for _, label := range node.Labels {
-		key := queryKey(node, label.Key())
-		_, delErr := n.etcd.Delete(context.TODO(), key)
-		err = errors.Join(err, delErr)
+		labelOps, err := n.deleteLabelQueryModel(node, label)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, labelOps...)
 	}
-	return err
+	for resource, value := range node.Resources {
+		ops = append(ops, etcd.OpDelete(resourceKey(node, resource, value)))
+	}
+	return ops, nil
+}
+
+// deleteStaleQueryModel deletes the index keys of prev labels and resources that the node no longer has with the same value,
+// keys that stay the same are left to the puts, since a txn can't touch a key twice
+func (n nodeEtcdRepo) deleteStaleQueryModel(prev, node domain.Node) ([]etcd.Op, error) {
+	keys, err := queryModelKeys(node)
+	if err != nil {
+		return nil, err
+	}
+	prevKeys, err := queryModelKeys(prev)
+	if err != nil {
+		return nil, err
+	}
+	ops := make([]etcd.Op, 0)
+	for _, key := range prevKeys {
+		if !slices.Contains(keys, key) {
+			ops = append(ops, etcd.OpDelete(key))
+		}
+	}
+	return ops, nil
 }
 
-func (n nodeEtcdRepo) putNodeGetModel(node domain.Node) error {
+func (n nodeEtcdRepo) putNodeGetModel(node domain.Node) (etcd.Op, error) {
 	nodeMarshalled, err := n.nodeMarshaller.Marshal(node)
 	if err != nil {
-		return err
+		return etcd.Op{}, err
 	}
-	key := getKey(node)
-	_, err = n.etcd.Put(context.TODO(), key, string(nodeMarshalled))
-	return err
+	return etcd.OpPut(getKey(node), string(nodeMarshalled)), nil
 }
 
-func (n nodeEtcdRepo) putLabelGetModel(node domain.Node, label domain.Label) error {
+func (n nodeEtcdRepo) putLabelGetModel(node domain.Node, label domain.Label) (etcd.Op, error) {
 	labelIndex := -1
 	for i, nodeLabel := range node.Labels {
 		if nodeLabel.Key() == label.Key() {
@@ -199,7 +646,7 @@ func (n nodeEtcdRepo) putLabelGetModel(node domain.Node, label domain.Label) err
 	return n.putNodeGetModel(node)
 }
 
-func (n nodeEtcdRepo) deleteLabelGetModel(node domain.Node, labelKey string) error {
+func (n nodeEtcdRepo) deleteLabelGetModel(node domain.Node, labelKey string) ([]etcd.Op, error) {
 	labelIndex := -1
 	for i, nodeLabel := range node.Labels {
 		if nodeLabel.Key() == labelKey {
@@ -208,87 +655,222 @@ func (n nodeEtcdRepo) deleteLabelGetModel(node domain.Node, labelKey string) err
 	}
 	if labelIndex >= 0 {
 		node.Labels = slices.Delete(node.Labels, labelIndex, labelIndex+1)
-		return n.putNodeGetModel(node)
+		op, err := n.putNodeGetModel(node)
+		if err != nil {
+			return nil, err
+		}
+		return []etcd.Op{op}, nil
 	}
-	return nil
+	return []etcd.Op{}, nil
 }
 
-func (n nodeEtcdRepo) putNodeQueryModel(node domain.Node) error {
+func (n nodeEtcdRepo) putNodeQueryModel(node domain.Node) ([]etcd.Op, error) {
+	ops := make([]etcd.Op, 0, len(node.Labels)+len(node.Resources))
 	for _, label := range node.Labels {
-		err := n.putLabelQueryModel(node, label)
+		labelOps, err := n.putLabelQueryModel(node, label)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		ops = append(ops, labelOps...)
 	}
-	return nil
+	for resource, value := range node.Resources {
+		ops = append(ops, etcd.OpPut(resourceKey(node, resource, value), strconv.FormatFloat(value, 'f', -1, 64)))
+	}
+	return ops, nil
 }
 
-func (n nodeEtcdRepo) putLabelQueryModel(node domain.Node, label domain.Label) error {
+func (n nodeEtcdRepo) putLabelQueryModel(node domain.Node, label domain.Label) ([]etcd.Op, error) {
 	labelMarshalled, err := n.labelMarshaller.Marshal(label)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	key := queryKey(node, label.Key())
-	_, err = n.etcd.Put(context.TODO(), key, string(labelMarshalled))
-	return err
+	keys, err := queryKeys(node, label)
+	if err != nil {
+		return nil, err
+	}
+	ops := make([]etcd.Op, 0, len(keys))
+	for _, key := range keys {
+		ops = append(ops, etcd.OpPut(key, string(labelMarshalled)))
+	}
+	return ops, nil
 }
 
-func (n nodeEtcdRepo) deleteLabelQueryModel(node domain.Node, labelKey string) error {
-	key := queryKey(node, labelKey)
-	_, err := n.etcd.Delete(context.TODO(), key)
-	return err
+func (n nodeEtcdRepo) deleteLabelQueryModel(node domain.Node, label domain.Label) ([]etcd.Op, error) {
+	keys, err := queryKeys(node, label)
+	if err != nil {
+		return nil, err
+	}
+	ops := make([]etcd.Op, 0, len(keys))
+	for _, key := range keys {
+		ops = append(ops, etcd.OpDelete(key))
+	}
+	return ops, nil
 }
 
-func (n nodeEtcdRepo) queryNodes(query domain.Query, keyPrefix string) ([]domain.NodeId, error) {
-	nodeIds := make([]domain.NodeId, 0)
-	for i, selector := range query {
-		currNodes, err := n.selectNodes(selector, keyPrefix)
-		if err != nil {
-			return nil, err
+// queryNodes evaluates all selectors at the same revision, zero revision means the latest one,
+// the selectors are evaluated in the order of the query plan (see query_planner.go)
+func (n nodeEtcdRepo) queryNodes(query domain.Query, keyPrefix string, revision int64) ([]domain.NodeId, int64, error) {
+	if len(query) == 1 {
+		return n.selectNodes(query[0], keyPrefix, revision)
+	}
+	counts, revision, err := n.countRanges(estimateRanges(query, keyPrefix), revision)
+	if err != nil {
+		return nil, 0, err
+	}
+	plan := planQuery(query, keyPrefix, counts)
+	nodeIds, revision, err := n.selectNodes(plan[0].selector, keyPrefix, revision)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i, step := range plan[1:] {
+		if len(nodeIds) == 0 {
+			break
 		}
-		if i == 0 {
-			nodeIds = currNodes
-		} else {
-			intersection := intersect.Simple(nodeIds, currNodes)
-			nodeIds = make([]domain.NodeId, len(intersection))
-			for i, node := range intersection {
-				nodeIds[i] = node.(domain.NodeId)
+		if int64(len(nodeIds)) <= step.estimate {
+			nodes, err := n.getManyAt(nodeIds, orgOf(keyPrefix), revision)
+			if err != nil {
+				return nil, 0, err
 			}
+			remaining := make(domain.Query, 0, len(plan)-i-1)
+			for _, rest := range plan[i+1:] {
+				remaining = append(remaining, rest.selector)
+			}
+			return matchingNodeIds(nodes, remaining), revision, nil
+		}
+		currNodes, _, err := n.selectNodes(step.selector, keyPrefix, revision)
+		if err != nil {
+			return nil, 0, err
+		}
+		intersection := intersect.Simple(nodeIds, currNodes)
+		nodeIds = make([]domain.NodeId, len(intersection))
+		for i, node := range intersection {
+			nodeIds[i] = node.(domain.NodeId)
 		}
 	}
-	return nodeIds, nil
+	return nodeIds, revision, nil
 }
 
-func (n nodeEtcdRepo) selectNodes(selector domain.Selector, keyPrefix string) ([]domain.NodeId, error) {
-	prefix := fmt.Sprintf("%s/%s/", keyPrefix, selector.LabelKey)
-	resp, err := n.etcd.Get(context.TODO(), prefix, etcd.WithPrefix())
-	if err != nil {
-		return nil, err
+// countRanges counts the keys in every range in batched txns at the same revision
+func (n nodeEtcdRepo) countRanges(ranges []keyRange, revision int64) (map[keyRange]int64, int64, error) {
+	counts := make(map[keyRange]int64, len(ranges))
+	unique := make([]keyRange, 0, len(ranges))
+	for _, r := range ranges {
+		if _, ok := counts[r]; !ok {
+			counts[r] = 0
+			unique = append(unique, r)
+		}
 	}
-	nodeIds := make([]domain.NodeId, 0)
-	for _, kv := range resp.Kvs {
-		nodeLabel, err := n.labelMarshaller.Unmarshal(kv.Value)
+	// the first txn pins the revision, even when there is nothing to count
+	for start := 0; start == 0 || start < len(unique); start += txnOpsLimit {
+		end := min(start+txnOpsLimit, len(unique))
+		ops := make([]etcd.Op, 0, end-start)
+		for _, r := range unique[start:end] {
+			ops = append(ops, etcd.OpGet(r.start, etcd.WithRange(r.end), etcd.WithCountOnly(), etcd.WithRev(revision)))
+		}
+		resp, err := n.etcd.Txn(context.TODO()).Then(ops...).Commit()
 		if err != nil {
-			return nil, err
+			return nil, 0, err
+		}
+		if revision == 0 {
+			revision = resp.Header.Revision
 		}
-		cmpResult, err := nodeLabel.Compare(selector.Value)
+		for i, opResp := range resp.Responses {
+			counts[unique[start+i]] = opResp.GetResponseRange().Count
+		}
+	}
+	return counts, revision, nil
+}
+
+func (n nodeEtcdRepo) selectNodes(selector domain.Selector, keyPrefix string, revision int64) ([]domain.NodeId, int64, error) {
+	if selector.Group != nil {
+		return n.selectGroup(*selector.Group, keyPrefix, revision)
+	}
+	matches, err := selector.Matcher()
+	if err != nil {
+		return nil, 0, err
+	}
+	labelPrefix := selectorIndexPrefix(keyPrefix, selector)
+	ranges, exact := labelIndexRanges(selector, labelPrefix)
+	ops := make([]etcd.Op, 0, len(ranges))
+	for _, r := range ranges {
+		ops = append(ops, etcd.OpGet(r.start, etcd.WithRange(r.end), etcd.WithKeysOnly(), etcd.WithRev(revision)))
+	}
+	// all ranges are read in a single txn, so they are consistent even when no revision is given yet
+	resp, err := n.etcd.Txn(context.TODO()).Then(ops...).Commit()
+	if err != nil {
+		return nil, 0, err
+	}
+	if revision == 0 {
+		revision = resp.Header.Revision
+	}
+	keys := make([]string, 0)
+	for _, opResp := range resp.Responses {
+		for _, kv := range opResp.GetResponseRange().Kvs {
+			keys = append(keys, string(kv.Key))
+		}
+	}
+	nodeIds, labeled, err := matchIndexKeys(keys, labelPrefix, selector.LabelKey, exact, matches)
+	if err != nil {
+		return nil, 0, err
+	}
+	if selector.ShouldBe == domain.CompResDoesNotExist {
+		// nodes without the label have no index key, so they are found through the get model
+		all, err := n.nodeIdsInScope(keyPrefix, revision)
 		if err != nil {
-			log.Println(err)
-			continue
+			return nil, 0, err
 		}
-		if slices.Contains(cmpResult, selector.ShouldBe) {
-			nodeId := extractNodeIdFromQueryKey(string(kv.Key))
-			nodeIds = append(nodeIds, domain.NodeId{
-				Value: nodeId,
-			})
+		nodeIds = subtractNodeIds(all, labeled)
+	}
+	return nodeIds, revision, nil
+}
+
+// selectGroup evaluates the group over node id sets: intersection for and, union for or,
+// and the complement of the intersection within the pool or the org for not
+func (n nodeEtcdRepo) selectGroup(group domain.SelectorGroup, keyPrefix string, revision int64) ([]domain.NodeId, int64, error) {
+	if group.Op == domain.GroupOr {
+		nodeIds := make([]domain.NodeId, 0)
+		for _, selector := range group.Selectors {
+			currNodes, currRevision, err := n.selectNodes(selector, keyPrefix, revision)
+			if err != nil {
+				return nil, 0, err
+			}
+			revision = currRevision
+			nodeIds = unionNodeIds(nodeIds, currNodes)
 		}
+		return nodeIds, revision, nil
+	}
+	nodeIds, revision, err := n.queryNodes(group.Selectors, keyPrefix, revision)
+	if err != nil || group.Op != domain.GroupNot {
+		return nodeIds, revision, err
+	}
+	all, err := n.nodeIdsInScope(keyPrefix, revision)
+	if err != nil {
+		return nil, 0, err
+	}
+	return subtractNodeIds(all, nodeIds), revision, nil
+}
+
+// nodeIdsInScope lists all nodes of the pool or the org the query model key prefix belongs to
+func (n nodeEtcdRepo) nodeIdsInScope(keyPrefix string, revision int64) ([]domain.NodeId, error) {
+	resp, err := n.etcd.Get(context.TODO(), getKeyPrefixOf(keyPrefix)+"/", etcd.WithPrefix(), etcd.WithKeysOnly(), etcd.WithRev(revision))
+	if err != nil {
+		return nil, err
+	}
+	nodeIds := make([]domain.NodeId, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		nodeIds = append(nodeIds, nodeFromGetKey(string(kv.Key)).Id)
 	}
 	return nodeIds, nil
 }
 
 const (
-	getKeyPrefix   = "nodes"
-	queryKeyPrefix = "labels"
+	getKeyPrefix      = "nodes"
+	queryKeyPrefix    = "index"
+	resourceKeyPrefix = "resources"
+	// stay well within the default limit of 128 ops per txn
+	txnOpsLimit = 64
+	// the default limit of etcd, --max-txn-ops
+	maxTxnOps = 128
 )
 
 func getKey(node domain.Node) string {
@@ -298,16 +880,85 @@ func getKey(node domain.Node) string {
 	return fmt.Sprintf("%s/pool/%s", getKeyPrefix, node.Id.Value)
 }
 
-func queryKey(node domain.Node, labelKey string) string {
+// queryKeys returns the index keys of the label, string set labels have a key per member
+func queryKeys(node domain.Node, label domain.Label) ([]string, error) {
+	encodings, err := encodeLabelValue(label)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(encodings))
+	for _, encoded := range encodings {
+		if node.Claimed() {
+			keys = append(keys, fmt.Sprintf("%s/orgs/%s/%s/%s/%s", queryKeyPrefix, node.Org, label.Key(), encoded, node.Id.Value))
+		} else {
+			keys = append(keys, fmt.Sprintf("%s/pool/%s/%s/%s", queryKeyPrefix, label.Key(), encoded, node.Id.Value))
+		}
+	}
+	return keys, nil
+}
+
+// queryModelKeys returns the index keys of all labels and resources of the node
+func queryModelKeys(node domain.Node) ([]string, error) {
+	keys := make([]string, 0, len(node.Labels)+len(node.Resources))
+	for _, label := range node.Labels {
+		labelKeys, err := queryKeys(node, label)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, labelKeys...)
+	}
+	for resource, value := range node.Resources {
+		keys = append(keys, resourceKey(node, resource, value))
+	}
+	return keys, nil
+}
+
+func resourceKey(node domain.Node, resource string, value float64) string {
 	if node.Claimed() {
-		return fmt.Sprintf("%s/orgs/%s/%s/%s", queryKeyPrefix, node.Org, labelKey, node.Id.Value)
+		return fmt.Sprintf("%s/orgs/%s/%s/%s/%s", resourceKeyPrefix, node.Org, resource, encodeFloat64(value), node.Id.Value)
+	}
+	return fmt.Sprintf("%s/pool/%s/%s/%s", resourceKeyPrefix, resource, encodeFloat64(value), node.Id.Value)
+}
+
+// nodeFromGetKey recovers the node identity when only its key is known
+func nodeFromGetKey(key string) domain.Node {
+	parts := strings.Split(key, "/")
+	node := domain.Node{
+		Id: domain.NodeId{
+			Value: parts[len(parts)-1],
+		},
+	}
+	if len(parts) == 4 {
+		node.Org = parts[2]
 	}
-	return fmt.Sprintf("%s/pool/%s/%s", queryKeyPrefix, labelKey, node.Id.Value)
+	return node
 }
 
-func extractNodeIdFromQueryKey(key string) string {
-	if strings.HasPrefix(key, fmt.Sprintf("%s/pool", queryKeyPrefix)) {
-		return strings.Split(key, "/")[3]
+func unionNodeIds(nodeIds, other []domain.NodeId) []domain.NodeId {
+	for _, nodeId := range other {
+		if !slices.Contains(nodeIds, nodeId) {
+			nodeIds = append(nodeIds, nodeId)
+		}
+	}
+	return nodeIds
+}
+
+func subtractNodeIds(nodeIds, other []domain.NodeId) []domain.NodeId {
+	difference := make([]domain.NodeId, 0, len(nodeIds))
+	for _, nodeId := range nodeIds {
+		if !slices.Contains(other, nodeId) {
+			difference = append(difference, nodeId)
+		}
 	}
-	return strings.Split(key, "/")[4]
+	return difference
+}
+
+// getKeyPrefixOf maps a query model key prefix to the get model key prefix of the same nodes
+func getKeyPrefixOf(queryModelKeyPrefix string) string {
+	return getKeyPrefix + strings.TrimPrefix(queryModelKeyPrefix, queryKeyPrefix)
+}
+
+// orgOf returns the org a query model key prefix belongs to, empty for the pool
+func orgOf(queryModelKeyPrefix string) string {
+	return nodeFromGetKey(getKeyPrefixOf(queryModelKeyPrefix) + "/").Org
 }
@@ -0,0 +1,678 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/c12s/magnetar/internal/domain"
+	"github.com/juliangruber/go-intersect"
+	"golang.org/x/exp/slices"
+)
+
+// in-memory counterpart of nodeEtcdRepo
+// it keeps the same key-value data model (see node_etcd.go),
+// so both implementations share the pool/org split and query semantics
+// revisions are emulated with a counter bumped once per mutation
+
+type nodeInMemRepo struct {
+	kvs      map[string]inMemKv
+	revision int64
+	// all get model changes, there is no compaction
+	events          []inMemEvent
+	eventsNotify    chan struct{}
+	mu              sync.RWMutex
+	nodeMarshaller  domain.NodeMarshaller
+	labelMarshaller domain.LabelMarshaller
+}
+
+func NewNodeInMemRepo(nodeMarshaller domain.NodeMarshaller, labelMarshaller domain.LabelMarshaller) (domain.NodeRepo, error) {
+	return &nodeInMemRepo{
+		kvs:             make(map[string]inMemKv),
+		events:          make([]inMemEvent, 0),
+		eventsNotify:    make(chan struct{}),
+		nodeMarshaller:  nodeMarshaller,
+		labelMarshaller: labelMarshaller,
+	}, nil
+}
+
+func (n *nodeInMemRepo) Put(node domain.Node) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.revision++
+	err := n.putNodeGetModel(node)
+	if err != nil {
+		return err
+	}
+	return n.putNodeQueryModel(node)
+}
+
+func (n *nodeInMemRepo) Create(node domain.Node) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.kvs[getKey(node)]; ok {
+		return domain.ErrNodeExists
+	}
+	n.revision++
+	err := n.putNodeGetModel(node)
+	if err != nil {
+		return err
+	}
+	return n.putNodeQueryModel(node)
+}
+
+func (n *nodeInMemRepo) Update(prev domain.Node, node domain.Node) (*domain.Node, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.unchanged(prev) {
+		return nil, domain.ErrResourceVersionMismatch
+	}
+	n.revision++
+	node.Id = prev.Id
+	node.Org = prev.Org
+	err := n.deleteNodeQueryModel(prev)
+	if err != nil {
+		return nil, err
+	}
+	err = n.putNodeGetModel(node)
+	if err != nil {
+		return nil, err
+	}
+	err = n.putNodeQueryModel(node)
+	if err != nil {
+		return nil, err
+	}
+	return n.get(node.Id, node.Org)
+}
+
+func (n *nodeInMemRepo) Delete(node domain.Node) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.unchanged(node) {
+		return domain.ErrResourceVersionMismatch
+	}
+	n.revision++
+	n.deleteNodeGetModel(node)
+	return n.deleteNodeQueryModel(node)
+}
+
+func (n *nodeInMemRepo) Claim(nodeId domain.NodeId, org string) (*domain.Node, error) {
+	return n.move(nodeId, "", org, domain.ErrNodeClaimed)
+}
+
+func (n *nodeInMemRepo) Release(nodeId domain.NodeId, org string) (*domain.Node, error) {
+	return n.move(nodeId, org, "", domain.ErrNodeNotFound)
+}
+
+func (n *nodeInMemRepo) move(nodeId domain.NodeId, fromOrg, toOrg string, errMoved error) (*domain.Node, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	node, err := n.get(nodeId, fromOrg)
+	if err != nil {
+		return nil, errMoved
+	}
+	n.revision++
+	moved := *node
+	moved.Org = toOrg
+	moved.ResourceVersion = n.revision
+	err = n.putNodeGetModel(moved)
+	if err != nil {
+		return nil, err
+	}
+	err = n.putNodeQueryModel(moved)
+	if err != nil {
+		return nil, err
+	}
+	n.deleteNodeGetModel(*node)
+	err = n.deleteNodeQueryModel(*node)
+	if err != nil {
+		return nil, err
+	}
+	return &moved, nil
+}
+
+func (n *nodeInMemRepo) Get(nodeId domain.NodeId, org string) (*domain.Node, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.get(nodeId, org)
+}
+
+func (n *nodeInMemRepo) Find(nodeId domain.NodeId) (*domain.Node, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	node, err := n.get(nodeId, "")
+	if err == nil {
+		return node, nil
+	}
+	for _, key := range n.keysWithPrefix(fmt.Sprintf("%s/orgs/", getKeyPrefix)) {
+		if owned := nodeFromGetKey(key); owned.Id == nodeId {
+			return n.get(nodeId, owned.Org)
+		}
+	}
+	return nil, domain.ErrNodeNotFound
+}
+
+func (n *nodeInMemRepo) ListNodePool(page domain.Page) ([]domain.Node, string, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	keyPrefix := fmt.Sprintf("%s/pool", getKeyPrefix)
+	return n.listNodes(keyPrefix, page)
+}
+
+func (n *nodeInMemRepo) ListOrgOwnedNodes(org string, page domain.Page) ([]domain.Node, string, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	keyPrefix := fmt.Sprintf("%s/orgs/%s", getKeyPrefix, org)
+	return n.listNodes(keyPrefix, page)
+}
+
+func (n *nodeInMemRepo) ListAllNodes(page domain.Page) ([]domain.Node, string, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.listNodes(getKeyPrefix, page)
+}
+
+func (n *nodeInMemRepo) QueryNodePool(query domain.Query, page domain.Page) ([]domain.Node, string, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if len(query) == 0 && !page.Sorted() {
+		return n.listNodes(fmt.Sprintf("%s/pool", getKeyPrefix), page)
+	}
+	keyPrefix := fmt.Sprintf("%s/pool", queryKeyPrefix)
+	return n.queryNodes(query, keyPrefix, "", page)
+}
+
+func (n *nodeInMemRepo) QueryOrgOwnedNodes(query domain.Query, org string, page domain.Page) ([]domain.Node, string, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if len(query) == 0 && !page.Sorted() {
+		return n.listNodes(fmt.Sprintf("%s/orgs/%s", getKeyPrefix, org), page)
+	}
+	keyPrefix := fmt.Sprintf("%s/orgs/%s", queryKeyPrefix, org)
+	return n.queryNodes(query, keyPrefix, org, page)
+}
+
+func (n *nodeInMemRepo) Watch(ctx context.Context, org string, revision int64, handler func(event domain.NodeEvent) error) error {
+	keyPrefix := getKey(domain.Node{Org: org})
+	n.mu.RLock()
+	next := len(n.events)
+	if revision > 0 {
+		next = sort.Search(len(n.events), func(i int) bool {
+			return n.events[i].kv.modRevision >= revision
+		})
+	}
+	n.mu.RUnlock()
+	for {
+		n.mu.RLock()
+		events := n.events[next:]
+		notify := n.eventsNotify
+		n.mu.RUnlock()
+		next += len(events)
+		for _, ev := range events {
+			if !strings.HasPrefix(ev.key, keyPrefix) {
+				continue
+			}
+			event, err := n.nodeEvent(ev)
+			if err != nil {
+				return err
+			}
+			err = handler(*event)
+			if err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+func (n *nodeInMemRepo) PutLabel(node domain.Node, label domain.Label) (*domain.Node, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.unchanged(node) {
+		return nil, domain.ErrResourceVersionMismatch
+	}
+	n.revision++
+	labelIndex := -1
+	for i, nodeLabel := range node.Labels {
+		if nodeLabel.Key() == label.Key() {
+			labelIndex = i
+		}
+	}
+	if labelIndex >= 0 {
+		err := n.deleteLabelQueryModel(node, node.Labels[labelIndex])
+		if err != nil {
+			return nil, err
+		}
+		node.Labels[labelIndex] = label
+	} else {
+		node.Labels = append(node.Labels, label)
+	}
+	err := n.putNodeGetModel(node)
+	if err != nil {
+		return nil, err
+	}
+	err = n.putLabelQueryModel(node, label)
+	if err != nil {
+		return nil, err
+	}
+	return n.get(node.Id, node.Org)
+}
+
+func (n *nodeInMemRepo) DeleteLabel(node domain.Node, labelKey string) (*domain.Node, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.unchanged(node) {
+		return nil, domain.ErrResourceVersionMismatch
+	}
+	n.revision++
+	labelIndex := -1
+	for i, nodeLabel := range node.Labels {
+		if nodeLabel.Key() == labelKey {
+			labelIndex = i
+		}
+	}
+	if labelIndex >= 0 {
+		err := n.deleteLabelQueryModel(node, node.Labels[labelIndex])
+		if err != nil {
+			return nil, err
+		}
+		node.Labels = slices.Delete(node.Labels, labelIndex, labelIndex+1)
+		err = n.putNodeGetModel(node)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return n.get(node.Id, node.Org)
+}
+
+func (n *nodeInMemRepo) get(nodeId domain.NodeId, org string) (*domain.Node, error) {
+	kv, ok := n.kvs[getKey(domain.Node{Id: nodeId, Org: org})]
+	if !ok {
+		return nil, domain.ErrNodeNotFound
+	}
+	return n.unmarshalNode(kv)
+}
+
+// unchanged reports whether the stored node still has the resource version
+// the caller has read, a zero resource version skips the check
+func (n *nodeInMemRepo) unchanged(node domain.Node) bool {
+	return node.ResourceVersion == 0 || n.kvs[getKey(node)].modRevision == node.ResourceVersion
+}
+
+func (n *nodeInMemRepo) unmarshalNode(kv inMemKv) (*domain.Node, error) {
+	node, err := n.nodeMarshaller.Unmarshal(kv.value)
+	if err != nil {
+		return nil, err
+	}
+	node.ResourceVersion = kv.modRevision
+	return node, nil
+}
+
+// listNodes pages over the current state,
+// unlike etcd older revisions are not kept so the token revision is ignored
+func (n *nodeInMemRepo) listNodes(keyPrefix string, page domain.Page) ([]domain.Node, string, error) {
+	if err := page.Validate(); err != nil {
+		return nil, "", err
+	}
+	token, err := decodePageToken(page.Token, keyPrefix)
+	if err != nil {
+		return nil, "", err
+	}
+	keys := n.keysWithPrefix(keyPrefix)
+	if token != nil {
+		keys = slices.DeleteFunc(keys, func(key string) bool {
+			return key <= token.Key
+		})
+	}
+	nodes, more, err := fillPage(page, func(limit int64) ([]domain.Node, bool, error) {
+		batch := keys
+		if limit > 0 && int64(len(batch)) > limit {
+			batch = batch[:limit]
+		}
+		keys = keys[len(batch):]
+		nodes := make([]domain.Node, 0, len(batch))
+		for _, key := range batch {
+			node, err := n.unmarshalNode(n.kvs[key])
+			if err != nil {
+				return nil, false, err
+			}
+			nodes = append(nodes, *node)
+		}
+		return nodes, len(keys) > 0, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if !more {
+		return nodes, "", nil
+	}
+	return nodes, encodePageToken(getKey(nodes[len(nodes)-1]), n.revision), nil
+}
+
+func (n *nodeInMemRepo) putNodeGetModel(node domain.Node) error {
+	nodeMarshalled, err := n.nodeMarshaller.Marshal(node)
+	if err != nil {
+		return err
+	}
+	key := getKey(node)
+	kv := inMemKv{value: nodeMarshalled, modRevision: n.revision}
+	prevKv, ok := n.kvs[key]
+	n.kvs[key] = kv
+	if ok {
+		n.recordEvent(inMemEvent{key: key, kv: kv, prevKv: &prevKv})
+	} else {
+		n.recordEvent(inMemEvent{key: key, kv: kv})
+	}
+	return nil
+}
+
+func (n *nodeInMemRepo) deleteNodeGetModel(node domain.Node) {
+	key := getKey(node)
+	prevKv, ok := n.kvs[key]
+	if !ok {
+		return
+	}
+	delete(n.kvs, key)
+	n.recordEvent(inMemEvent{key: key, kv: inMemKv{modRevision: n.revision}, prevKv: &prevKv, deleted: true})
+}
+
+func (n *nodeInMemRepo) putNodeQueryModel(node domain.Node) error {
+	for _, label := range node.Labels {
+		err := n.putLabelQueryModel(node, label)
+		if err != nil {
+			return err
+		}
+	}
+	for resource, value := range node.Resources {
+		n.kvs[resourceKey(node, resource, value)] = inMemKv{value: []byte(strconv.FormatFloat(value, 'f', -1, 64)), modRevision: n.revision}
+	}
+	return nil
+}
+
+func (n *nodeInMemRepo) putLabelQueryModel(node domain.Node, label domain.Label) error {
+	labelMarshalled, err := n.labelMarshaller.Marshal(label)
+	if err != nil {
+		return err
+	}
+	keys, err := queryKeys(node, label)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		n.kvs[key] = inMemKv{value: labelMarshalled, modRevision: n.revision}
+	}
+	return nil
+}
+
+func (n *nodeInMemRepo) deleteNodeQueryModel(node domain.Node) error {
+	for _, label := range node.Labels {
+		err := n.deleteLabelQueryModel(node, label)
+		if err != nil {
+			return err
+		}
+	}
+	for resource, value := range node.Resources {
+		delete(n.kvs, resourceKey(node, resource, value))
+	}
+	return nil
+}
+
+func (n *nodeInMemRepo) deleteLabelQueryModel(node domain.Node, label domain.Label) error {
+	keys, err := queryKeys(node, label)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		delete(n.kvs, key)
+	}
+	return nil
+}
+
+func (n *nodeInMemRepo) queryNodes(query domain.Query, keyPrefix, org string, page domain.Page) ([]domain.Node, string, error) {
+	if err := query.Validate(); err != nil {
+		return nil, "", err
+	}
+	if err := page.Validate(); err != nil {
+		return nil, "", err
+	}
+	token, err := decodePageToken(page.Token, getKey(domain.Node{Org: org}))
+	if err != nil {
+		return nil, "", err
+	}
+	var nodeIds []domain.NodeId
+	if len(query) == 0 {
+		nodeIds = n.nodeIdsInScope(keyPrefix)
+	} else {
+		nodeIds, err = n.intersectSelectors(query, keyPrefix)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	if page.Sorted() {
+		return n.querySortedNodePage(nodeIds, org, page, token)
+	}
+	nodeIds = nodeIdsAfterToken(nodeIds, org, token)
+	nodes, more, err := fillPage(page, func(limit int64) ([]domain.Node, bool, error) {
+		batch := nodeIds
+		if limit > 0 && int64(len(batch)) > limit {
+			batch = batch[:limit]
+		}
+		nodeIds = nodeIds[len(batch):]
+		nodes := make([]domain.Node, 0, len(batch))
+		for _, nodeId := range batch {
+			// the read lock is held since the query was evaluated, so every matching node is still there
+			node, err := n.get(nodeId, org)
+			if err != nil {
+				return nil, false, err
+			}
+			nodes = append(nodes, *node)
+		}
+		return nodes, len(nodeIds) > 0, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if !more {
+		return nodes, "", nil
+	}
+	return nodes, encodePageToken(getKey(nodes[len(nodes)-1]), n.revision), nil
+}
+
+func (n *nodeInMemRepo) querySortedNodePage(nodeIds []domain.NodeId, org string, page domain.Page, token *pageToken) ([]domain.Node, string, error) {
+	nodes := make([]domain.Node, 0, len(nodeIds))
+	for _, nodeId := range nodeIds {
+		node, err := n.get(nodeId, org)
+		if err != nil {
+			return nil, "", err
+		}
+		nodes = append(nodes, *node)
+	}
+	domain.SortNodes(nodes, page.OrderBy)
+	nodes, more, err := pageSortedNodes(nodes, token, page)
+	if err != nil {
+		return nil, "", err
+	}
+	if !more {
+		return nodes, "", nil
+	}
+	return nodes, encodePageToken(getKey(nodes[len(nodes)-1]), n.revision), nil
+}
+
+// intersectSelectors evaluates the selectors in the order of the query plan (see query_planner.go)
+func (n *nodeInMemRepo) intersectSelectors(query domain.Query, keyPrefix string) ([]domain.NodeId, error) {
+	if len(query) == 1 {
+		return n.selectNodes(query[0], keyPrefix)
+	}
+	counts := make(map[keyRange]int64)
+	for _, r := range estimateRanges(query, keyPrefix) {
+		counts[r] = int64(len(n.keysInRange(r)))
+	}
+	plan := planQuery(query, keyPrefix, counts)
+	nodeIds, err := n.selectNodes(plan[0].selector, keyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for i, step := range plan[1:] {
+		if len(nodeIds) == 0 {
+			break
+		}
+		if int64(len(nodeIds)) <= step.estimate {
+			nodes := make([]domain.Node, 0, len(nodeIds))
+			for _, nodeId := range nodeIds {
+				node, err := n.get(nodeId, orgOf(keyPrefix))
+				if err != nil {
+					return nil, err
+				}
+				nodes = append(nodes, *node)
+			}
+			remaining := make(domain.Query, 0, len(plan)-i-1)
+			for _, rest := range plan[i+1:] {
+				remaining = append(remaining, rest.selector)
+			}
+			return matchingNodeIds(nodes, remaining), nil
+		}
+		currNodes, err := n.selectNodes(step.selector, keyPrefix)
+		if err != nil {
+			return nil, err
+		}
+		intersection := intersect.Simple(nodeIds, currNodes)
+		nodeIds = make([]domain.NodeId, len(intersection))
+		for i, node := range intersection {
+			nodeIds[i] = node.(domain.NodeId)
+		}
+	}
+	return nodeIds, nil
+}
+
+func (n *nodeInMemRepo) selectNodes(selector domain.Selector, keyPrefix string) ([]domain.NodeId, error) {
+	if selector.Group != nil {
+		return n.selectGroup(*selector.Group, keyPrefix)
+	}
+	matches, err := selector.Matcher()
+	if err != nil {
+		return nil, err
+	}
+	labelPrefix := selectorIndexPrefix(keyPrefix, selector)
+	ranges, exact := labelIndexRanges(selector, labelPrefix)
+	keys := make([]string, 0)
+	for _, r := range ranges {
+		keys = append(keys, n.keysInRange(r)...)
+	}
+	nodeIds, labeled, err := matchIndexKeys(keys, labelPrefix, selector.LabelKey, exact, matches)
+	if err != nil {
+		return nil, err
+	}
+	if selector.ShouldBe == domain.CompResDoesNotExist {
+		nodeIds = subtractNodeIds(n.nodeIdsInScope(keyPrefix), labeled)
+	}
+	return nodeIds, nil
+}
+
+func (n *nodeInMemRepo) selectGroup(group domain.SelectorGroup, keyPrefix string) ([]domain.NodeId, error) {
+	if group.Op == domain.GroupOr {
+		nodeIds := make([]domain.NodeId, 0)
+		for _, selector := range group.Selectors {
+			currNodes, err := n.selectNodes(selector, keyPrefix)
+			if err != nil {
+				return nil, err
+			}
+			nodeIds = unionNodeIds(nodeIds, currNodes)
+		}
+		return nodeIds, nil
+	}
+	nodeIds, err := n.intersectSelectors(group.Selectors, keyPrefix)
+	if err != nil || group.Op != domain.GroupNot {
+		return nodeIds, err
+	}
+	return subtractNodeIds(n.nodeIdsInScope(keyPrefix), nodeIds), nil
+}
+
+func (n *nodeInMemRepo) nodeIdsInScope(keyPrefix string) []domain.NodeId {
+	nodeIds := make([]domain.NodeId, 0)
+	for _, key := range n.keysWithPrefix(getKeyPrefixOf(keyPrefix) + "/") {
+		nodeIds = append(nodeIds, nodeFromGetKey(key).Id)
+	}
+	return nodeIds
+}
+
+// keysWithPrefix returns matching keys in etcd (lexicographical) order
+func (n *nodeInMemRepo) keysWithPrefix(prefix string) []string {
+	keys := make([]string, 0)
+	for key := range n.kvs {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// keysInRange returns keys within the range in etcd (lexicographical) order
+func (n *nodeInMemRepo) keysInRange(r keyRange) []string {
+	keys := make([]string, 0)
+	for key := range n.kvs {
+		if key >= r.start && key < r.end {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// recordEvent must be called with the write lock held
+func (n *nodeInMemRepo) recordEvent(event inMemEvent) {
+	n.events = append(n.events, event)
+	close(n.eventsNotify)
+	n.eventsNotify = make(chan struct{})
+}
+
+func (n *nodeInMemRepo) nodeEvent(ev inMemEvent) (*domain.NodeEvent, error) {
+	event := &domain.NodeEvent{
+		Revision: ev.kv.modRevision,
+	}
+	var prevNode *domain.Node
+	if ev.prevKv != nil {
+		node, err := n.unmarshalNode(*ev.prevKv)
+		if err != nil {
+			return nil, err
+		}
+		prevNode = node
+	}
+	if ev.deleted {
+		event.Type = domain.NodeDeleted
+		event.Node = *prevNode
+		event.Node.ResourceVersion = ev.kv.modRevision
+		return event, nil
+	}
+	node, err := n.unmarshalNode(ev.kv)
+	if err != nil {
+		return nil, err
+	}
+	event.Node = *node
+	if prevNode == nil {
+		event.Type = domain.NodeAdded
+	} else {
+		event.Type = domain.NodeModified
+		event.PrevNode = prevNode
+	}
+	return event, nil
+}
+
+type inMemEvent struct {
+	key     string
+	kv      inMemKv
+	prevKv  *inMemKv
+	deleted bool
+}
+
+type inMemKv struct {
+	value       []byte
+	modRevision int64
+}
@@ -0,0 +1,1238 @@
+package repos_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/c12s/magnetar/internal/domain"
+	"github.com/c12s/magnetar/internal/marshallers/proto"
+	"github.com/c12s/magnetar/internal/repos"
+	etcd "go.etcd.io/etcd/client/v3"
+)
+
+// every domain.NodeRepo implementation is run through the same suite
+
+func TestNodeInMemRepo(t *testing.T) {
+	testNodeRepo(t, func(t *testing.T) domain.NodeRepo {
+		repo, err := repos.NewNodeInMemRepo(proto.NewProtoNodeMarshaller(), proto.NewProtoLabelMarshaller())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repo
+	})
+}
+
+// requires a running etcd instance, e.g. ETCD_ADDRESS=localhost:2379
+func TestNodeEtcdRepo(t *testing.T) {
+	address := os.Getenv("ETCD_ADDRESS")
+	if address == "" {
+		t.Skip("ETCD_ADDRESS not set")
+	}
+	client, err := etcd.New(etcd.Config{
+		Endpoints: []string{fmt.Sprintf("http://%s", address)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	newRepo := func(t *testing.T) domain.NodeRepo {
+		for _, prefix := range []string{"nodes/", "labels/", "index/", "resources/"} {
+			if _, err := client.Delete(context.TODO(), prefix, etcd.WithPrefix()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		repo, err := repos.NewNodeEtcdRepo(client, proto.NewProtoNodeMarshaller(), proto.NewProtoLabelMarshaller())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repo
+	}
+	testNodeRepo(t, newRepo)
+
+	// only etcd keeps the revisions page tokens refer to
+	t.Run("CompactedPageToken", func(t *testing.T) {
+		repo := newRepo(t)
+		for i := 1; i <= 3; i++ {
+			mustPut(t, repo, newTestNode(fmt.Sprintf("n%d", i), "", domain.NewStringLabel("os", "linux")))
+		}
+		_, listToken, err := repo.ListNodePool(domain.Page{Size: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, queryToken, err := repo.QueryNodePool(domain.Query{{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "linux"}}, domain.Page{Size: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		mustPut(t, repo, newTestNode("n4", ""))
+		resp, err := client.Get(context.TODO(), "nodes/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.Compact(context.TODO(), resp.Header.Revision); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := repo.ListNodePool(domain.Page{Size: 1, Token: listToken}); !errors.Is(err, domain.ErrInvalidPageToken) {
+			t.Errorf("got error %v, want %v", err, domain.ErrInvalidPageToken)
+		}
+		_, _, err = repo.QueryNodePool(domain.Query{{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "linux"}}, domain.Page{Size: 1, Token: queryToken})
+		if !errors.Is(err, domain.ErrInvalidPageToken) {
+			t.Errorf("got error %v, want %v", err, domain.ErrInvalidPageToken)
+		}
+	})
+
+	// the largest node the services accept can be claimed and released, which rewrites all its keys at once,
+	// larger ones are rejected before etcd does
+	t.Run("TxnOpsLimit", func(t *testing.T) {
+		repo := newRepo(t)
+		withIndexKeys := func(id string, keys int) domain.Node {
+			node := newTestNode(id, "", domain.NewStringSetLabel("caps", []string{"gpu", "sgx"}))
+			node.Resources = make(map[string]float64)
+			for i := 0; node.IndexKeys() < keys; i++ {
+				node.Resources[fmt.Sprintf("r%d", i)] = float64(i)
+			}
+			return node
+		}
+		largest := withIndexKeys("n1", domain.MaxNodeIndexKeys)
+		mustPut(t, repo, largest)
+		if _, err := repo.Claim(largest.Id, "org1"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.Release(largest.Id, "org1"); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Put(withIndexKeys("n2", 128)); !errors.Is(err, domain.ErrNodeTooLarge) {
+			t.Errorf("got error %v, want %v", err, domain.ErrNodeTooLarge)
+		}
+	})
+}
+
+func testNodeRepo(t *testing.T, newRepo func(t *testing.T) domain.NodeRepo) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo domain.NodeRepo)
+	}{
+		{"PutGet", testPutGet},
+		{"ConcurrentCreate", testConcurrentCreate},
+		{"PoolOrgSplit", testPoolOrgSplit},
+		{"Delete", testDelete},
+		{"Find", testFind},
+		{"Update", testUpdate},
+		{"QueryIntersection", testQueryIntersection},
+		{"QueryOperators", testQueryOperators},
+		{"QueryRanges", testQueryRanges},
+		{"QueryResources", testQueryResources},
+		{"QueryLabelTypes", testQueryLabelTypes},
+		{"QueryGroups", testQueryGroups},
+		{"QueryPlan", testQueryPlan},
+		{"QueryEmpty", testQueryEmpty},
+		{"QueryOrgScope", testQueryOrgScope},
+		{"QueryManyNodes", testQueryManyNodes},
+		{"PutLabel", testPutLabel},
+		{"DeleteLabel", testDeleteLabel},
+		{"Claim", testClaim},
+		{"ConcurrentClaim", testConcurrentClaim},
+		{"Release", testRelease},
+		{"ResourceVersion", testResourceVersion},
+		{"Pagination", testPagination},
+		{"FilteredPagination", testFilteredPagination},
+		{"SortedQuery", testSortedQuery},
+		{"Watch", testWatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepo(t))
+		})
+	}
+}
+
+func testPutGet(t *testing.T, repo domain.NodeRepo) {
+	node := newTestNode("n1", "", domain.NewStringLabel("os", "linux"), domain.NewFloat64Label("cpu", 4))
+	node.Resources = map[string]float64{"mem": 16}
+	node.BindAddress = "10.0.0.1:7946"
+	mustPut(t, repo, node)
+
+	got, err := repo.Get(node.Id, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNode(t, *got, node)
+	if got.Resources["mem"] != 16 || got.BindAddress != node.BindAddress {
+		t.Errorf("got resources %v and bind address %q", got.Resources, got.BindAddress)
+	}
+	if _, err := repo.Get(domain.NodeId{Value: "missing"}, ""); err == nil {
+		t.Error("expected an error for a missing node")
+	}
+}
+
+func testPoolOrgSplit(t *testing.T, repo domain.NodeRepo) {
+	free := newTestNode("n1", "")
+	claimed := newTestNode("n2", "org1")
+	mustPut(t, repo, free)
+	mustPut(t, repo, claimed)
+
+	if _, err := repo.Get(claimed.Id, ""); err == nil {
+		t.Error("claimed node must not be in the pool")
+	}
+	if _, err := repo.Get(free.Id, "org1"); err == nil {
+		t.Error("free node must not be owned by an org")
+	}
+	got, err := repo.Get(claimed.Id, "org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Claimed() || got.Org != "org1" {
+		t.Errorf("expected node claimed by org1, got org %q", got.Org)
+	}
+
+	pool, _, err := repo.ListNodePool(domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, pool, "n1")
+	owned, _, err := repo.ListOrgOwnedNodes("org1", domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, owned, "n2")
+	all, _, err := repo.ListAllNodes(domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, all, "n1", "n2")
+}
+
+func testDelete(t *testing.T, repo domain.NodeRepo) {
+	node := newTestNode("n1", "", domain.NewBoolLabel("gpu", true))
+	mustPut(t, repo, node)
+	if err := repo.Delete(node); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Get(node.Id, ""); err == nil {
+		t.Error("deleted node still readable")
+	}
+	nodes, _, err := repo.QueryNodePool(domain.Query{{LabelKey: "gpu", ShouldBe: domain.CompResEq, Value: "true"}}, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes)
+
+	// a node claimed after it was read is not deleted
+	mustPut(t, repo, newTestNode("n2", ""))
+	read, err := repo.Get(domain.NodeId{Value: "n2"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Claim(read.Id, "org1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete(*read); !errors.Is(err, domain.ErrResourceVersionMismatch) {
+		t.Errorf("got error %v, want %v", err, domain.ErrResourceVersionMismatch)
+	}
+	if _, err := repo.Get(read.Id, "org1"); err != nil {
+		t.Errorf("expected the claimed node to be kept, got %v", err)
+	}
+}
+
+func testFind(t *testing.T, repo domain.NodeRepo) {
+	mustPut(t, repo, newTestNode("n1", ""))
+	mustPut(t, repo, newTestNode("n2", "org1"))
+
+	got, err := repo.Find(domain.NodeId{Value: "n1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNode(t, *got, newTestNode("n1", ""))
+	got, err = repo.Find(domain.NodeId{Value: "n2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNode(t, *got, newTestNode("n2", "org1"))
+	if _, err := repo.Find(domain.NodeId{Value: "missing"}); !errors.Is(err, domain.ErrNodeNotFound) {
+		t.Errorf("got error %v, want %v", err, domain.ErrNodeNotFound)
+	}
+}
+
+func testUpdate(t *testing.T, repo domain.NodeRepo) {
+	mustPut(t, repo, newTestNode("n1", "org1", domain.NewStringLabel("os", "linux"), domain.NewFloat64Label("cpu", 4)))
+	prev, err := repo.Get(domain.NodeId{Value: "n1"}, "org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := repo.Update(*prev, newTestNode("n1", "", domain.NewFloat64Label("cpu", 8)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNode(t, *got, newTestNode("n1", "org1", domain.NewFloat64Label("cpu", 8)))
+
+	nodes, _, err := repo.QueryOrgOwnedNodes(domain.Query{{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "linux"}}, "org1", domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes)
+	nodes, _, err = repo.QueryOrgOwnedNodes(domain.Query{{LabelKey: "cpu", ShouldBe: domain.CompResEq, Value: "8"}}, "org1", domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n1")
+
+	if _, err := repo.Update(*prev, newTestNode("n1", "")); !errors.Is(err, domain.ErrResourceVersionMismatch) {
+		t.Errorf("got error %v, want %v", err, domain.ErrResourceVersionMismatch)
+	}
+}
+
+func testQueryIntersection(t *testing.T, repo domain.NodeRepo) {
+	mustPut(t, repo, newTestNode("n1", "", domain.NewStringLabel("os", "linux"), domain.NewFloat64Label("cpu", 8)))
+	mustPut(t, repo, newTestNode("n2", "", domain.NewStringLabel("os", "linux"), domain.NewFloat64Label("cpu", 2)))
+	mustPut(t, repo, newTestNode("n3", "", domain.NewStringLabel("os", "windows"), domain.NewFloat64Label("cpu", 16)))
+	mustPut(t, repo, newTestNode("n4", "", domain.NewStringLabel("os", "linux")))
+
+	nodes, _, err := repo.QueryNodePool(domain.Query{
+		{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "linux"},
+		{LabelKey: "cpu", ShouldBe: domain.CompResGt, Value: "4"},
+	}, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n1")
+
+	nodes, _, err = repo.QueryNodePool(domain.Query{
+		{LabelKey: "os", ShouldBe: domain.CompResNeq, Value: "windows"},
+	}, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n1", "n2", "n4")
+}
+
+func testQueryOperators(t *testing.T, repo domain.NodeRepo) {
+	mustPut(t, repo, newTestNode("n1", "", domain.NewStringLabel("zone", "eu-west-1"), domain.NewFloat64Label("cpu", 8)))
+	mustPut(t, repo, newTestNode("n2", "", domain.NewStringLabel("zone", "eu-east-1"), domain.NewFloat64Label("cpu", 4)))
+	mustPut(t, repo, newTestNode("n3", "", domain.NewStringLabel("zone", "us-west-1"), domain.NewBoolLabel("gpu", true)))
+	mustPut(t, repo, newTestNode("n4", "org1", domain.NewStringLabel("zone", "eu-west-1")))
+
+	tests := []struct {
+		selector domain.Selector
+		want     []string
+	}{
+		{domain.Selector{LabelKey: "cpu", ShouldBe: domain.CompResGe, Value: "8"}, []string{"n1"}},
+		{domain.Selector{LabelKey: "cpu", ShouldBe: domain.CompResLe, Value: "8"}, []string{"n1", "n2"}},
+		{domain.Selector{LabelKey: "zone", ShouldBe: domain.CompResIn, Values: []string{"eu-west-1", "us-west-1"}}, []string{"n1", "n3"}},
+		{domain.Selector{LabelKey: "zone", ShouldBe: domain.CompResNotIn, Values: []string{"eu-west-1"}}, []string{"n2", "n3"}},
+		{domain.Selector{LabelKey: "gpu", ShouldBe: domain.CompResExists}, []string{"n3"}},
+		{domain.Selector{LabelKey: "gpu", ShouldBe: domain.CompResDoesNotExist}, []string{"n1", "n2"}},
+		{domain.Selector{LabelKey: "missing", ShouldBe: domain.CompResDoesNotExist}, []string{"n1", "n2", "n3"}},
+		{domain.Selector{LabelKey: "zone", ShouldBe: domain.CompResPrefix, Value: "eu-"}, []string{"n1", "n2"}},
+		{domain.Selector{LabelKey: "zone", ShouldBe: domain.CompResRegex, Value: "^(eu|us)-west-[0-9]$"}, []string{"n1", "n3"}},
+	}
+	for _, tt := range tests {
+		nodes, _, err := repo.QueryNodePool(domain.Query{tt.selector}, domain.Page{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertNodeIds(t, nodes, tt.want...)
+		for _, node := range nodes {
+			if !tt.selector.Matches(node) {
+				t.Errorf("node %s returned by the repo does not match %s", node.Id.Value, tt.selector.ShouldBe)
+			}
+		}
+	}
+
+	nodes, _, err := repo.QueryOrgOwnedNodes(domain.Query{{LabelKey: "gpu", ShouldBe: domain.CompResDoesNotExist}}, "org1", domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n4")
+
+	if _, _, err := repo.QueryNodePool(domain.Query{{LabelKey: "zone", ShouldBe: domain.CompResRegex, Value: "["}}, domain.Page{}); !errors.Is(err, domain.ErrInvalidQuery) {
+		t.Errorf("got error %v, want %v", err, domain.ErrInvalidQuery)
+	}
+}
+
+func testQueryRanges(t *testing.T, repo domain.NodeRepo) {
+	mustPut(t, repo, newTestNode("n1", "", domain.NewFloat64Label("temp", -12.5)))
+	mustPut(t, repo, newTestNode("n2", "", domain.NewFloat64Label("temp", -0.001)))
+	mustPut(t, repo, newTestNode("n3", "", domain.NewFloat64Label("temp", 0.25)))
+	mustPut(t, repo, newTestNode("n4", "", domain.NewFloat64Label("temp", 3)))
+	mustPut(t, repo, newTestNode("n5", "", domain.NewFloat64Label("temp", 1024)))
+	mustPut(t, repo, newTestNode("n6", "", domain.NewStringLabel("temp", "3")))
+	mustPut(t, repo, newTestNode("n7", "", domain.NewBoolLabel("temp", true)))
+
+	tests := []struct {
+		selector domain.Selector
+		want     []string
+	}{
+		{domain.Selector{LabelKey: "temp", ShouldBe: domain.CompResGt, Value: "0"}, []string{"n3", "n4", "n5"}},
+		{domain.Selector{LabelKey: "temp", ShouldBe: domain.CompResLt, Value: "0"}, []string{"n1"}},
+		{domain.Selector{LabelKey: "temp", ShouldBe: domain.CompResEq, Value: "0"}, []string{"n2"}},
+		{domain.Selector{LabelKey: "temp", ShouldBe: domain.CompResGe, Value: "3.001"}, []string{"n4", "n5"}},
+		{domain.Selector{LabelKey: "temp", ShouldBe: domain.CompResLe, Value: "3"}, []string{"n1", "n2", "n3", "n4", "n6"}},
+		{domain.Selector{LabelKey: "temp", ShouldBe: domain.CompResGt, Value: "-100"}, []string{"n1", "n2", "n3", "n4", "n5"}},
+		{domain.Selector{LabelKey: "temp", ShouldBe: domain.CompResLe, Value: "true"}, []string{"n7"}},
+		{domain.Selector{LabelKey: "temp", ShouldBe: domain.CompResEq, Value: "3"}, []string{"n4", "n6"}},
+		{domain.Selector{LabelKey: "temp", ShouldBe: domain.CompResNeq, Value: "3"}, []string{"n1", "n2", "n3", "n5"}},
+	}
+	for _, tt := range tests {
+		nodes, _, err := repo.QueryNodePool(domain.Query{tt.selector}, domain.Page{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertNodeIds(t, nodes, tt.want...)
+		for _, node := range nodes {
+			if !tt.selector.Matches(node) {
+				t.Errorf("node %s returned by the repo does not match %s %s", node.Id.Value, tt.selector.ShouldBe, tt.selector.Value)
+			}
+		}
+	}
+}
+
+func testQueryLabelTypes(t *testing.T, repo domain.NodeRepo) {
+	version := func(value string) domain.Version {
+		v, err := domain.ParseVersion(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	provisioned := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	mustPut(t, repo, newTestNode("n1", "",
+		domain.NewInt64Label("pods", 9007199254740993),
+		domain.NewTimestampLabel("provisioned-at", provisioned),
+		domain.NewSemverLabel("kernel", version("5.15.0-91-generic")),
+		domain.NewStringSetLabel("capabilities", []string{"gpu", "sgx", "gpu"})))
+	mustPut(t, repo, newTestNode("n2", "",
+		domain.NewInt64Label("pods", -3),
+		domain.NewTimestampLabel("provisioned-at", provisioned.Add(-time.Nanosecond)),
+		domain.NewSemverLabel("kernel", version("5.15.0")),
+		domain.NewStringSetLabel("capabilities", []string{"sgx"})))
+	mustPut(t, repo, newTestNode("n3", "",
+		domain.NewInt64Label("pods", 110),
+		domain.NewTimestampLabel("provisioned-at", provisioned.Add(time.Hour)),
+		domain.NewSemverLabel("kernel", version("v6.1.0-rc.2")),
+		domain.NewStringSetLabel("capabilities", nil)))
+	mustPut(t, repo, newTestNode("n4", "",
+		domain.NewFloat64Label("pods", 110),
+		domain.NewStringLabel("kernel", "5.15.0")))
+
+	tests := []struct {
+		selector domain.Selector
+		want     []string
+	}{
+		// integers are compared exactly, the float label compares as well
+		{domain.Selector{LabelKey: "pods", ShouldBe: domain.CompResEq, Value: "9007199254740993"}, []string{"n1"}},
+		{domain.Selector{LabelKey: "pods", ShouldBe: domain.CompResGt, Value: "9007199254740992"}, []string{"n1"}},
+		{domain.Selector{LabelKey: "pods", ShouldBe: domain.CompResLt, Value: "0"}, []string{"n2"}},
+		{domain.Selector{LabelKey: "pods", ShouldBe: domain.CompResGe, Value: "110"}, []string{"n1", "n3", "n4"}},
+		{domain.Selector{LabelKey: "pods", ShouldBe: domain.CompResNeq, Value: "110"}, []string{"n1", "n2"}},
+		{domain.Selector{LabelKey: "provisioned-at", ShouldBe: domain.CompResGe, Value: "2024-03-01T12:00:00Z"}, []string{"n1", "n3"}},
+		{domain.Selector{LabelKey: "provisioned-at", ShouldBe: domain.CompResLt, Value: "2024-03-01T13:00:00+01:00"}, []string{"n2"}},
+		{domain.Selector{LabelKey: "provisioned-at", ShouldBe: domain.CompResEq, Value: "2024-03-01T12:00:00.000Z"}, []string{"n1"}},
+		// pre-releases precede the release
+		{domain.Selector{LabelKey: "kernel", ShouldBe: domain.CompResLt, Value: "5.15.0"}, []string{"n1"}},
+		{domain.Selector{LabelKey: "kernel", ShouldBe: domain.CompResGe, Value: "5.15.0"}, []string{"n2", "n3", "n4"}},
+		{domain.Selector{LabelKey: "kernel", ShouldBe: domain.CompResGt, Value: "6.1.0-rc.1"}, []string{"n3"}},
+		{domain.Selector{LabelKey: "kernel", ShouldBe: domain.CompResGt, Value: "6.1.0-rc.10"}, []string{}},
+		{domain.Selector{LabelKey: "kernel", ShouldBe: domain.CompResRegex, Value: "^5\\."}, []string{"n4"}},
+		// string sets equal every value they contain
+		{domain.Selector{LabelKey: "capabilities", ShouldBe: domain.CompResEq, Value: "gpu"}, []string{"n1"}},
+		{domain.Selector{LabelKey: "capabilities", ShouldBe: domain.CompResIn, Values: []string{"gpu", "sgx"}}, []string{"n1", "n2"}},
+		{domain.Selector{LabelKey: "capabilities", ShouldBe: domain.CompResNotIn, Values: []string{"gpu"}}, []string{"n2", "n3"}},
+		{domain.Selector{LabelKey: "capabilities", ShouldBe: domain.CompResNeq, Value: "sgx"}, []string{"n3"}},
+		// an empty set contains no value, not even an empty one
+		{domain.Selector{LabelKey: "capabilities", ShouldBe: domain.CompResEq, Value: ""}, []string{}},
+		{domain.Selector{LabelKey: "capabilities", ShouldBe: domain.CompResIn, Values: []string{""}}, []string{}},
+		{domain.Selector{LabelKey: "capabilities", ShouldBe: domain.CompResPrefix, Value: "sg"}, []string{"n1", "n2"}},
+		{domain.Selector{LabelKey: "capabilities", ShouldBe: domain.CompResPrefix, Value: ""}, []string{"n1", "n2"}},
+		{domain.Selector{LabelKey: "capabilities", ShouldBe: domain.CompResRegex, Value: "^g"}, []string{"n1"}},
+		{domain.Selector{LabelKey: "capabilities", ShouldBe: domain.CompResExists}, []string{"n1", "n2", "n3"}},
+		{domain.Selector{LabelKey: "capabilities", ShouldBe: domain.CompResDoesNotExist}, []string{"n4"}},
+	}
+	for _, tt := range tests {
+		nodes, _, err := repo.QueryNodePool(domain.Query{tt.selector}, domain.Page{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertNodeIds(t, nodes, tt.want...)
+		for _, node := range nodes {
+			if !tt.selector.Matches(node) {
+				t.Errorf("node %s returned by the repo does not match %s %s", node.Id.Value, tt.selector.ShouldBe, tt.selector.Value)
+			}
+		}
+	}
+
+	got, err := repo.Get(domain.NodeId{Value: "n1"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNode(t, *got, newTestNode("n1", "",
+		domain.NewInt64Label("pods", 9007199254740993),
+		domain.NewTimestampLabel("provisioned-at", provisioned),
+		domain.NewSemverLabel("kernel", version("5.15.0-91-generic")),
+		domain.NewStringSetLabel("capabilities", []string{"gpu", "sgx"})))
+
+	// members no longer in the set are dropped from the index
+	got, err = repo.PutLabel(*got, domain.NewStringSetLabel("capabilities", []string{"sgx", "tpm"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes, _, err := repo.QueryNodePool(domain.Query{{LabelKey: "capabilities", ShouldBe: domain.CompResEq, Value: "gpu"}}, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes)
+	if _, err := repo.DeleteLabel(*got, "capabilities"); err != nil {
+		t.Fatal(err)
+	}
+	nodes, _, err = repo.QueryNodePool(domain.Query{{LabelKey: "capabilities", ShouldBe: domain.CompResIn, Values: []string{"sgx", "tpm"}}}, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n2")
+
+	// replacing the largest set with another one deletes and puts all member keys at once
+	largest := func(member string) []string {
+		members := make([]string, 0, domain.MaxStringSetSize)
+		for i := 0; i < domain.MaxStringSetSize; i++ {
+			members = append(members, fmt.Sprintf("%s%d", member, i))
+		}
+		return members
+	}
+	got, err = repo.Get(domain.NodeId{Value: "n1"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = repo.PutLabel(*got, domain.NewStringSetLabel("capabilities", largest("a")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = repo.PutLabel(*got, domain.NewStringSetLabel("capabilities", largest("b"))); err != nil {
+		t.Fatal(err)
+	}
+
+	nodes, _, err = repo.QueryNodePool(domain.Query{}, domain.Page{OrderBy: []domain.OrderBy{{LabelKey: "kernel"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertOrder(t, nodes, "n1", "n2", "n3", "n4")
+}
+
+func testQueryResources(t *testing.T, repo domain.NodeRepo) {
+	withResources := func(node domain.Node, resources map[string]float64) domain.Node {
+		node.Resources = resources
+		return node
+	}
+	mustPut(t, repo, withResources(newTestNode("n1", "", domain.NewFloat64Label("cpu", 2)), map[string]float64{"cpu": 16, "memory": 64}))
+	mustPut(t, repo, withResources(newTestNode("n2", ""), map[string]float64{"cpu": 8}))
+	mustPut(t, repo, withResources(newTestNode("n3", ""), map[string]float64{"cpu": 4, "gpu": 1}))
+	mustPut(t, repo, withResources(newTestNode("n4", "org1"), map[string]float64{"cpu": 32}))
+
+	tests := []struct {
+		query domain.Query
+		want  []string
+	}{
+		// resources and labels with the same name are independent
+		{domain.Query{{Resource: "cpu", ShouldBe: domain.CompResGe, Value: "8"}}, []string{"n1", "n2"}},
+		{domain.Query{{LabelKey: "cpu", ShouldBe: domain.CompResGe, Value: "8"}}, []string{}},
+		{domain.Query{{Resource: "gpu", ShouldBe: domain.CompResExists}}, []string{"n3"}},
+		{domain.Query{{Resource: "memory", ShouldBe: domain.CompResDoesNotExist}}, []string{"n2", "n3"}},
+		{domain.Query{{Resource: "cpu", ShouldBe: domain.CompResLt, Value: "16"}, {Resource: "gpu", ShouldBe: domain.CompResDoesNotExist}}, []string{"n2"}},
+	}
+	for _, tt := range tests {
+		nodes, _, err := repo.QueryNodePool(tt.query, domain.Page{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertNodeIds(t, nodes, tt.want...)
+	}
+
+	// the index follows resource changes and claims
+	prev, err := repo.Get(domain.NodeId{Value: "n2"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Update(*prev, withResources(newTestNode("n2", ""), map[string]float64{"cpu": 2})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Claim(domain.NodeId{Value: "n1"}, "org1"); err != nil {
+		t.Fatal(err)
+	}
+	nodes, _, err := repo.QueryNodePool(domain.Query{{Resource: "cpu", ShouldBe: domain.CompResGe, Value: "8"}}, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes)
+	nodes, _, err = repo.QueryOrgOwnedNodes(domain.Query{{Resource: "cpu", ShouldBe: domain.CompResGe, Value: "8"}}, "org1", domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n1", "n4")
+}
+
+func testQueryGroups(t *testing.T, repo domain.NodeRepo) {
+	mustPut(t, repo, newTestNode("n1", "", domain.NewStringLabel("zone", "a"), domain.NewBoolLabel("gpu", true), domain.NewFloat64Label("mem", 128)))
+	mustPut(t, repo, newTestNode("n2", "", domain.NewStringLabel("zone", "b"), domain.NewBoolLabel("gpu", true), domain.NewFloat64Label("mem", 32)))
+	mustPut(t, repo, newTestNode("n3", "", domain.NewStringLabel("zone", "c"), domain.NewStringLabel("tier", "premium")))
+	mustPut(t, repo, newTestNode("n4", "", domain.NewStringLabel("zone", "c")))
+
+	or := func(selectors ...domain.Selector) domain.Selector {
+		return domain.Selector{Group: &domain.SelectorGroup{Op: domain.GroupOr, Selectors: selectors}}
+	}
+	and := func(selectors ...domain.Selector) domain.Selector {
+		return domain.Selector{Group: &domain.SelectorGroup{Op: domain.GroupAnd, Selectors: selectors}}
+	}
+	not := func(selectors ...domain.Selector) domain.Selector {
+		return domain.Selector{Group: &domain.SelectorGroup{Op: domain.GroupNot, Selectors: selectors}}
+	}
+	zoneA := domain.Selector{LabelKey: "zone", ShouldBe: domain.CompResEq, Value: "a"}
+	zoneB := domain.Selector{LabelKey: "zone", ShouldBe: domain.CompResEq, Value: "b"}
+	gpu := domain.Selector{LabelKey: "gpu", ShouldBe: domain.CompResEq, Value: "true"}
+	bigMem := domain.Selector{LabelKey: "mem", ShouldBe: domain.CompResGt, Value: "64"}
+	premium := domain.Selector{LabelKey: "tier", ShouldBe: domain.CompResEq, Value: "premium"}
+
+	tests := []struct {
+		query domain.Query
+		want  []string
+	}{
+		{domain.Query{or(zoneA, zoneB)}, []string{"n1", "n2"}},
+		{domain.Query{or(and(gpu, bigMem), premium)}, []string{"n1", "n3"}},
+		{domain.Query{gpu, or(zoneB, premium)}, []string{"n2"}},
+		{domain.Query{not(gpu)}, []string{"n3", "n4"}},
+		{domain.Query{not(gpu, bigMem)}, []string{"n2", "n3", "n4"}},
+		{domain.Query{not(or(zoneA, zoneB)), not(premium)}, []string{"n4"}},
+	}
+	for _, tt := range tests {
+		nodes, _, err := repo.QueryNodePool(tt.query, domain.Page{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertNodeIds(t, nodes, tt.want...)
+		for _, node := range nodes {
+			if !tt.query.Matches(node) {
+				t.Errorf("node %s returned by the repo does not match the query", node.Id.Value)
+			}
+		}
+	}
+}
+
+func testQueryPlan(t *testing.T, repo domain.NodeRepo) {
+	for i := 0; i < 20; i++ {
+		labels := []domain.Label{domain.NewStringLabel("os", "linux"), domain.NewFloat64Label("cpu", float64(i))}
+		if i < 2 {
+			labels = append(labels, domain.NewBoolLabel("gpu", true))
+		}
+		if i%2 == 0 {
+			labels = append(labels, domain.NewStringLabel("spot", "yes"))
+		}
+		mustPut(t, repo, newTestNode(fmt.Sprintf("n%02d", i), "", labels...))
+	}
+
+	gpu := domain.Selector{LabelKey: "gpu", ShouldBe: domain.CompResEq, Value: "true"}
+	linux := domain.Selector{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "linux"}
+	notSpot := domain.Selector{LabelKey: "spot", ShouldBe: domain.CompResDoesNotExist}
+	lowCpu := domain.Selector{LabelKey: "cpu", ShouldBe: domain.CompResLt, Value: "10"}
+	windows := domain.Selector{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "windows"}
+	not := func(selectors ...domain.Selector) domain.Selector {
+		return domain.Selector{Group: &domain.SelectorGroup{Op: domain.GroupNot, Selectors: selectors}}
+	}
+
+	// the result must not depend on the order the selectors are given in
+	tests := []struct {
+		query domain.Query
+		want  []string
+	}{
+		{domain.Query{linux, lowCpu, notSpot, gpu}, []string{"n01"}},
+		{domain.Query{gpu, notSpot, lowCpu, linux}, []string{"n01"}},
+		{domain.Query{notSpot, linux, lowCpu}, []string{"n01", "n03", "n05", "n07", "n09"}},
+		{domain.Query{lowCpu, not(linux, gpu)}, []string{"n02", "n03", "n04", "n05", "n06", "n07", "n08", "n09"}},
+		{domain.Query{linux, lowCpu, windows}, []string{}},
+	}
+	for _, tt := range tests {
+		nodes, _, err := repo.QueryNodePool(tt.query, domain.Page{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertNodeIds(t, nodes, tt.want...)
+	}
+
+	// invalid selectors are rejected even when the planner would never read their index
+	invalid := domain.Selector{LabelKey: "cpu", ShouldBe: domain.CompResRegex, Value: "["}
+	if _, _, err := repo.QueryNodePool(domain.Query{windows, invalid}, domain.Page{}); !errors.Is(err, domain.ErrInvalidQuery) {
+		t.Errorf("got error %v, want %v", err, domain.ErrInvalidQuery)
+	}
+}
+
+func testQueryEmpty(t *testing.T, repo domain.NodeRepo) {
+	mustPut(t, repo, newTestNode("n1", "", domain.NewStringLabel("os", "linux")))
+	mustPut(t, repo, newTestNode("n2", "org1", domain.NewStringLabel("os", "linux")))
+
+	nodes, _, err := repo.QueryNodePool(domain.Query{}, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n1")
+	nodes, _, err = repo.QueryOrgOwnedNodes(domain.Query{}, "org1", domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n2")
+}
+
+func testQueryOrgScope(t *testing.T, repo domain.NodeRepo) {
+	mustPut(t, repo, newTestNode("n1", "", domain.NewStringLabel("os", "linux")))
+	mustPut(t, repo, newTestNode("n2", "org1", domain.NewStringLabel("os", "linux")))
+	mustPut(t, repo, newTestNode("n3", "org2", domain.NewStringLabel("os", "linux")))
+
+	query := domain.Query{{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "linux"}}
+	nodes, _, err := repo.QueryNodePool(query, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n1")
+	nodes, _, err = repo.QueryOrgOwnedNodes(query, "org1", domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n2")
+	if nodes[0].Org != "org1" {
+		t.Errorf("expected org1, got %q", nodes[0].Org)
+	}
+}
+
+func testQueryManyNodes(t *testing.T, repo domain.NodeRepo) {
+	want := make([]string, 0)
+	for i := 0; i < 150; i++ {
+		id := fmt.Sprintf("n%03d", i)
+		mustPut(t, repo, newTestNode(id, "org1", domain.NewStringLabel("idx", fmt.Sprint(i))))
+		if i%2 == 0 {
+			want = append(want, id)
+		}
+	}
+	mustPut(t, repo, newTestNode("other", "org2", domain.NewStringLabel("idx", "0")))
+
+	query := domain.Query{{LabelKey: "idx", ShouldBe: domain.CompResRegex, Value: "[02468]$"}}
+	nodes, _, err := repo.QueryOrgOwnedNodes(query, "org1", domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, want...)
+	for _, node := range nodes {
+		if node.Org != "org1" || len(node.Labels) != 1 {
+			t.Errorf("got node %s of org %q with labels %s", node.Id.Value, node.Org, labelsString(node.Labels))
+		}
+	}
+}
+
+func testPutLabel(t *testing.T, repo domain.NodeRepo) {
+	node := newTestNode("n1", "org1", domain.NewStringLabel("os", "linux"))
+	mustPut(t, repo, node)
+
+	got, err := repo.PutLabel(node, domain.NewFloat64Label("cpu", 8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNode(t, *got, newTestNode("n1", "org1", domain.NewStringLabel("os", "linux"), domain.NewFloat64Label("cpu", 8)))
+
+	got, err = repo.PutLabel(*got, domain.NewStringLabel("os", "windows"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNode(t, *got, newTestNode("n1", "org1", domain.NewStringLabel("os", "windows"), domain.NewFloat64Label("cpu", 8)))
+
+	nodes, _, err := repo.QueryOrgOwnedNodes(domain.Query{{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "windows"}}, "org1", domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n1")
+	nodes, _, err = repo.QueryOrgOwnedNodes(domain.Query{{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "linux"}}, "org1", domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes)
+}
+
+func testDeleteLabel(t *testing.T, repo domain.NodeRepo) {
+	node := newTestNode("n1", "", domain.NewStringLabel("os", "linux"), domain.NewBoolLabel("gpu", true))
+	mustPut(t, repo, node)
+
+	got, err := repo.DeleteLabel(node, "gpu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNode(t, *got, newTestNode("n1", "", domain.NewStringLabel("os", "linux")))
+
+	nodes, _, err := repo.QueryNodePool(domain.Query{{LabelKey: "gpu", ShouldBe: domain.CompResEq, Value: "true"}}, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes)
+
+	got, err = repo.DeleteLabel(*got, "missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNode(t, *got, newTestNode("n1", "", domain.NewStringLabel("os", "linux")))
+}
+
+func testClaim(t *testing.T, repo domain.NodeRepo) {
+	mustPut(t, repo, newTestNode("n1", "", domain.NewStringLabel("os", "linux")))
+
+	got, err := repo.Claim(domain.NodeId{Value: "n1"}, "org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNode(t, *got, newTestNode("n1", "org1", domain.NewStringLabel("os", "linux")))
+
+	query := domain.Query{{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "linux"}}
+	nodes, _, err := repo.QueryNodePool(query, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes)
+	nodes, _, err = repo.QueryOrgOwnedNodes(query, "org1", domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n1")
+
+	if _, err := repo.Claim(domain.NodeId{Value: "n1"}, "org2"); !errors.Is(err, domain.ErrNodeClaimed) {
+		t.Errorf("got error %v, want %v", err, domain.ErrNodeClaimed)
+	}
+	if _, err := repo.Claim(domain.NodeId{Value: "missing"}, "org2"); !errors.Is(err, domain.ErrNodeClaimed) {
+		t.Errorf("got error %v, want %v", err, domain.ErrNodeClaimed)
+	}
+}
+
+func testRelease(t *testing.T, repo domain.NodeRepo) {
+	mustPut(t, repo, newTestNode("n1", "org1", domain.NewStringLabel("os", "linux")))
+
+	got, err := repo.Release(domain.NodeId{Value: "n1"}, "org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNode(t, *got, newTestNode("n1", "", domain.NewStringLabel("os", "linux")))
+
+	query := domain.Query{{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "linux"}}
+	nodes, _, err := repo.QueryOrgOwnedNodes(query, "org1", domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes)
+	nodes, _, err = repo.QueryNodePool(query, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n1")
+
+	if _, err := repo.Release(domain.NodeId{Value: "n1"}, "org1"); !errors.Is(err, domain.ErrNodeNotFound) {
+		t.Errorf("got error %v, want %v", err, domain.ErrNodeNotFound)
+	}
+}
+
+func testConcurrentClaim(t *testing.T, repo domain.NodeRepo) {
+	mustPut(t, repo, newTestNode("n1", "", domain.NewStringLabel("os", "linux")))
+
+	const claimers = 8
+	errs := make(chan error, claimers)
+	wg := sync.WaitGroup{}
+	for i := 0; i < claimers; i++ {
+		wg.Add(1)
+		go func(org string) {
+			defer wg.Done()
+			_, err := repo.Claim(domain.NodeId{Value: "n1"}, org)
+			errs <- err
+		}(fmt.Sprintf("org%d", i))
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+		} else if !errors.Is(err, domain.ErrNodeClaimed) {
+			t.Fatal(err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("node claimed %d times", succeeded)
+	}
+	all, _, err := repo.ListAllNodes(domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, all, "n1")
+}
+
+func testConcurrentCreate(t *testing.T, repo domain.NodeRepo) {
+	const creators = 8
+	errs := make(chan error, creators)
+	wg := sync.WaitGroup{}
+	for i := 0; i < creators; i++ {
+		wg.Add(1)
+		go func(address string) {
+			defer wg.Done()
+			node := newTestNode("n1", "", domain.NewStringLabel("address", address))
+			node.BindAddress = address
+			errs <- repo.Create(node)
+		}(fmt.Sprintf("10.0.0.%d:7946", i))
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+		} else if !errors.Is(err, domain.ErrNodeExists) {
+			t.Fatal(err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("node created %d times", succeeded)
+	}
+	got, err := repo.Get(domain.NodeId{Value: "n1"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the index holds only the labels of the node that was created
+	nodes, _, err := repo.QueryNodePool(domain.Query{{LabelKey: "address", ShouldBe: domain.CompResExists}}, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n1")
+	nodes, _, err = repo.QueryNodePool(domain.Query{{LabelKey: "address", ShouldBe: domain.CompResEq, Value: got.BindAddress}}, domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n1")
+}
+
+func testResourceVersion(t *testing.T, repo domain.NodeRepo) {
+	mustPut(t, repo, newTestNode("n1", "", domain.NewStringLabel("os", "linux")))
+	stale, err := repo.Get(domain.NodeId{Value: "n1"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stale.ResourceVersion == 0 {
+		t.Fatal("resource version not set")
+	}
+
+	updated, err := repo.PutLabel(*stale, domain.NewBoolLabel("gpu", true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.ResourceVersion <= stale.ResourceVersion {
+		t.Errorf("resource version not bumped: %d -> %d", stale.ResourceVersion, updated.ResourceVersion)
+	}
+	if _, err := repo.PutLabel(*stale, domain.NewBoolLabel("gpu", false)); !errors.Is(err, domain.ErrResourceVersionMismatch) {
+		t.Errorf("got error %v, want %v", err, domain.ErrResourceVersionMismatch)
+	}
+	if _, err := repo.DeleteLabel(*stale, "gpu"); !errors.Is(err, domain.ErrResourceVersionMismatch) {
+		t.Errorf("got error %v, want %v", err, domain.ErrResourceVersionMismatch)
+	}
+
+	nodes, _, err := repo.ListNodePool(domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || nodes[0].ResourceVersion != updated.ResourceVersion {
+		t.Errorf("listed nodes %v, want resource version %d", nodes, updated.ResourceVersion)
+	}
+	claimed, err := repo.Claim(updated.Id, "org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := repo.Get(updated.Id, "org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claimed.ResourceVersion != got.ResourceVersion {
+		t.Errorf("claimed resource version %d, stored %d", claimed.ResourceVersion, got.ResourceVersion)
+	}
+}
+
+func testPagination(t *testing.T, repo domain.NodeRepo) {
+	for i := 1; i <= 5; i++ {
+		mustPut(t, repo, newTestNode(fmt.Sprintf("n%d", i), "", domain.NewStringLabel("os", "linux")))
+	}
+	mustPut(t, repo, newTestNode("n6", "", domain.NewStringLabel("os", "windows")))
+
+	listPage := func(page domain.Page) ([]domain.Node, string, error) {
+		return repo.ListNodePool(page)
+	}
+	queryPage := func(page domain.Page) ([]domain.Node, string, error) {
+		return repo.QueryNodePool(domain.Query{{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "linux"}}, page)
+	}
+	assertPages(t, listPage, 4, []string{"n1", "n2", "n3", "n4"}, []string{"n5", "n6"})
+	assertPages(t, queryPage, 2, []string{"n1", "n2"}, []string{"n3", "n4"}, []string{"n5"})
+	assertPages(t, queryPage, 0, []string{"n1", "n2", "n3", "n4", "n5"})
+
+	if _, _, err := repo.ListNodePool(domain.Page{Size: 2, Token: "invalid"}); !errors.Is(err, domain.ErrInvalidPageToken) {
+		t.Errorf("got error %v, want %v", err, domain.ErrInvalidPageToken)
+	}
+	_, token, err := repo.ListNodePool(domain.Page{Size: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := repo.ListOrgOwnedNodes("org1", domain.Page{Size: 2, Token: token}); !errors.Is(err, domain.ErrInvalidPageToken) {
+		t.Errorf("got error %v, want %v", err, domain.ErrInvalidPageToken)
+	}
+	if _, _, err := repo.ListNodePool(domain.Page{Size: -1}); !errors.Is(err, domain.ErrInvalidPageSize) {
+		t.Errorf("got error %v, want %v", err, domain.ErrInvalidPageSize)
+	}
+	if _, _, err := queryPage(domain.Page{Size: -1}); !errors.Is(err, domain.ErrInvalidPageSize) {
+		t.Errorf("got error %v, want %v", err, domain.ErrInvalidPageSize)
+	}
+}
+
+func testFilteredPagination(t *testing.T, repo domain.NodeRepo) {
+	for i := 1; i <= 6; i++ {
+		mustPut(t, repo, newTestNode(fmt.Sprintf("n%d", i), "", domain.NewStringLabel("os", "linux")))
+	}
+	dropped := map[string]bool{"n2": true, "n3": true, "n5": true}
+	filter := func(nodes []domain.Node) ([]domain.Node, error) {
+		kept := make([]domain.Node, 0, len(nodes))
+		for _, node := range nodes {
+			if !dropped[node.Id.Value] {
+				kept = append(kept, node)
+			}
+		}
+		return kept, nil
+	}
+	listPage := func(page domain.Page) ([]domain.Node, string, error) {
+		page.Filter = filter
+		return repo.ListNodePool(page)
+	}
+	queryPage := func(page domain.Page) ([]domain.Node, string, error) {
+		page.Filter = filter
+		return repo.QueryNodePool(domain.Query{{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "linux"}}, page)
+	}
+	// pages are filled past the dropped nodes and a full last page has no token
+	assertPages(t, listPage, 2, []string{"n1", "n4"}, []string{"n6"})
+	assertPages(t, listPage, 3, []string{"n1", "n4", "n6"})
+	assertPages(t, queryPage, 1, []string{"n1"}, []string{"n4"}, []string{"n6"})
+	assertPages(t, queryPage, 0, []string{"n1", "n4", "n6"})
+
+	// the limit counts the nodes left after filtering
+	topPage := func(page domain.Page) ([]domain.Node, string, error) {
+		page.Filter = filter
+		page.OrderBy = []domain.OrderBy{{LabelKey: "os"}}
+		page.Limit = 2
+		return repo.QueryNodePool(domain.Query{}, page)
+	}
+	assertPages(t, topPage, 0, []string{"n1", "n4"})
+	assertPages(t, topPage, 1, []string{"n1"}, []string{"n4"})
+
+	// a token node dropped by the filter after its page was returned still marks where the next page starts
+	_, token, err := repo.QueryNodePool(domain.Query{}, domain.Page{OrderBy: []domain.OrderBy{{LabelKey: "os"}}, Size: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes, _, err := topPage(domain.Page{Token: token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNodeIds(t, nodes, "n4")
+}
+
+func testSortedQuery(t *testing.T, repo domain.NodeRepo) {
+	withMemory := func(node domain.Node, memory float64) domain.Node {
+		node.Resources = map[string]float64{"memory": memory}
+		return node
+	}
+	mustPut(t, repo, withMemory(newTestNode("n1", "org1", domain.NewFloat64Label("price", 10), domain.NewStringLabel("os", "linux")), 16))
+	mustPut(t, repo, withMemory(newTestNode("n2", "org1", domain.NewFloat64Label("price", 9.5), domain.NewStringLabel("os", "linux")), 64))
+	mustPut(t, repo, withMemory(newTestNode("n3", "org1", domain.NewFloat64Label("price", 100), domain.NewStringLabel("os", "linux")), 32))
+	mustPut(t, repo, newTestNode("n4", "org1", domain.NewStringLabel("os", "linux")))
+	mustPut(t, repo, withMemory(newTestNode("n5", "org1", domain.NewFloat64Label("price", 9.5), domain.NewStringLabel("os", "windows")), 64))
+
+	linux := domain.Query{{LabelKey: "os", ShouldBe: domain.CompResEq, Value: "linux"}}
+	cheapest := []domain.OrderBy{{LabelKey: "price"}}
+	mostMemory := []domain.OrderBy{{Resource: "memory", Descending: true}}
+	tests := []struct {
+		query domain.Query
+		page  domain.Page
+		want  [][]string
+	}{
+		// numeric, not lexicographical order, nodes without the label last
+		{linux, domain.Page{OrderBy: cheapest}, [][]string{{"n2", "n1", "n3", "n4"}}},
+		{linux, domain.Page{OrderBy: []domain.OrderBy{{LabelKey: "price", Descending: true}}}, [][]string{{"n3", "n1", "n2", "n4"}}},
+		{linux, domain.Page{OrderBy: mostMemory, Limit: 2}, [][]string{{"n2", "n3"}}},
+		// ties are broken by the next order and then by node id
+		{domain.Query{}, domain.Page{OrderBy: cheapest}, [][]string{{"n2", "n5", "n1", "n3", "n4"}}},
+		{domain.Query{}, domain.Page{OrderBy: append(mostMemory, cheapest...)}, [][]string{{"n2", "n5", "n3", "n1", "n4"}}},
+		{domain.Query{}, domain.Page{Limit: 3}, [][]string{{"n1", "n2", "n3"}}},
+		{domain.Query{}, domain.Page{OrderBy: mostMemory, Limit: 4, Size: 3}, [][]string{{"n2", "n5", "n3"}, {"n1"}}},
+		{linux, domain.Page{OrderBy: cheapest, Size: 2}, [][]string{{"n2", "n1"}, {"n3", "n4"}}},
+	}
+	for _, tt := range tests {
+		page := tt.page
+		for i, want := range tt.want {
+			nodes, nextToken, err := repo.QueryOrgOwnedNodes(tt.query, "org1", page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, len(nodes))
+			for i, node := range nodes {
+				got[i] = node.Id.Value
+			}
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("page %d: got nodes %v, want %v", i, got, want)
+			}
+			if last := i == len(tt.want)-1; last != (nextToken == "") {
+				t.Fatalf("page %d: got next page token %q", i, nextToken)
+			}
+			page.Token = nextToken
+		}
+	}
+
+	invalid := domain.Page{OrderBy: []domain.OrderBy{{LabelKey: "price", Resource: "memory"}}}
+	if _, _, err := repo.QueryNodePool(linux, invalid); !errors.Is(err, domain.ErrInvalidQuery) {
+		t.Errorf("got error %v, want %v", err, domain.ErrInvalidQuery)
+	}
+}
+
+func assertPages(t *testing.T, list func(page domain.Page) ([]domain.Node, string, error), size int64, want ...[]string) {
+	t.Helper()
+	token := ""
+	for i, wantPage := range want {
+		nodes, nextToken, err := list(domain.Page{Size: size, Token: token})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertNodeIds(t, nodes, wantPage...)
+		if last := i == len(want)-1; last != (nextToken == "") {
+			t.Fatalf("page %d: got next page token %q", i, nextToken)
+		}
+		token = nextToken
+	}
+}
+
+func testWatch(t *testing.T, repo domain.NodeRepo) {
+	mustPut(t, repo, newTestNode("n1", "", domain.NewStringLabel("os", "linux")))
+	node, err := repo.Get(domain.NodeId{Value: "n1"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	poolEvents := watchEvents(ctx, repo, "", node.ResourceVersion)
+
+	updated, err := repo.PutLabel(*node, domain.NewBoolLabel("gpu", true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimed, err := repo.Claim(node.Id, "org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgEvents := watchEvents(ctx, repo, "org1", node.ResourceVersion)
+
+	assertEvent(t, poolEvents, domain.NodeAdded, "", node.ResourceVersion)
+	event := assertEvent(t, poolEvents, domain.NodeModified, "", updated.ResourceVersion)
+	if event.PrevNode == nil || len(event.PrevNode.Labels) != 1 || len(event.Node.Labels) != 2 {
+		t.Errorf("got modified event %+v", event)
+	}
+	assertEvent(t, poolEvents, domain.NodeDeleted, "", claimed.ResourceVersion)
+	assertEvent(t, orgEvents, domain.NodeAdded, "org1", claimed.ResourceVersion)
+
+	errStop := errors.New("stop")
+	err = repo.Watch(ctx, "", node.ResourceVersion, func(event domain.NodeEvent) error {
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("got error %v, want %v", err, errStop)
+	}
+}
+
+func watchEvents(ctx context.Context, repo domain.NodeRepo, org string, revision int64) <-chan domain.NodeEvent {
+	events := make(chan domain.NodeEvent, 16)
+	go func() {
+		_ = repo.Watch(ctx, org, revision, func(event domain.NodeEvent) error {
+			events <- event
+			return nil
+		})
+	}()
+	return events
+}
+
+func assertEvent(t *testing.T, events <-chan domain.NodeEvent, eventType domain.NodeEventType, org string, revision int64) domain.NodeEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		if event.Type != eventType || event.Node.Id.Value != "n1" || event.Node.Org != org || event.Revision != revision {
+			t.Errorf("got event %d for %s/%s at %d, want %d for %s/n1 at %d",
+				event.Type, event.Node.Org, event.Node.Id.Value, event.Revision, eventType, org, revision)
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for event %d", eventType)
+	}
+	return domain.NodeEvent{}
+}
+
+func newTestNode(id, org string, labels ...domain.Label) domain.Node {
+	return domain.Node{
+		Id:     domain.NodeId{Value: id},
+		Org:    org,
+		Labels: labels,
+	}
+}
+
+func mustPut(t *testing.T, repo domain.NodeRepo, node domain.Node) {
+	t.Helper()
+	if err := repo.Put(node); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertNode(t *testing.T, got, want domain.Node) {
+	t.Helper()
+	if got.Id != want.Id || got.Org != want.Org {
+		t.Fatalf("got node %s/%s, want %s/%s", got.Org, got.Id.Value, want.Org, want.Id.Value)
+	}
+	if labelsString(got.Labels) != labelsString(want.Labels) {
+		t.Errorf("got labels %s, want %s", labelsString(got.Labels), labelsString(want.Labels))
+	}
+}
+
+func assertNodeIds(t *testing.T, nodes []domain.Node, want ...string) {
+	t.Helper()
+	got := make([]string, len(nodes))
+	for i, node := range nodes {
+		got[i] = node.Id.Value
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got nodes %v, want %v", got, want)
+	}
+}
+
+func assertOrder(t *testing.T, nodes []domain.Node, want ...string) {
+	t.Helper()
+	got := make([]string, len(nodes))
+	for i, node := range nodes {
+		got[i] = node.Id.Value
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got nodes %v, want %v", got, want)
+	}
+}
+
+func labelsString(labels []domain.Label) string {
+	strs := make([]string, len(labels))
+	for i, label := range labels {
+		strs[i] = label.Key() + "=" + label.StringValue()
+	}
+	sort.Strings(strs)
+	return fmt.Sprint(strs)
+}
@@ -0,0 +1,295 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/c12s/magnetar/internal/domain"
+	etcd "go.etcd.io/etcd/client/v3"
+)
+
+// data model
+// key - operations/{orgId}/{operationId}
+// value - the operation, finished operations are attached to a lease so they are kept around for a while only
+// key - cancellations/{orgId}/{operationId}
+// value - time of the first cancellation request (RFC3339), the key is attached to a lease so it is kept around for a while only
+// key - unfinished_operations/{orgId}/{operationId}
+// value - empty, the key exists while the operation hasn't finished, so that unfinished operations are listed apart
+// key - operation_owners/{orgId}/{operationId}
+// value - time the ownership was taken (RFC3339), the key is attached to a lease the owner keeps alive while running the operation
+
+const (
+	operationKeyPrefix           = "operations"
+	cancellationKeyPrefix        = "cancellations"
+	unfinishedOperationKeyPrefix = "unfinished_operations"
+	operationOwnerKeyPrefix      = "operation_owners"
+	finishedOperationTTL         = 7 * 24 * time.Hour
+	// an owner that stops without releasing the operation loses it once the lease expires
+	operationOwnerTTL     = 10
+	operationOwnerTimeout = 5 * time.Second
+)
+
+type operationEtcdRepo struct {
+	etcd       *etcd.Client
+	marshaller domain.OperationMarshaller
+}
+
+func NewOperationEtcdRepo(etcd *etcd.Client, marshaller domain.OperationMarshaller) (domain.OperationRepo, error) {
+	return &operationEtcdRepo{
+		etcd:       etcd,
+		marshaller: marshaller,
+	}, nil
+}
+
+func (o operationEtcdRepo) Put(operation domain.Operation) (*domain.Operation, error) {
+	operationMarshalled, err := o.marshaller.Marshal(operation)
+	if err != nil {
+		return nil, err
+	}
+	key := operationKey(operation.Id, operation.Org)
+	opts := make([]etcd.OpOption, 0)
+	unfinishedOp := etcd.OpPut(unfinishedOperationKey(operation.Id, operation.Org), "")
+	var lease *etcd.LeaseGrantResponse
+	if operation.Finished() {
+		lease, err = o.etcd.Grant(context.TODO(), int64(finishedOperationTTL.Seconds()))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, etcd.WithLease(lease.ID))
+		unfinishedOp = etcd.OpDelete(unfinishedOperationKey(operation.Id, operation.Org))
+	}
+	cmp := etcd.Compare(etcd.ModRevision(key), "=", operation.Revision)
+	if operation.Revision == 0 {
+		cmp = etcd.Compare(etcd.CreateRevision(key), "=", 0)
+	}
+	resp, err := o.etcd.Txn(context.TODO()).
+		If(cmp).
+		Then(etcd.OpPut(key, string(operationMarshalled), opts...), unfinishedOp).
+		Commit()
+	if err == nil && !resp.Succeeded {
+		err = domain.ErrOperationModified
+	}
+	if err != nil {
+		// nothing has been attached to the lease, it would be left around until it expires otherwise
+		if lease != nil {
+			o.revoke(lease.ID)
+		}
+		return nil, err
+	}
+	operation.Revision = resp.Header.Revision
+	return &operation, nil
+}
+
+func (o operationEtcdRepo) Get(id, org string) (*domain.Operation, error) {
+	resp, err := o.etcd.Txn(context.TODO()).
+		Then(
+			etcd.OpGet(operationKey(id, org)),
+			etcd.OpGet(cancellationKey(id, org), etcd.WithKeysOnly()),
+		).
+		Commit()
+	if err != nil {
+		return nil, err
+	}
+	kvs := resp.Responses[0].GetResponseRange().Kvs
+	if len(kvs) == 0 {
+		return nil, domain.ErrOperationNotFound
+	}
+	operation, err := o.unmarshal(kvs[0].Value, kvs[0].ModRevision)
+	if err != nil {
+		return nil, err
+	}
+	operation.CancelRequested = operation.CancelRequested || len(resp.Responses[1].GetResponseRange().Kvs) > 0
+	return operation, nil
+}
+
+// List reads all pages at the revision of the first one, along with the cancellation requests
+func (o operationEtcdRepo) List(org string, page domain.Page) ([]domain.Operation, string, error) {
+	if err := page.Validate(); err != nil {
+		return nil, "", err
+	}
+	keyPrefix := fmt.Sprintf("%s/%s/", operationKeyPrefix, org)
+	token, err := decodePageToken(page.Token, keyPrefix)
+	if err != nil {
+		return nil, "", err
+	}
+	endKey := etcd.GetPrefixRangeEnd(keyPrefix)
+	revision := int64(0)
+	if token != nil {
+		endKey = token.Key
+		revision = token.Revision
+	}
+	resp, err := o.etcd.Get(context.TODO(), keyPrefix,
+		etcd.WithRange(endKey),
+		etcd.WithSort(etcd.SortByKey, etcd.SortDescend),
+		etcd.WithLimit(page.Size),
+		etcd.WithRev(revision))
+	if err != nil {
+		return nil, "", pageReadErr(err)
+	}
+	if revision == 0 {
+		revision = resp.Header.Revision
+	}
+	cancellations, err := o.etcd.Get(context.TODO(), fmt.Sprintf("%s/%s/", cancellationKeyPrefix, org), etcd.WithPrefix(), etcd.WithKeysOnly(), etcd.WithRev(revision))
+	if err != nil {
+		return nil, "", pageReadErr(err)
+	}
+	cancelled := make(map[string]bool)
+	for _, kv := range cancellations.Kvs {
+		cancelled[string(kv.Key)] = true
+	}
+	operations := make([]domain.Operation, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		operation, err := o.unmarshal(kv.Value, kv.ModRevision)
+		if err != nil {
+			return nil, "", err
+		}
+		operation.CancelRequested = operation.CancelRequested || cancelled[cancellationKey(operation.Id, operation.Org)]
+		operations = append(operations, *operation)
+	}
+	if !resp.More {
+		return operations, "", nil
+	}
+	last := operations[len(operations)-1]
+	return operations, encodePageToken(operationKey(last.Id, last.Org), revision), nil
+}
+
+// ListUnfinished reads the operations listed by the unfinished operation keys
+func (o operationEtcdRepo) ListUnfinished() ([]domain.Operation, error) {
+	keyPrefix := unfinishedOperationKeyPrefix + "/"
+	resp, err := o.etcd.Get(context.TODO(), keyPrefix, etcd.WithPrefix(), etcd.WithKeysOnly())
+	if err != nil {
+		return nil, err
+	}
+	operations := make([]domain.Operation, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		key := strings.TrimPrefix(string(kv.Key), keyPrefix)
+		separator := strings.LastIndex(key, "/")
+		if separator < 0 {
+			continue
+		}
+		operation, err := o.Get(key[separator+1:], key[:separator])
+		// finished and deleted since the keys were read
+		if errors.Is(err, domain.ErrOperationNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !operation.Finished() {
+			operations = append(operations, *operation)
+		}
+	}
+	return operations, nil
+}
+
+// HasUnfinished relies on the index alone, it is updated in the same txn as the operations
+func (o operationEtcdRepo) HasUnfinished(org string) (bool, error) {
+	resp, err := o.etcd.Get(context.TODO(), fmt.Sprintf("%s/%s/", unfinishedOperationKeyPrefix, org), etcd.WithPrefix(), etcd.WithCountOnly())
+	if err != nil {
+		return false, err
+	}
+	return resp.Count > 0, nil
+}
+
+// Cancel records the first cancellation request only, so that repeated requests don't attach the key to a new lease every time
+func (o operationEtcdRepo) Cancel(id, org string) error {
+	lease, err := o.etcd.Grant(context.TODO(), int64(finishedOperationTTL.Seconds()))
+	if err != nil {
+		return err
+	}
+	key := cancellationKey(id, org)
+	resp, err := o.etcd.Txn(context.TODO()).
+		If(etcd.Compare(etcd.CreateRevision(operationKey(id, org)), ">", 0), etcd.Compare(etcd.CreateRevision(key), "=", 0)).
+		Then(etcd.OpPut(key, time.Now().UTC().Format(time.RFC3339), etcd.WithLease(lease.ID))).
+		Else(etcd.OpGet(operationKey(id, org), etcd.WithCountOnly())).
+		Commit()
+	if err != nil {
+		o.revoke(lease.ID)
+		return err
+	}
+	if resp.Succeeded {
+		return nil
+	}
+	o.revoke(lease.ID)
+	if resp.Responses[0].GetResponseRange().Count == 0 {
+		return domain.ErrOperationNotFound
+	}
+	// already cancelled
+	return nil
+}
+
+func (o operationEtcdRepo) Own(ctx context.Context, id, org string) (context.Context, func(), error) {
+	lease, err := o.etcd.Grant(ctx, operationOwnerTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+	key := operationOwnerKey(id, org)
+	resp, err := o.etcd.Txn(ctx).
+		If(etcd.Compare(etcd.CreateRevision(key), "=", 0)).
+		Then(etcd.OpPut(key, time.Now().UTC().Format(time.RFC3339), etcd.WithLease(lease.ID))).
+		Commit()
+	if err == nil && !resp.Succeeded {
+		err = domain.ErrOperationOwned
+	}
+	if err != nil {
+		o.revoke(lease.ID)
+		return nil, nil, err
+	}
+	owned, cancel := context.WithCancel(ctx)
+	keepAlive, err := o.etcd.KeepAlive(owned, lease.ID)
+	if err != nil {
+		cancel()
+		o.revoke(lease.ID)
+		return nil, nil, err
+	}
+	go func() {
+		// closed once owned is done or the lease can't be renewed anymore
+		for range keepAlive {
+		}
+		cancel()
+	}()
+	release := func() {
+		cancel()
+		o.revoke(lease.ID)
+	}
+	return owned, release, nil
+}
+
+// revoke drops the lease and the keys attached to it right away, e.g. to end the ownership,
+// if it fails they lapse with the lease
+func (o operationEtcdRepo) revoke(leaseId etcd.LeaseID) {
+	ctx, cancel := context.WithTimeout(context.Background(), operationOwnerTimeout)
+	defer cancel()
+	if _, err := o.etcd.Revoke(ctx, leaseId); err != nil {
+		log.Println(err)
+	}
+}
+
+func (o operationEtcdRepo) unmarshal(operationMarshalled []byte, revision int64) (*domain.Operation, error) {
+	operation, err := o.marshaller.Unmarshal(operationMarshalled)
+	if err != nil {
+		return nil, err
+	}
+	operation.Revision = revision
+	return operation, nil
+}
+
+func operationKey(id, org string) string {
+	return fmt.Sprintf("%s/%s/%s", operationKeyPrefix, org, id)
+}
+
+func cancellationKey(id, org string) string {
+	return fmt.Sprintf("%s/%s/%s", cancellationKeyPrefix, org, id)
+}
+
+func unfinishedOperationKey(id, org string) string {
+	return fmt.Sprintf("%s/%s/%s", unfinishedOperationKeyPrefix, org, id)
+}
+
+func operationOwnerKey(id, org string) string {
+	return fmt.Sprintf("%s/%s/%s", operationOwnerKeyPrefix, org, id)
+}
@@ -0,0 +1,147 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/c12s/magnetar/internal/domain"
+	"golang.org/x/exp/slices"
+)
+
+type operationInMemRepo struct {
+	operations map[string]domain.Operation
+	cancelled  map[string]bool
+	owned      map[string]bool
+	revision   int64
+	mu         sync.RWMutex
+}
+
+func NewOperationInMemRepo() (domain.OperationRepo, error) {
+	return &operationInMemRepo{
+		operations: make(map[string]domain.Operation),
+		cancelled:  make(map[string]bool),
+		owned:      make(map[string]bool),
+	}, nil
+}
+
+func (o *operationInMemRepo) Put(operation domain.Operation) (*domain.Operation, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	key := operationKey(operation.Id, operation.Org)
+	if stored, ok := o.operations[key]; (ok && stored.Revision != operation.Revision) || (!ok && operation.Revision != 0) {
+		return nil, domain.ErrOperationModified
+	}
+	o.revision++
+	operation.Revision = o.revision
+	operation.Nodes = slices.Clone(operation.Nodes)
+	o.operations[key] = operation
+	return &operation, nil
+}
+
+func (o *operationInMemRepo) Get(id, org string) (*domain.Operation, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	operation, ok := o.operations[operationKey(id, org)]
+	if !ok {
+		return nil, domain.ErrOperationNotFound
+	}
+	operation = o.copy(operation)
+	return &operation, nil
+}
+
+func (o *operationInMemRepo) List(org string, page domain.Page) ([]domain.Operation, string, error) {
+	if err := page.Validate(); err != nil {
+		return nil, "", err
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	keyPrefix := fmt.Sprintf("%s/%s/", operationKeyPrefix, org)
+	token, err := decodePageToken(page.Token, keyPrefix)
+	if err != nil {
+		return nil, "", err
+	}
+	keys := make([]string, 0)
+	for key := range o.operations {
+		if strings.HasPrefix(key, keyPrefix) && (token == nil || key < token.Key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	more := page.Size > 0 && int64(len(keys)) > page.Size
+	if more {
+		keys = keys[:page.Size]
+	}
+	operations := make([]domain.Operation, 0, len(keys))
+	for _, key := range keys {
+		operations = append(operations, o.copy(o.operations[key]))
+	}
+	if !more {
+		return operations, "", nil
+	}
+	return operations, encodePageToken(keys[len(keys)-1], o.revision), nil
+}
+
+func (o *operationInMemRepo) ListUnfinished() ([]domain.Operation, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	operations := make([]domain.Operation, 0)
+	for _, operation := range o.operations {
+		if !operation.Finished() {
+			operations = append(operations, o.copy(operation))
+		}
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].Id < operations[j].Id
+	})
+	return operations, nil
+}
+
+func (o *operationInMemRepo) HasUnfinished(org string) (bool, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	for _, operation := range o.operations {
+		if operation.Org == org && !operation.Finished() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (o *operationInMemRepo) Cancel(id, org string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	key := operationKey(id, org)
+	if _, ok := o.operations[key]; !ok {
+		return domain.ErrOperationNotFound
+	}
+	o.cancelled[key] = true
+	return nil
+}
+
+// Own keeps the ownership until it is released, there is no owner that could stop without releasing it
+func (o *operationInMemRepo) Own(ctx context.Context, id, org string) (context.Context, func(), error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	key := operationKey(id, org)
+	if o.owned[key] {
+		return nil, nil, domain.ErrOperationOwned
+	}
+	o.owned[key] = true
+	owned, cancel := context.WithCancel(ctx)
+	release := func() {
+		cancel()
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		delete(o.owned, key)
+	}
+	return owned, release, nil
+}
+
+func (o *operationInMemRepo) copy(operation domain.Operation) domain.Operation {
+	operation.Nodes = slices.Clone(operation.Nodes)
+	operation.CancelRequested = operation.CancelRequested || o.cancelled[operationKey(operation.Id, operation.Org)]
+	return operation
+}
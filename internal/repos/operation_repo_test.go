@@ -0,0 +1,264 @@
+package repos_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/c12s/magnetar/internal/domain"
+	"github.com/c12s/magnetar/internal/marshallers/proto"
+	"github.com/c12s/magnetar/internal/repos"
+	etcd "go.etcd.io/etcd/client/v3"
+)
+
+func TestOperationInMemRepo(t *testing.T) {
+	repo, err := repos.NewOperationInMemRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testOperationRepo(t, repo)
+}
+
+// requires a running etcd instance, e.g. ETCD_ADDRESS=localhost:2379
+func TestOperationEtcdRepo(t *testing.T) {
+	address := os.Getenv("ETCD_ADDRESS")
+	if address == "" {
+		t.Skip("ETCD_ADDRESS not set")
+	}
+	client, err := etcd.New(etcd.Config{
+		Endpoints: []string{fmt.Sprintf("http://%s", address)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	for _, prefix := range []string{"operations/", "cancellations/", "unfinished_operations/", "operation_owners/"} {
+		if _, err := client.Delete(context.TODO(), prefix, etcd.WithPrefix()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo, err := repos.NewOperationEtcdRepo(client, proto.NewProtoOperationMarshaller())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testOperationRepo(t, repo)
+
+	// leases that end up without keys are revoked instead of being kept until they expire
+	t.Run("LeasesRevoked", func(t *testing.T) {
+		leases := func() int {
+			resp, err := client.Leases(context.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+			return len(resp.Leases)
+		}
+		operation, err := repo.Put(domain.Operation{Id: "op5", Org: "org1", Kind: domain.OperationClaim, State: domain.OperationRunning})
+		if err != nil {
+			t.Fatal(err)
+		}
+		before := leases()
+		stale := *operation
+		stale.Revision--
+		stale.State = domain.OperationSucceeded
+		if _, err := repo.Put(stale); !errors.Is(err, domain.ErrOperationModified) {
+			t.Fatalf("expected ErrOperationModified, got %v", err)
+		}
+		for i := 0; i < 3; i++ {
+			if err := repo.Cancel("op5", "org1"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := repo.Cancel("missing", "org1"); !errors.Is(err, domain.ErrOperationNotFound) {
+			t.Errorf("expected ErrOperationNotFound, got %v", err)
+		}
+		if got := leases(); got != before+1 {
+			t.Errorf("expected a single lease for the cancellation, got %d new leases", got-before)
+		}
+	})
+}
+
+func testOperationRepo(t *testing.T, repo domain.OperationRepo) {
+	now := time.Now().UTC().Truncate(time.Second)
+	operation := domain.Operation{
+		Id:    "op1",
+		Org:   "org1",
+		Kind:  domain.OperationClaim,
+		State: domain.OperationRunning,
+		Nodes: []domain.OperationNode{
+			{NodeId: domain.NodeId{Value: "n1"}},
+			{NodeId: domain.NodeId{Value: "n2"}},
+		},
+		Requirements: domain.ClaimRequirements{
+			Count:     2,
+			Resources: map[string]float64{"cpu": 4},
+			Strategy:  domain.ClaimBestFit,
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	created, err := repo.Put(operation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Put(operation); !errors.Is(err, domain.ErrOperationModified) {
+		t.Errorf("expected a second create to fail, got %v", err)
+	}
+
+	updated := *created
+	updated.Step = domain.OperationStepClaim
+	updated.Nodes[0].Step = domain.OperationStepClaim
+	updated.Nodes[0].Status = domain.NodeOperationClaimed
+	updated.Nodes[1].Status = domain.NodeOperationFailed
+	updated.Nodes[1].Attempts = 1
+	updated.Nodes[1].Err = domain.ErrNodeClaimed.Error()
+	if _, err := repo.Put(updated); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Put(*created); !errors.Is(err, domain.ErrOperationModified) {
+		t.Errorf("expected an update of a stale operation to fail, got %v", err)
+	}
+
+	got, err := repo.Get("op1", "org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Kind != domain.OperationClaim || got.Step != domain.OperationStepClaim || got.Requirements.Strategy != domain.ClaimBestFit ||
+		got.Requirements.Count != 2 || got.Requirements.Resources["cpu"] != 4 || !got.CreatedAt.Equal(now) || got.CancelRequested {
+		t.Errorf("unexpected operation %+v", got)
+	}
+	if fmt.Sprint(got.Nodes) != fmt.Sprint(updated.Nodes) {
+		t.Errorf("got nodes %v, want %v", got.Nodes, updated.Nodes)
+	}
+	if _, err := repo.Get("op1", "org2"); !errors.Is(err, domain.ErrOperationNotFound) {
+		t.Errorf("expected operations of other orgs not to be found, got %v", err)
+	}
+
+	// cancelling doesn't conflict with updates of the operation
+	if err := repo.Cancel("op1", "org1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Cancel("missing", "org1"); !errors.Is(err, domain.ErrOperationNotFound) {
+		t.Errorf("expected ErrOperationNotFound, got %v", err)
+	}
+	got.State = domain.OperationCancelled
+	if _, err := repo.Put(*got); err != nil {
+		t.Fatal(err)
+	}
+
+	later := domain.Operation{
+		Id:        "op2",
+		Org:       "org1",
+		Kind:      domain.OperationRelease,
+		State:     domain.OperationRunning,
+		CreatedAt: now.Add(time.Second),
+		UpdatedAt: now.Add(time.Second),
+	}
+	if _, err := repo.Put(later); err != nil {
+		t.Fatal(err)
+	}
+	operations, _, err := repo.List("org1", domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(operations) != 2 || operations[0].Id != "op2" || operations[1].Id != "op1" || !operations[1].CancelRequested {
+		t.Errorf("unexpected operations %+v", operations)
+	}
+	unfinished, err := repo.ListUnfinished()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unfinished) != 1 || unfinished[0].Id != "op2" {
+		t.Errorf("expected op2 to be unfinished, got %v", unfinished)
+	}
+	for org, want := range map[string]bool{"org1": true, "org2": false} {
+		if busy, err := repo.HasUnfinished(org); err != nil || busy != want {
+			t.Errorf("got %s unfinished %t, %v, want %t", org, busy, err, want)
+		}
+	}
+
+	t.Run("Pagination", func(t *testing.T) {
+		if _, err := repo.Put(domain.Operation{Id: "op3", Org: "org1", Kind: domain.OperationRelease, State: domain.OperationSucceeded}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.Put(domain.Operation{Id: "op4", Org: "org2", Kind: domain.OperationRelease}); err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]string, 0)
+		page := domain.Page{Size: 2}
+		for pages := 0; ; pages++ {
+			if pages == 3 {
+				t.Fatal("too many pages")
+			}
+			operations, nextPageToken, err := repo.List("org1", page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, operation := range operations {
+				ids = append(ids, operation.Id)
+			}
+			if nextPageToken == "" {
+				break
+			}
+			page.Token = nextPageToken
+		}
+		if fmt.Sprint(ids) != "[op3 op2 op1]" {
+			t.Errorf("got operations %v, want [op3 op2 op1]", ids)
+		}
+		if _, _, err := repo.List("org2", page); !errors.Is(err, domain.ErrInvalidPageToken) {
+			t.Errorf("expected a token of another org to be rejected, got %v", err)
+		}
+		if _, _, err := repo.List("org1", domain.Page{Size: -1}); !errors.Is(err, domain.ErrInvalidPageSize) {
+			t.Errorf("expected ErrInvalidPageSize, got %v", err)
+		}
+	})
+
+	t.Run("Own", func(t *testing.T) {
+		owned, release, err := repo.Own(context.Background(), "op1", "org1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := repo.Own(context.Background(), "op1", "org1"); !errors.Is(err, domain.ErrOperationOwned) {
+			t.Errorf("expected ErrOperationOwned, got %v", err)
+		}
+		_, releaseOther, err := repo.Own(context.Background(), "op1", "org2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		releaseOther()
+		release()
+		if owned.Err() == nil {
+			t.Error("expected the owned context to be done once released")
+		}
+		_, release, err = repo.Own(context.Background(), "op1", "org1")
+		if err != nil {
+			t.Fatalf("expected a released operation to be owned again, got %v", err)
+		}
+		release()
+	})
+
+	t.Run("UnfinishedOnceFinished", func(t *testing.T) {
+		op2, err := repo.Get("op2", "org1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		op2.State = domain.OperationSucceeded
+		if _, err := repo.Put(*op2); err != nil {
+			t.Fatal(err)
+		}
+		unfinished, err := repo.ListUnfinished()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(unfinished) != 1 || unfinished[0].Id != "op4" {
+			t.Errorf("expected op4 to be the only unfinished operation, got %v", unfinished)
+		}
+		if busy, err := repo.HasUnfinished("org1"); err != nil || busy {
+			t.Errorf("expected org1 to have no unfinished operations, got %t, %v", busy, err)
+		}
+	})
+}
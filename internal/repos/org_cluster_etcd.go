@@ -0,0 +1,85 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/c12s/magnetar/internal/domain"
+	etcd "go.etcd.io/etcd/client/v3"
+)
+
+// data model
+// key - clusters/{orgId}
+// value - what oort, meridian and gravity last confirmed for the org
+
+const orgClusterKeyPrefix = "clusters"
+
+type orgClusterEtcdRepo struct {
+	etcd       *etcd.Client
+	marshaller domain.OrgClusterMarshaller
+}
+
+func NewOrgClusterEtcdRepo(etcd *etcd.Client, marshaller domain.OrgClusterMarshaller) (domain.OrgClusterRepo, error) {
+	return &orgClusterEtcdRepo{
+		etcd:       etcd,
+		marshaller: marshaller,
+	}, nil
+}
+
+func (o orgClusterEtcdRepo) Get(org string) (*domain.OrgCluster, error) {
+	resp, err := o.etcd.Get(context.TODO(), orgClusterKey(org))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return &domain.OrgCluster{Org: org}, nil
+	}
+	cluster, err := o.marshaller.Unmarshal(resp.Kvs[0].Value)
+	if err != nil {
+		return nil, err
+	}
+	cluster.Revision = resp.Kvs[0].ModRevision
+	return cluster, nil
+}
+
+func (o orgClusterEtcdRepo) Put(cluster domain.OrgCluster) (*domain.OrgCluster, error) {
+	clusterMarshalled, err := o.marshaller.Marshal(cluster)
+	if err != nil {
+		return nil, err
+	}
+	key := orgClusterKey(cluster.Org)
+	cmp := etcd.Compare(etcd.ModRevision(key), "=", cluster.Revision)
+	if cluster.Revision == 0 {
+		cmp = etcd.Compare(etcd.CreateRevision(key), "=", 0)
+	}
+	resp, err := o.etcd.Txn(context.TODO()).
+		If(cmp).
+		Then(etcd.OpPut(key, string(clusterMarshalled))).
+		Commit()
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Succeeded {
+		return nil, domain.ErrOrgClusterModified
+	}
+	cluster.Revision = resp.Header.Revision
+	return &cluster, nil
+}
+
+func (o orgClusterEtcdRepo) ListOrgs() ([]string, error) {
+	prefix := orgClusterKeyPrefix + "/"
+	resp, err := o.etcd.Get(context.TODO(), prefix, etcd.WithPrefix(), etcd.WithKeysOnly())
+	if err != nil {
+		return nil, err
+	}
+	orgs := make([]string, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		orgs = append(orgs, strings.TrimPrefix(string(kv.Key), prefix))
+	}
+	return orgs, nil
+}
+
+func orgClusterKey(org string) string {
+	return fmt.Sprintf("%s/%s", orgClusterKeyPrefix, org)
+}
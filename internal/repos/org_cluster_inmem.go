@@ -0,0 +1,63 @@
+package repos
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/c12s/magnetar/internal/domain"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+type orgClusterInMemRepo struct {
+	clusters map[string]domain.OrgCluster
+	revision int64
+	mu       sync.RWMutex
+}
+
+func NewOrgClusterInMemRepo() (domain.OrgClusterRepo, error) {
+	return &orgClusterInMemRepo{
+		clusters: make(map[string]domain.OrgCluster),
+	}, nil
+}
+
+func (o *orgClusterInMemRepo) Get(org string) (*domain.OrgCluster, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	cluster, ok := o.clusters[org]
+	if !ok {
+		return &domain.OrgCluster{Org: org}, nil
+	}
+	cluster = copyOrgCluster(cluster)
+	return &cluster, nil
+}
+
+func (o *orgClusterInMemRepo) Put(cluster domain.OrgCluster) (*domain.OrgCluster, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if stored, ok := o.clusters[cluster.Org]; (ok && stored.Revision != cluster.Revision) || (!ok && cluster.Revision != 0) {
+		return nil, domain.ErrOrgClusterModified
+	}
+	o.revision++
+	cluster.Revision = o.revision
+	cluster = copyOrgCluster(cluster)
+	o.clusters[cluster.Org] = cluster
+	return &cluster, nil
+}
+
+func (o *orgClusterInMemRepo) ListOrgs() ([]string, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	orgs := maps.Keys(o.clusters)
+	sort.Strings(orgs)
+	return orgs, nil
+}
+
+func copyOrgCluster(cluster domain.OrgCluster) domain.OrgCluster {
+	if cluster.Quotas != nil {
+		cluster.Quotas = maps.Clone(cluster.Quotas)
+	}
+	cluster.Related = slices.Clone(cluster.Related)
+	cluster.Joined = slices.Clone(cluster.Joined)
+	return cluster
+}
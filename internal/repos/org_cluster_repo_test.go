@@ -0,0 +1,115 @@
+package repos_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/c12s/magnetar/internal/domain"
+	"github.com/c12s/magnetar/internal/marshallers/proto"
+	"github.com/c12s/magnetar/internal/repos"
+	etcd "go.etcd.io/etcd/client/v3"
+)
+
+func TestOrgClusterInMemRepo(t *testing.T) {
+	repo, err := repos.NewOrgClusterInMemRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testOrgClusterRepo(t, repo)
+}
+
+// requires a running etcd instance, e.g. ETCD_ADDRESS=localhost:2379
+func TestOrgClusterEtcdRepo(t *testing.T) {
+	address := os.Getenv("ETCD_ADDRESS")
+	if address == "" {
+		t.Skip("ETCD_ADDRESS not set")
+	}
+	client, err := etcd.New(etcd.Config{
+		Endpoints: []string{fmt.Sprintf("http://%s", address)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	if _, err := client.Delete(context.TODO(), "clusters/", etcd.WithPrefix()); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := repos.NewOrgClusterEtcdRepo(client, proto.NewProtoOrgClusterMarshaller())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testOrgClusterRepo(t, repo)
+}
+
+func testOrgClusterRepo(t *testing.T, repo domain.OrgClusterRepo) {
+	cluster, err := repo.Get("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cluster.Org != "org1" || cluster.Revision != 0 || cluster.Quotas != nil || len(cluster.Related) > 0 {
+		t.Errorf("expected an empty record, got %+v", cluster)
+	}
+
+	cluster.SetRelated(domain.NodeId{Value: "n1"}, true)
+	cluster.SetJoined(domain.NodeId{Value: "n1"}, true)
+	cluster.Quotas = map[string]float64{}
+	created, err := repo.Put(*cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Put(*cluster); !errors.Is(err, domain.ErrOrgClusterModified) {
+		t.Errorf("expected a second create to fail, got %v", err)
+	}
+
+	created.SetRelated(domain.NodeId{Value: "n2"}, true)
+	created.SetJoined(domain.NodeId{Value: "n1"}, false)
+	created.Quotas = map[string]float64{"cpu": 4}
+	updated, err := repo.Put(*created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Put(*created); !errors.Is(err, domain.ErrOrgClusterModified) {
+		t.Errorf("expected an update of a stale record to fail, got %v", err)
+	}
+
+	stored, err := repo.Get("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Revision != updated.Revision {
+		t.Errorf("expected revision %d, got %d", updated.Revision, stored.Revision)
+	}
+	if !stored.IsRelated(domain.NodeId{Value: "n1"}) || !stored.IsRelated(domain.NodeId{Value: "n2"}) || len(stored.Related) != 2 {
+		t.Errorf("expected n1 and n2 to be related, got %v", stored.Related)
+	}
+	if len(stored.Joined) != 0 {
+		t.Errorf("expected no joined nodes, got %v", stored.Joined)
+	}
+	if !reflect.DeepEqual(stored.Quotas, map[string]float64{"cpu": 4}) {
+		t.Errorf("expected quotas cpu=4, got %v", stored.Quotas)
+	}
+
+	if _, err := repo.Put(domain.OrgCluster{Org: "org2", Quotas: map[string]float64{}}); err != nil {
+		t.Fatal(err)
+	}
+	empty, err := repo.Get("org2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty.Quotas == nil {
+		t.Error("expected empty quotas that have been set to be kept apart from unset ones")
+	}
+	orgs, err := repo.ListOrgs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(orgs, []string{"org1", "org2"}) {
+		t.Errorf("expected orgs [org1 org2], got %v", orgs)
+	}
+}
@@ -0,0 +1,131 @@
+package repos
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/c12s/magnetar/internal/domain"
+	"golang.org/x/exp/slices"
+)
+
+// page tokens are opaque to clients,
+// they hold the last returned key and the revision the first page was read at
+
+type pageToken struct {
+	Key      string `json:"key"`
+	Revision int64  `json:"rev"`
+}
+
+func encodePageToken(key string, revision int64) string {
+	tokenMarshalled, _ := json.Marshal(pageToken{
+		Key:      key,
+		Revision: revision,
+	})
+	return base64.RawURLEncoding.EncodeToString(tokenMarshalled)
+}
+
+// decodePageToken returns nil for an empty token,
+// tokens issued for a different key range are rejected
+func decodePageToken(token, keyPrefix string) (*pageToken, error) {
+	if token == "" {
+		return nil, nil
+	}
+	tokenMarshalled, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, domain.ErrInvalidPageToken
+	}
+	decoded := &pageToken{}
+	err = json.Unmarshal(tokenMarshalled, decoded)
+	if err != nil || decoded.Revision <= 0 || !strings.HasPrefix(decoded.Key, keyPrefix) {
+		return nil, domain.ErrInvalidPageToken
+	}
+	return decoded, nil
+}
+
+// nodeIdsAfterToken orders node ids by their get model keys and returns the ones following the token
+func nodeIdsAfterToken(nodeIds []domain.NodeId, org string, token *pageToken) []domain.NodeId {
+	keys := make(map[domain.NodeId]string, len(nodeIds))
+	for _, nodeId := range nodeIds {
+		keys[nodeId] = getKey(domain.Node{Id: nodeId, Org: org})
+	}
+	sorted := make([]domain.NodeId, 0, len(nodeIds))
+	for _, nodeId := range nodeIds {
+		if token == nil || keys[nodeId] > token.Key {
+			sorted = append(sorted, nodeId)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return keys[sorted[i]] < keys[sorted[j]]
+	})
+	return sorted
+}
+
+// fillPage reads the nodes following the token batch by batch until the page is full,
+// read is given the number of nodes to read, zero meaning all of them, and reports whether any are left,
+// nodes dropped by the page filter are read past, and one node more than the page size is read,
+// so that the page is only followed by a token if there are nodes left to return
+func fillPage(page domain.Page, read func(limit int64) ([]domain.Node, bool, error)) ([]domain.Node, bool, error) {
+	nodes := make([]domain.Node, 0)
+	for {
+		limit := int64(0)
+		if page.Size > 0 {
+			limit = page.Size - int64(len(nodes)) + 1
+		}
+		batch, more, err := read(limit)
+		if err != nil {
+			return nil, false, err
+		}
+		batch, err = filterNodes(batch, page)
+		if err != nil {
+			return nil, false, err
+		}
+		nodes = append(nodes, batch...)
+		if page.Size > 0 && int64(len(nodes)) > page.Size {
+			return nodes[:page.Size], true, nil
+		}
+		if !more {
+			return nodes, false, nil
+		}
+	}
+}
+
+func filterNodes(nodes []domain.Node, page domain.Page) ([]domain.Node, error) {
+	if page.Filter == nil {
+		return nodes, nil
+	}
+	return page.Filter(nodes)
+}
+
+// pageSortedNodes filters the sorted nodes, caps them at the limit and returns the ones following the token
+// in the order they are given in, along with whether any are left,
+// the token node is looked up before filtering, since it may be dropped by the filter after its page was returned
+func pageSortedNodes(nodes []domain.Node, token *pageToken, page domain.Page) ([]domain.Node, bool, error) {
+	positions := make(map[string]int, len(nodes))
+	for i, node := range nodes {
+		positions[getKey(node)] = i
+	}
+	last := -1
+	if token != nil {
+		position, ok := positions[token.Key]
+		if !ok {
+			return nil, false, domain.ErrInvalidPageToken
+		}
+		last = position
+	}
+	nodes, err := filterNodes(nodes, page)
+	if err != nil {
+		return nil, false, err
+	}
+	if page.Limit > 0 && int64(len(nodes)) > page.Limit {
+		nodes = nodes[:page.Limit]
+	}
+	nodes = slices.DeleteFunc(nodes, func(node domain.Node) bool {
+		return positions[getKey(node)] <= last
+	})
+	if page.Size <= 0 || int64(len(nodes)) <= page.Size {
+		return nodes, false, nil
+	}
+	return nodes[:page.Size], true, nil
+}
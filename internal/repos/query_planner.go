@@ -0,0 +1,99 @@
+package repos
+
+import (
+	"math"
+	"sort"
+
+	"github.com/c12s/magnetar/internal/domain"
+)
+
+// the query planner orders the selectors of a conjunction by the number of index keys they have to read,
+// so the most selective one is evaluated first and the candidate set only shrinks from there
+// once there are fewer candidates than index keys the next selector would read,
+// the remaining selectors are matched against the candidate nodes instead
+
+// unbounded is the estimate of selectors that can only be answered by listing the whole pool or org
+const unbounded = math.MaxInt64
+
+type plannedSelector struct {
+	selector domain.Selector
+	estimate int64
+}
+
+// estimateRanges collects the index ranges of all selectors that have to be counted to estimate the query
+func estimateRanges(query domain.Query, keyPrefix string) []keyRange {
+	ranges := make([]keyRange, 0)
+	for _, selector := range query {
+		if selector.Group != nil {
+			ranges = append(ranges, estimateRanges(selector.Group.Selectors, keyPrefix)...)
+			continue
+		}
+		if selector.ShouldBe == domain.CompResDoesNotExist {
+			continue
+		}
+		selectorRanges, _ := labelIndexRanges(selector, selectorIndexPrefix(keyPrefix, selector))
+		ranges = append(ranges, selectorRanges...)
+	}
+	return ranges
+}
+
+// planQuery orders the selectors by their estimates, ties keep the request order
+func planQuery(query domain.Query, keyPrefix string, counts map[keyRange]int64) []plannedSelector {
+	plan := make([]plannedSelector, len(query))
+	for i, selector := range query {
+		plan[i] = plannedSelector{
+			selector: selector,
+			estimate: estimateSelector(selector, keyPrefix, counts),
+		}
+	}
+	sort.SliceStable(plan, func(i, j int) bool {
+		return plan[i].estimate < plan[j].estimate
+	})
+	return plan
+}
+
+// estimateSelector returns an upper bound of the index keys the selector reads
+func estimateSelector(selector domain.Selector, keyPrefix string, counts map[keyRange]int64) int64 {
+	if selector.Group != nil {
+		switch selector.Group.Op {
+		case domain.GroupOr:
+			sum := int64(0)
+			for _, s := range selector.Group.Selectors {
+				estimate := estimateSelector(s, keyPrefix, counts)
+				if estimate == unbounded {
+					return unbounded
+				}
+				sum += estimate
+			}
+			return sum
+		case domain.GroupAnd:
+			lowest := int64(unbounded)
+			for _, s := range selector.Group.Selectors {
+				lowest = min(lowest, estimateSelector(s, keyPrefix, counts))
+			}
+			return lowest
+		default:
+			return unbounded
+		}
+	}
+	if selector.ShouldBe == domain.CompResDoesNotExist {
+		return unbounded
+	}
+	ranges, _ := labelIndexRanges(selector, selectorIndexPrefix(keyPrefix, selector))
+	sum := int64(0)
+	for _, r := range ranges {
+		sum += counts[r]
+	}
+	return sum
+}
+
+// matchingNodeIds returns the ids of nodes matching all selectors
+func matchingNodeIds(nodes []domain.Node, query domain.Query) []domain.NodeId {
+	nodeIds := make([]domain.NodeId, 0, len(nodes))
+	for _, node := range nodes {
+		if query.Matches(node) {
+			nodeIds = append(nodeIds, node.Id)
+		}
+	}
+	return nodeIds
+}
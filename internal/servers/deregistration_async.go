@@ -0,0 +1,52 @@
+package servers
+
+import (
+	"context"
+	"log"
+
+	"github.com/c12s/magnetar/internal/mappers/proto"
+	"github.com/c12s/magnetar/internal/services"
+	"github.com/c12s/magnetar/pkg/api"
+	"github.com/c12s/magnetar/pkg/messaging"
+)
+
+type DeregistrationAsyncServer struct {
+	subscriber messaging.Subscriber
+	service    services.NodeService
+}
+
+func NewDeregistrationAsyncServer(subscriber messaging.Subscriber, service services.NodeService) (*DeregistrationAsyncServer, error) {
+	return &DeregistrationAsyncServer{
+		subscriber: subscriber,
+		service:    service,
+	}, nil
+}
+
+func (d *DeregistrationAsyncServer) Serve() error {
+	return d.subscriber.Subscribe(d.deregister)
+}
+
+func (d *DeregistrationAsyncServer) deregister(msg []byte, _ string) {
+	reqProto := &api.DeregistrationReq{}
+	err := reqProto.Unmarshal(msg)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	req, err := proto.DeregistrationReqToDomain(reqProto)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	err = d.service.Deregister(context.Background(), *req)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (d *DeregistrationAsyncServer) GracefulStop() {
+	err := d.subscriber.Unsubscribe()
+	if err != nil {
+		log.Println(err)
+	}
+}
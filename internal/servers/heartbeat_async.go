@@ -0,0 +1,51 @@
+package servers
+
+import (
+	"log"
+
+	"github.com/c12s/magnetar/internal/mappers/proto"
+	"github.com/c12s/magnetar/internal/services"
+	"github.com/c12s/magnetar/pkg/api"
+	"github.com/c12s/magnetar/pkg/messaging"
+)
+
+type HeartbeatAsyncServer struct {
+	subscriber messaging.Subscriber
+	service    services.LivenessService
+}
+
+func NewHeartbeatAsyncServer(subscriber messaging.Subscriber, service services.LivenessService) (*HeartbeatAsyncServer, error) {
+	return &HeartbeatAsyncServer{
+		subscriber: subscriber,
+		service:    service,
+	}, nil
+}
+
+func (h *HeartbeatAsyncServer) Serve() error {
+	return h.subscriber.Subscribe(h.heartbeat)
+}
+
+func (h *HeartbeatAsyncServer) heartbeat(msg []byte, _ string) {
+	reqProto := &api.HeartbeatReq{}
+	err := reqProto.Unmarshal(msg)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	req, err := proto.HeartbeatReqToDomain(reqProto)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	err = h.service.Heartbeat(*req)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *HeartbeatAsyncServer) GracefulStop() {
+	err := h.subscriber.Unsubscribe()
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -60,6 +60,9 @@ func (m *MagnetarGrpcServer) GetFromOrg(ctx context.Context, req *api.GetFromOrg
 func (m *MagnetarGrpcServer) ClaimOwnership(ctx context.Context, req *api.ClaimOwnershipReq) (*api.ClaimOwnershipResp, error) {
 	domainReq, err := proto.ClaimOwnershipReqToDomain(req)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
 	domainResp, err := m.nodeService.ClaimOwnership(ctx, *domainReq)
@@ -67,11 +70,140 @@ func (m *MagnetarGrpcServer) ClaimOwnership(ctx context.Context, req *api.ClaimO
 		if errors.Is(err, domain.ErrForbidden) {
 			return nil, status.Error(codes.PermissionDenied, err.Error())
 		}
+		if errors.Is(err, domain.ErrInsufficientNodes) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, domain.ErrShuttingDown) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
 		return nil, err
 	}
 	return proto.ClaimOwnershipRespFromDomain(*domainResp)
 }
 
+func (m *MagnetarGrpcServer) ReleaseNodes(ctx context.Context, req *api.ReleaseNodesReq) (*api.ReleaseNodesResp, error) {
+	domainReq, err := proto.ReleaseNodesReqToDomain(req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+	domainResp, err := m.nodeService.ReleaseNodes(ctx, *domainReq)
+	if err != nil {
+		if errors.Is(err, domain.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, domain.ErrNodeNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, domain.ErrShuttingDown) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		return nil, err
+	}
+	return proto.ReleaseNodesRespFromDomain(*domainResp)
+}
+
+func (m *MagnetarGrpcServer) GetOperation(ctx context.Context, req *api.GetOperationReq) (*api.GetOperationResp, error) {
+	domainReq, err := proto.GetOperationReqToDomain(req)
+	if err != nil {
+		return nil, err
+	}
+	domainResp, err := m.nodeService.GetOperation(ctx, *domainReq)
+	if err != nil {
+		if errors.Is(err, domain.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrOperationNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+	return proto.GetOperationRespFromDomain(*domainResp)
+}
+
+func (m *MagnetarGrpcServer) ListOperations(ctx context.Context, req *api.ListOperationsReq) (*api.ListOperationsResp, error) {
+	domainReq, err := proto.ListOperationsReqToDomain(req)
+	if err != nil {
+		return nil, err
+	}
+	domainResp, err := m.nodeService.ListOperations(ctx, *domainReq)
+	if err != nil {
+		if errors.Is(err, domain.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrInvalidPageToken) || errors.Is(err, domain.ErrInvalidPageSize) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+	return proto.ListOperationsRespFromDomain(*domainResp)
+}
+
+func (m *MagnetarGrpcServer) CancelOperation(ctx context.Context, req *api.CancelOperationReq) (*api.CancelOperationResp, error) {
+	domainReq, err := proto.CancelOperationReqToDomain(req)
+	if err != nil {
+		return nil, err
+	}
+	domainResp, err := m.nodeService.CancelOperation(ctx, *domainReq)
+	if err != nil {
+		if errors.Is(err, domain.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrOperationNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, domain.ErrOperationFinished) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, err
+	}
+	return proto.CancelOperationRespFromDomain(*domainResp)
+}
+
+func (m *MagnetarGrpcServer) GetClaimSaga(ctx context.Context, req *api.GetClaimSagaReq) (*api.GetClaimSagaResp, error) {
+	domainReq, err := proto.GetClaimSagaReqToDomain(req)
+	if err != nil {
+		return nil, err
+	}
+	domainResp, err := m.nodeService.GetClaimSaga(ctx, *domainReq)
+	if err != nil {
+		if errors.Is(err, domain.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrOperationNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+	return proto.GetClaimSagaRespFromDomain(*domainResp)
+}
+
+func (m *MagnetarGrpcServer) DeregisterNode(ctx context.Context, req *api.DeregisterNodeReq) (*api.DeregisterNodeResp, error) {
+	domainReq, err := proto.DeregisterNodeReqToDomain(req)
+	if err != nil {
+		return nil, err
+	}
+	domainResp, err := m.nodeService.DeregisterNode(ctx, *domainReq)
+	if err != nil {
+		if errors.Is(err, domain.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrNodeNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, domain.ErrResourceVersionMismatch) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		return nil, err
+	}
+	return proto.DeregisterNodeRespFromDomain(*domainResp)
+}
+
 func (m *MagnetarGrpcServer) ListNodePool(ctx context.Context, req *api.ListNodePoolReq) (*api.ListNodePoolResp, error) {
 	domainReq, err := proto.ListNodePoolReqToDomain(req)
 	if err != nil {
@@ -82,6 +214,9 @@ func (m *MagnetarGrpcServer) ListNodePool(ctx context.Context, req *api.ListNode
 		if errors.Is(err, domain.ErrForbidden) {
 			return nil, status.Error(codes.PermissionDenied, err.Error())
 		}
+		if errors.Is(err, domain.ErrInvalidPageToken) || errors.Is(err, domain.ErrInvalidPageSize) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
 	return proto.ListNodePoolRespFromDomain(*domainResp)
@@ -97,6 +232,9 @@ func (m *MagnetarGrpcServer) ListOrgOwnedNodes(ctx context.Context, req *api.Lis
 		if errors.Is(err, domain.ErrForbidden) {
 			return nil, status.Error(codes.PermissionDenied, err.Error())
 		}
+		if errors.Is(err, domain.ErrInvalidPageToken) || errors.Is(err, domain.ErrInvalidPageSize) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
 	return proto.ListOrgOwnedNodesRespFromDomain(*domainResp)
@@ -105,6 +243,9 @@ func (m *MagnetarGrpcServer) ListOrgOwnedNodes(ctx context.Context, req *api.Lis
 func (m *MagnetarGrpcServer) QueryNodePool(ctx context.Context, req *api.QueryNodePoolReq) (*api.QueryNodePoolResp, error) {
 	domainReq, err := proto.QueryNodePoolReqToDomain(req)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) || errors.Is(err, domain.ErrInvalidPageSize) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
 	domainResp, err := m.nodeService.QueryNodePool(ctx, *domainReq)
@@ -112,6 +253,9 @@ func (m *MagnetarGrpcServer) QueryNodePool(ctx context.Context, req *api.QueryNo
 		if errors.Is(err, domain.ErrForbidden) {
 			return nil, status.Error(codes.PermissionDenied, err.Error())
 		}
+		if errors.Is(err, domain.ErrInvalidPageToken) || errors.Is(err, domain.ErrInvalidPageSize) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
 	return proto.QueryNodePoolRespFromDomain(*domainResp)
@@ -120,6 +264,9 @@ func (m *MagnetarGrpcServer) QueryNodePool(ctx context.Context, req *api.QueryNo
 func (m *MagnetarGrpcServer) QueryOrgOwnedNodes(ctx context.Context, req *api.QueryOrgOwnedNodesReq) (*api.QueryOrgOwnedNodesResp, error) {
 	domainReq, err := proto.QueryOrgOwnedNodesReqToDomain(req)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) || errors.Is(err, domain.ErrInvalidPageSize) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
 	domainResp, err := m.nodeService.QueryOrgOwnedNodes(ctx, *domainReq)
@@ -127,6 +274,9 @@ func (m *MagnetarGrpcServer) QueryOrgOwnedNodes(ctx context.Context, req *api.Qu
 		if errors.Is(err, domain.ErrForbidden) {
 			return nil, status.Error(codes.PermissionDenied, err.Error())
 		}
+		if errors.Is(err, domain.ErrInvalidPageToken) || errors.Is(err, domain.ErrInvalidPageSize) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
 	return proto.QueryOrgOwnedNodesRespFromDomain(*domainResp)
@@ -139,9 +289,15 @@ func (m *MagnetarGrpcServer) PutBoolLabel(ctx context.Context, req *api.PutBoolL
 	}
 	domainResp, err := m.labelService.PutLabel(ctx, *domainReq)
 	if err != nil {
+		if errors.Is(err, domain.ErrNodeTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		if errors.Is(err, domain.ErrForbidden) {
 			return nil, status.Error(codes.PermissionDenied, err.Error())
 		}
+		if errors.Is(err, domain.ErrResourceVersionMismatch) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
 		return nil, err
 	}
 	return proto.PutLabelRespFromDomain(*domainResp)
@@ -154,9 +310,15 @@ func (m *MagnetarGrpcServer) PutFloat64Label(ctx context.Context, req *api.PutFl
 	}
 	domainResp, err := m.labelService.PutLabel(ctx, *domainReq)
 	if err != nil {
+		if errors.Is(err, domain.ErrNodeTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		if errors.Is(err, domain.ErrForbidden) {
 			return nil, status.Error(codes.PermissionDenied, err.Error())
 		}
+		if errors.Is(err, domain.ErrResourceVersionMismatch) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
 		return nil, err
 	}
 	return proto.PutLabelRespFromDomain(*domainResp)
@@ -169,9 +331,108 @@ func (m *MagnetarGrpcServer) PutStringLabel(ctx context.Context, req *api.PutStr
 	}
 	domainResp, err := m.labelService.PutLabel(ctx, *domainReq)
 	if err != nil {
+		if errors.Is(err, domain.ErrNodeTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, domain.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrResourceVersionMismatch) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		return nil, err
+	}
+	return proto.PutLabelRespFromDomain(*domainResp)
+}
+
+func (m *MagnetarGrpcServer) PutInt64Label(ctx context.Context, req *api.PutInt64LabelReq) (*api.PutLabelResp, error) {
+	domainReq, err := proto.PutInt64LabelReqToDomain(req)
+	if err != nil {
+		return nil, err
+	}
+	domainResp, err := m.labelService.PutLabel(ctx, *domainReq)
+	if err != nil {
+		if errors.Is(err, domain.ErrNodeTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, domain.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrResourceVersionMismatch) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		return nil, err
+	}
+	return proto.PutLabelRespFromDomain(*domainResp)
+}
+
+func (m *MagnetarGrpcServer) PutTimestampLabel(ctx context.Context, req *api.PutTimestampLabelReq) (*api.PutLabelResp, error) {
+	domainReq, err := proto.PutTimestampLabelReqToDomain(req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidLabelValue) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+	domainResp, err := m.labelService.PutLabel(ctx, *domainReq)
+	if err != nil {
+		if errors.Is(err, domain.ErrNodeTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		if errors.Is(err, domain.ErrForbidden) {
 			return nil, status.Error(codes.PermissionDenied, err.Error())
 		}
+		if errors.Is(err, domain.ErrResourceVersionMismatch) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		return nil, err
+	}
+	return proto.PutLabelRespFromDomain(*domainResp)
+}
+
+func (m *MagnetarGrpcServer) PutSemverLabel(ctx context.Context, req *api.PutSemverLabelReq) (*api.PutLabelResp, error) {
+	domainReq, err := proto.PutSemverLabelReqToDomain(req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidLabelValue) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+	domainResp, err := m.labelService.PutLabel(ctx, *domainReq)
+	if err != nil {
+		if errors.Is(err, domain.ErrNodeTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, domain.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrResourceVersionMismatch) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		return nil, err
+	}
+	return proto.PutLabelRespFromDomain(*domainResp)
+}
+
+func (m *MagnetarGrpcServer) PutStringSetLabel(ctx context.Context, req *api.PutStringSetLabelReq) (*api.PutLabelResp, error) {
+	domainReq, err := proto.PutStringSetLabelReqToDomain(req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidLabelValue) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+	domainResp, err := m.labelService.PutLabel(ctx, *domainReq)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidLabelValue) || errors.Is(err, domain.ErrNodeTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, domain.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrResourceVersionMismatch) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
 		return nil, err
 	}
 	return proto.PutLabelRespFromDomain(*domainResp)
@@ -187,17 +448,54 @@ func (m *MagnetarGrpcServer) DeleteLabel(ctx context.Context, req *api.DeleteLab
 		if errors.Is(err, domain.ErrForbidden) {
 			return nil, status.Error(codes.PermissionDenied, err.Error())
 		}
+		if errors.Is(err, domain.ErrResourceVersionMismatch) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
 		return nil, err
 	}
 	return proto.DeleteLabelRespFromDomain(*domainResp)
 }
 
 func (m *MagnetarGrpcServer) ListAllNodes(ctx context.Context, req *api.ListAllNodesReq) (*api.ListAllNodesResp, error) {
-	nodes, err := m.nodeService.ListAllNodes(ctx)
+	domainReq, err := proto.ListAllNodesReqToDomain(req)
 	if err != nil {
 		return nil, err
 	}
-	return proto.ListAlldNodesRespFromDomain(nodes)
+	domainResp, err := m.nodeService.ListAllNodes(ctx, *domainReq)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPageToken) || errors.Is(err, domain.ErrInvalidPageSize) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+	return proto.ListAlldNodesRespFromDomain(*domainResp)
+}
+
+func (m *MagnetarGrpcServer) WatchNodes(req *api.WatchNodesReq, stream api.Magnetar_WatchNodesServer) error {
+	domainReq, err := proto.WatchNodesReqToDomain(req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return err
+	}
+	err = m.nodeService.WatchNodes(stream.Context(), *domainReq, func(event domain.NodeEvent) error {
+		resp, err := proto.WatchNodesRespFromDomain(event)
+		if err != nil {
+			return err
+		}
+		return stream.Send(resp)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrForbidden) {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, domain.ErrRevisionCompacted) {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
+		return err
+	}
+	return nil
 }
 
 func GetAuthInterceptor() func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
@@ -210,3 +508,24 @@ func GetAuthInterceptor() func(ctx context.Context, req interface{}, info *grpc.
 		return handler(ctx, req)
 	}
 }
+
+func GetAuthStreamInterceptor() func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		md, ok := metadata.FromIncomingContext(ctx)
+		if ok && len(md.Get("authz-token")) > 0 {
+			ctx = context.WithValue(ctx, "authz-token", md.Get("authz-token")[0])
+		}
+		// Calls the handler with the token in the stream context
+		return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
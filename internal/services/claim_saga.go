@@ -0,0 +1,337 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/c12s/magnetar/internal/domain"
+	"golang.org/x/exp/slices"
+)
+
+// a claim is run as a saga, the steps are run for all nodes in order:
+// claim in etcd, relate in oort, set the namespace quotas in meridian once, join the gravity cluster
+// a node whose relate or join step keeps failing is compensated on its own,
+// the whole claim is aborted and all nodes compensated if the claimed nodes no longer meet the requirements,
+// the namespace quotas can't be set or the claim is cancelled
+
+const cancelledCause = "cancelled"
+
+// runClaimSaga continues the claim from its last recorded step until it is finished,
+// the candidates replace nodes that can't be claimed, there are none once the claim is resumed after a restart,
+// an error is returned only if the progress can't be recorded or ctx is done, which stops the claim at its current step
+func (n *NodeService) runClaimSaga(ctx context.Context, operation *domain.Operation, candidates []domain.Node) error {
+	if operation.State == domain.OperationCompensating {
+		return n.abortClaimSaga(ctx, operation, operation.Err)
+	}
+	if operation.Step < domain.OperationStepClaim {
+		if err := n.claimOperationNodes(ctx, operation, candidates); err != nil {
+			return err
+		}
+		if operation.CancelRequested {
+			return n.abortClaimSaga(ctx, operation, cancelledCause)
+		}
+		if err := n.completeOperationStep(operation, domain.OperationStepClaim); err != nil {
+			return err
+		}
+	}
+	if met, err := n.claimRequirementsMet(operation); err != nil || !met {
+		return n.abortClaimSagaUnlessErr(ctx, operation, err, domain.ErrInsufficientNodes.Error())
+	}
+	if operation.Step < domain.OperationStepRelate {
+		for _, nodeId := range operation.NodesWithStatus(domain.NodeOperationClaimed) {
+			if n.cancelRequested(operation) {
+				return n.abortClaimSaga(ctx, operation, cancelledCause)
+			}
+			err := n.runClaimSagaNodeStep(ctx, operation, nodeId, domain.OperationStepRelate, func() error {
+				return n.relateNode(ctx, operation.Org, nodeId)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if err := n.completeOperationStep(operation, domain.OperationStepRelate); err != nil {
+			return err
+		}
+		if met, err := n.claimRequirementsMet(operation); err != nil || !met {
+			return n.abortClaimSagaUnlessErr(ctx, operation, err, domain.ErrInsufficientNodes.Error())
+		}
+	}
+	if operation.Step < domain.OperationStepQuotas {
+		if n.cancelRequested(operation) {
+			return n.abortClaimSaga(ctx, operation, cancelledCause)
+		}
+		_, err := retry(ctx, func() error {
+			return n.syncNamespaceQuotas(ctx, operation.Org)
+		})
+		if ctx.Err() != nil {
+			return stopOperation(ctx, operation)
+		}
+		if err != nil {
+			return n.abortClaimSaga(ctx, operation, fmt.Sprintf("setting namespace quotas: %s", err))
+		}
+		if err := n.completeOperationStep(operation, domain.OperationStepQuotas); err != nil {
+			return err
+		}
+	}
+	if operation.Step < domain.OperationStepJoin {
+		joinAddress, err := n.claimJoinAddress(operation)
+		if err != nil {
+			return err
+		}
+		operation.JoinAddress = joinAddress
+		log.Println("join address: " + joinAddress)
+		compensated := len(operation.NodesWithStatus(domain.NodeOperationCompensated))
+		for _, nodeId := range operation.NodesWithStatus(domain.NodeOperationClaimed) {
+			if n.cancelRequested(operation) {
+				return n.abortClaimSaga(ctx, operation, cancelledCause)
+			}
+			err := n.runClaimSagaNodeStep(ctx, operation, nodeId, domain.OperationStepJoin, func() error {
+				return n.joinCluster(ctx, operation.Org, nodeId, joinAddress)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(operation.NodesWithStatus(domain.NodeOperationCompensated)) > compensated {
+			if met, err := n.claimRequirementsMet(operation); err != nil || !met {
+				return n.abortClaimSagaUnlessErr(ctx, operation, err, domain.ErrInsufficientNodes.Error())
+			}
+			// the quotas were set before the compensated nodes were released
+			_, err := retry(ctx, func() error {
+				return n.syncNamespaceQuotas(ctx, operation.Org)
+			})
+			if ctx.Err() != nil {
+				return stopOperation(ctx, operation)
+			}
+			if err != nil {
+				operation.Err = fmt.Sprintf("setting namespace quotas: %s", err)
+			}
+		}
+		operation.Step = domain.OperationStepJoin
+	}
+	operation.State = domain.OperationSucceeded
+	return n.saveOperation(operation)
+}
+
+// claimOperationNodes runs the claim step for the pending nodes, nodes that can't be claimed are marked as failed
+// and replaced with untried candidates, as long as the candidates allow it
+func (n *NodeService) claimOperationNodes(ctx context.Context, operation *domain.Operation, candidates []domain.Node) error {
+	for {
+		for _, nodeId := range operation.NodesWithStatus(domain.NodeOperationPending) {
+			if n.cancelRequested(operation) {
+				return nil
+			}
+			if err := n.claimOperationNode(ctx, operation, nodeId); err != nil {
+				return err
+			}
+		}
+		claimed, err := n.claimedOperationNodes(operation)
+		if err != nil {
+			return err
+		}
+		if operation.Requirements.Empty() || operation.Requirements.Met(claimed) {
+			return nil
+		}
+		untried := slices.DeleteFunc(slices.Clone(candidates), func(node domain.Node) bool {
+			_, tried := operation.Node(node.Id)
+			return tried
+		})
+		selected, err := domain.SelectForClaim(untried, operation.Requirements.Remaining(claimed))
+		if err != nil {
+			// the claim is aborted once the requirements are checked
+			return nil
+		}
+		operation.Nodes = append(operation.Nodes, pendingOperationNodes(nodeIdsOf(selected))...)
+	}
+}
+
+// claimOperationNode claims a node whose claim step hasn't been recorded,
+// a node that is already claimed fails even if it belongs to the org, since this operation can't tell it
+// from a node claimed by someone else, that includes a node claimed right before a restart interrupted the operation,
+// such a node stays in the org without being related or joined until the reconciler finds it
+func (n *NodeService) claimOperationNode(ctx context.Context, operation *domain.Operation, nodeId domain.NodeId) error {
+	node, _ := operation.Node(nodeId)
+	attempts, err := retry(ctx, func() error {
+		_, err := n.nodeRepo.Claim(nodeId, operation.Org)
+		return err
+	})
+	if err != nil && ctx.Err() != nil {
+		return stopOperation(ctx, operation)
+	}
+	node.Attempts = attempts
+	if err != nil {
+		log.Println(err)
+		node.Status = domain.NodeOperationFailed
+		node.Err = err.Error()
+	} else {
+		node.Status = domain.NodeOperationClaimed
+		node.Step = domain.OperationStepClaim
+	}
+	return n.saveOperation(operation)
+}
+
+// runClaimSagaNodeStep retries the step for a claimed node and compensates the node if it keeps failing,
+// a step the node completed before the claim was stopped isn't run again
+func (n *NodeService) runClaimSagaNodeStep(ctx context.Context, operation *domain.Operation, nodeId domain.NodeId, step domain.OperationStep, run func() error) error {
+	node, _ := operation.Node(nodeId)
+	if node.Step >= step {
+		return nil
+	}
+	attempts, err := retry(ctx, run)
+	if err != nil && ctx.Err() != nil {
+		return stopOperation(ctx, operation)
+	}
+	node.Attempts = attempts
+	if err == nil {
+		node.Step = step
+		return n.saveOperation(operation)
+	}
+	log.Println(err)
+	return n.compensateClaimedNode(ctx, operation, nodeId, fmt.Sprintf("%s: %s", step, err))
+}
+
+// compensateClaimedNode undoes the completed steps of the node in reverse order,
+// if an undo keeps failing the node is left as it is and the failure is recorded
+func (n *NodeService) compensateClaimedNode(ctx context.Context, operation *domain.Operation, nodeId domain.NodeId, cause string) error {
+	node, _ := operation.Node(nodeId)
+	node.Err = cause
+	for node.Step > domain.OperationStepNone {
+		var undo func() error
+		switch node.Step {
+		case domain.OperationStepJoin:
+			undo = func() error {
+				return n.leaveCluster(ctx, operation.Org, nodeId)
+			}
+		case domain.OperationStepRelate:
+			undo = func() error {
+				return n.unrelateNode(ctx, operation.Org, nodeId)
+			}
+		case domain.OperationStepClaim:
+			undo = func() error {
+				_, err := n.nodeRepo.Release(nodeId, operation.Org)
+				// released or deregistered in the meantime
+				if errors.Is(err, domain.ErrNodeNotFound) {
+					return nil
+				}
+				return err
+			}
+		}
+		attempts, err := retry(ctx, undo)
+		if err != nil && ctx.Err() != nil {
+			return stopOperation(ctx, operation)
+		}
+		node.Attempts = attempts
+		if err != nil {
+			log.Println(err)
+			node.Status = domain.NodeOperationCompensationFailed
+			node.Err = fmt.Sprintf("%s, compensating %s: %s", cause, node.Step, err)
+			return n.saveOperation(operation)
+		}
+		node.Step--
+		// the quotas step isn't recorded per node
+		if node.Step == domain.OperationStepQuotas {
+			node.Step--
+		}
+		if err := n.saveOperation(operation); err != nil {
+			return err
+		}
+	}
+	node.Status = domain.NodeOperationCompensated
+	return n.saveOperation(operation)
+}
+
+// abortClaimSaga compensates all claimed nodes, the claim ends up cancelled if that was requested and failed otherwise
+func (n *NodeService) abortClaimSaga(ctx context.Context, operation *domain.Operation, cause string) error {
+	operation.State = domain.OperationCompensating
+	operation.Err = cause
+	cancelPendingNodes(operation)
+	if err := n.saveOperation(operation); err != nil {
+		return err
+	}
+	for _, nodeId := range operation.NodesWithStatus(domain.NodeOperationClaimed) {
+		if err := n.compensateClaimedNode(ctx, operation, nodeId, cause); err != nil {
+			return err
+		}
+	}
+	if operation.Step >= domain.OperationStepQuotas {
+		_, err := retry(ctx, func() error {
+			return n.syncNamespaceQuotas(ctx, operation.Org)
+		})
+		if ctx.Err() != nil {
+			return stopOperation(ctx, operation)
+		}
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	operation.State = domain.OperationFailed
+	if operation.CancelRequested {
+		operation.State = domain.OperationCancelled
+	}
+	return n.saveOperation(operation)
+}
+
+// abortClaimSagaUnlessErr aborts the claim unless checking whether it has to be aborted failed
+func (n *NodeService) abortClaimSagaUnlessErr(ctx context.Context, operation *domain.Operation, err error, cause string) error {
+	if err != nil {
+		return err
+	}
+	return n.abortClaimSaga(ctx, operation, cause)
+}
+
+func (n *NodeService) completeOperationStep(operation *domain.Operation, step domain.OperationStep) error {
+	operation.Step = step
+	return n.saveOperation(operation)
+}
+
+func (n *NodeService) claimRequirementsMet(operation *domain.Operation) (bool, error) {
+	claimed, err := n.claimedOperationNodes(operation)
+	if err != nil {
+		return false, err
+	}
+	return operation.Requirements.Empty() || operation.Requirements.Met(claimed), nil
+}
+
+// claimedOperationNodes returns the nodes the claim has claimed so far
+func (n *NodeService) claimedOperationNodes(operation *domain.Operation) ([]domain.Node, error) {
+	nodes := make([]domain.Node, 0)
+	for _, nodeId := range operation.NodesWithStatus(domain.NodeOperationClaimed) {
+		node, err := n.nodeRepo.Get(nodeId, operation.Org)
+		if errors.Is(err, domain.ErrNodeNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, *node)
+	}
+	return nodes, nil
+}
+
+// claimJoinAddress prefers nodes that were in the org's cluster before the claim
+func (n *NodeService) claimJoinAddress(operation *domain.Operation) (string, error) {
+	orgNodes, _, err := n.nodeRepo.ListOrgOwnedNodes(operation.Org, domain.Page{})
+	if err != nil {
+		return "", err
+	}
+	cluster := make([]domain.Node, 0)
+	claimed := make([]domain.Node, 0)
+	for _, node := range orgNodes {
+		if operationNode, ok := operation.Node(node.Id); ok && operationNode.Status == domain.NodeOperationClaimed {
+			claimed = append(claimed, node)
+		} else {
+			cluster = append(cluster, node)
+		}
+	}
+	return joinAddress(cluster, claimed), nil
+}
+
+func nodeIdsOf(nodes []domain.Node) []domain.NodeId {
+	ids := make([]domain.NodeId, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, node.Id)
+	}
+	return ids
+}
@@ -9,12 +9,14 @@ import (
 type LabelService struct {
 	nodeRepo   domain.NodeRepo
 	authorizer AuthZService
+	liveness   *LivenessService
 }
 
-func NewLabelService(nodeRepo domain.NodeRepo, evaluator oortapi.OortEvaluatorClient, authorizer AuthZService) (*LabelService, error) {
+func NewLabelService(nodeRepo domain.NodeRepo, evaluator oortapi.OortEvaluatorClient, authorizer AuthZService, liveness *LivenessService) (*LabelService, error) {
 	return &LabelService{
 		nodeRepo:   nodeRepo,
 		authorizer: authorizer,
+		liveness:   liveness,
 	}, nil
 }
 
@@ -22,14 +24,28 @@ func (l *LabelService) PutLabel(ctx context.Context, req domain.PutLabelReq) (*d
 	if !l.authorizer.Authorize(ctx, "node.label.put", "node", req.NodeId.Value) {
 		return nil, domain.ErrForbidden
 	}
+	if err := domain.ValidateLabel(req.Label); err != nil {
+		return nil, err
+	}
 	node, err := l.nodeRepo.Get(req.NodeId, req.Org)
 	if err != nil {
 		return nil, err
 	}
+	if req.ResourceVersion != 0 && req.ResourceVersion != node.ResourceVersion {
+		return nil, domain.ErrResourceVersionMismatch
+	}
+	if err := domain.ValidateNode(node.WithLabel(req.Label)); err != nil {
+		return nil, err
+	}
 	node, err = l.nodeRepo.PutLabel(*node, req.Label)
 	if err != nil {
 		return nil, err
 	}
+	nodes, err := l.liveness.WithLiveness([]domain.Node{*node})
+	if err != nil {
+		return nil, err
+	}
+	node = &nodes[0]
 	return &domain.PutLabelResp{
 		Node: *node,
 	}, nil
@@ -43,10 +59,18 @@ func (l *LabelService) DeleteLabel(ctx context.Context, req domain.DeleteLabelRe
 	if err != nil {
 		return nil, err
 	}
+	if req.ResourceVersion != 0 && req.ResourceVersion != node.ResourceVersion {
+		return nil, domain.ErrResourceVersionMismatch
+	}
 	node, err = l.nodeRepo.DeleteLabel(*node, req.LabelKey)
 	if err != nil {
 		return nil, err
 	}
+	nodes, err := l.liveness.WithLiveness([]domain.Node{*node})
+	if err != nil {
+		return nil, err
+	}
+	node = &nodes[0]
 	return &domain.DeleteLabelResp{
 		Node: *node,
 	}, nil
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"time"
+
+	"github.com/c12s/magnetar/internal/domain"
+)
+
+type LivenessService struct {
+	nodeRepo     domain.NodeRepo
+	livenessRepo domain.NodeLivenessRepo
+	ttl          time.Duration
+}
+
+func NewLivenessService(nodeRepo domain.NodeRepo, livenessRepo domain.NodeLivenessRepo, ttl time.Duration) (*LivenessService, error) {
+	return &LivenessService{
+		nodeRepo:     nodeRepo,
+		livenessRepo: livenessRepo,
+		ttl:          ttl,
+	}, nil
+}
+
+// Heartbeat is accepted only for registered nodes and from the agent holding the node's registration token
+func (l *LivenessService) Heartbeat(req domain.HeartbeatReq) error {
+	node, err := l.nodeRepo.Find(req.NodeId)
+	if err != nil {
+		return err
+	}
+	if !node.HasRegistrationToken(req.RegistrationToken) {
+		return domain.ErrForbidden
+	}
+	return l.livenessRepo.Heartbeat(*node, l.ttl)
+}
+
+// WithLiveness sets the status and last seen time of every node
+func (l *LivenessService) WithLiveness(nodes []domain.Node) ([]domain.Node, error) {
+	nodeIds := make([]domain.NodeId, len(nodes))
+	for i, node := range nodes {
+		nodeIds[i] = node.Id
+	}
+	liveness, err := l.livenessRepo.Get(nodeIds)
+	if err != nil {
+		return nil, err
+	}
+	for i := range nodes {
+		nodes[i].Status = liveness[nodes[i].Id].Status
+		nodes[i].LastSeen = liveness[nodes[i].Id].LastSeen
+	}
+	return nodes, nil
+}
+
+// ExcludeNotReady drops nodes that have stopped sending heartbeats and sets the liveness of the rest,
+// nodes that have never sent one are kept, it can be used as a page filter
+func (l *LivenessService) ExcludeNotReady(nodes []domain.Node) ([]domain.Node, error) {
+	nodes, err := l.WithLiveness(nodes)
+	if err != nil {
+		return nil, err
+	}
+	ready := make([]domain.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if node.Status != domain.NodeStatusNotReady {
+			ready = append(ready, node)
+		}
+	}
+	return ready, nil
+}
+
+func (l *LivenessService) Forget(nodeId domain.NodeId) error {
+	return l.livenessRepo.Delete(nodeId)
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/c12s/magnetar/internal/domain"
+)
+
+func TestHeartbeat(t *testing.T) {
+	service, nodeRepo := newTestNodeService(t)
+	node := newTestNode("n1")
+	node.RegistrationTokenHash = domain.HashRegistrationToken("token")
+	if err := nodeRepo.Put(node); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		req  domain.HeartbeatReq
+		err  error
+	}{
+		{"WrongToken", domain.HeartbeatReq{NodeId: node.Id, RegistrationToken: "guessed"}, domain.ErrForbidden},
+		{"WithoutToken", domain.HeartbeatReq{NodeId: node.Id}, domain.ErrForbidden},
+		{"UnknownNode", domain.HeartbeatReq{NodeId: domain.NodeId{Value: "n2"}, RegistrationToken: "token"}, domain.ErrNodeNotFound},
+		{"Token", domain.HeartbeatReq{NodeId: node.Id, RegistrationToken: "token"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := service.liveness.Heartbeat(tt.req); !errors.Is(err, tt.err) {
+				t.Fatalf("got error %v, want %v", err, tt.err)
+			}
+			nodes, err := service.liveness.WithLiveness([]domain.Node{node, {Id: domain.NodeId{Value: "n2"}}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ready := nodes[0].Status == domain.NodeStatusReady; ready != (tt.err == nil) {
+				t.Errorf("got node ready %t, want %t", ready, tt.err == nil)
+			}
+			if nodes[1].Status != domain.NodeStatusUnknown {
+				t.Errorf("expected no liveness for an unknown node, got %s", nodes[1].Status)
+			}
+		})
+	}
+}
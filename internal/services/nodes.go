@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"strings"
 
@@ -9,23 +11,37 @@ import (
 	"github.com/c12s/magnetar/internal/domain"
 	meridian_api "github.com/c12s/meridian/pkg/api"
 	oortapi "github.com/c12s/oort/pkg/api"
+	"golang.org/x/exp/slices"
 )
 
 type NodeService struct {
-	nodeRepo      domain.NodeRepo
-	administrator *oortapi.AdministrationAsyncClient
-	authorizer    AuthZService
-	meridian      meridian_api.MeridianClient
-	gravity       gravity_api.AgentQueueClient
+	nodeRepo       domain.NodeRepo
+	operationRepo  domain.OperationRepo
+	orgClusterRepo domain.OrgClusterRepo
+	administrator  oortAdministrator
+	authorizer     AuthZService
+	meridian       meridian_api.MeridianClient
+	gravity        gravity_api.AgentQueueClient
+	liveness       *LivenessService
+	operations     *operationRunner
 }
 
-func NewNodeService(nodeRepo domain.NodeRepo, evaluator oortapi.OortEvaluatorClient, administrator *oortapi.AdministrationAsyncClient, authorizer AuthZService, meridian meridian_api.MeridianClient, gravity gravity_api.AgentQueueClient) (*NodeService, error) {
+// oortAdministrator is the part of the oort administration client the service uses
+type oortAdministrator interface {
+	SendRequest(req interface{}, callback func(resp *oortapi.AdministrationAsyncResp)) error
+}
+
+func NewNodeService(nodeRepo domain.NodeRepo, operationRepo domain.OperationRepo, orgClusterRepo domain.OrgClusterRepo, evaluator oortapi.OortEvaluatorClient, administrator *oortapi.AdministrationAsyncClient, authorizer AuthZService, meridian meridian_api.MeridianClient, gravity gravity_api.AgentQueueClient, liveness *LivenessService) (*NodeService, error) {
 	return &NodeService{
-		nodeRepo:      nodeRepo,
-		administrator: administrator,
-		authorizer:    authorizer,
-		meridian:      meridian,
-		gravity:       gravity,
+		nodeRepo:       nodeRepo,
+		operationRepo:  operationRepo,
+		orgClusterRepo: orgClusterRepo,
+		liveness:       liveness,
+		administrator:  administrator,
+		authorizer:     authorizer,
+		meridian:       meridian,
+		gravity:        gravity,
+		operations:     newOperationRunner(),
 	}, nil
 }
 
@@ -34,6 +50,11 @@ func (n *NodeService) GetFromNodePool(ctx context.Context, req domain.GetFromNod
 	if err != nil {
 		return nil, err
 	}
+	nodes, err := n.liveness.WithLiveness([]domain.Node{*node})
+	if err != nil {
+		return nil, err
+	}
+	node = &nodes[0]
 	return &domain.GetFromNodePoolResp{
 		Node: *node,
 	}, nil
@@ -47,6 +68,11 @@ func (n *NodeService) GetFromOrg(ctx context.Context, req domain.GetFromOrgReq)
 	if err != nil {
 		return nil, err
 	}
+	nodes, err := n.liveness.WithLiveness([]domain.Node{*node})
+	if err != nil {
+		return nil, err
+	}
+	node = &nodes[0]
 	return &domain.GetFromOrgResp{
 		Node: *node,
 	}, nil
@@ -56,123 +82,241 @@ func (n *NodeService) ClaimOwnership(ctx context.Context, req domain.ClaimOwners
 	if !n.authorizer.Authorize(ctx, "node.put", "org", req.Org) {
 		return nil, domain.ErrForbidden
 	}
-	cluster, err := n.nodeRepo.ListOrgOwnedNodes(req.Org)
+	cluster, _, err := n.nodeRepo.ListOrgOwnedNodes(req.Org, domain.Page{})
 	if err != nil {
 		return nil, err
 	}
-	nodes, err := n.nodeRepo.QueryNodePool(req.Query)
+	nodes, _, err := n.nodeRepo.QueryNodePool(req.Query, n.readyPage(domain.Page{}, req.IncludeNotReady))
 	if err != nil {
 		return nil, err
 	}
-	for _, node := range nodes {
-		err = n.nodeRepo.Delete(node)
+	if req.IncludeNotReady {
+		nodes, err = n.liveness.WithLiveness(nodes)
 		if err != nil {
-			log.Println(err)
-			continue
+			return nil, err
 		}
-		node.Org = req.Org
-		err = n.nodeRepo.Put(node)
+	}
+	selected, err := domain.SelectForClaim(nodes, req.Requirements)
+	if err != nil {
+		return nil, err
+	}
+	// a dry run stops before anything is written or sent to oort, meridian or gravity
+	if req.DryRun {
+		return &domain.ClaimOwnershipResp{
+			Nodes:           selected,
+			DryRun:          true,
+			NamespaceQuotas: namespaceQuotas(append(slices.Clone(cluster), selected...)),
+			JoinAddress:     joinAddress(cluster, selected),
+		}, nil
+	}
+	operation, err := n.startOperation(domain.OperationClaim, req.Org, req.Requirements, nodeIdsOf(selected), func(ctx context.Context, operation *domain.Operation) error {
+		return n.runClaimSaga(ctx, operation, nodes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ClaimOwnershipResp{
+		Nodes:     selected,
+		Operation: operation,
+	}, nil
+}
+
+func (n *NodeService) DeregisterNode(ctx context.Context, req domain.DeregisterNodeReq) (*domain.DeregisterNodeResp, error) {
+	if !n.authorizer.Authorize(ctx, "node.delete", "node", req.Id.Value) {
+		return nil, domain.ErrForbidden
+	}
+	node, err := n.nodeRepo.Get(req.Id, req.Org)
+	if err != nil {
+		return nil, err
+	}
+	err = n.deregister(ctx, *node)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.DeregisterNodeResp{
+		Node: *node,
+	}, nil
+}
+
+// Deregister handles shutdown requests sent by the agents themselves, which have to hold the node's registration token,
+// the node is looked up again if it has been claimed or modified in the meantime
+func (n *NodeService) Deregister(ctx context.Context, req domain.DeregistrationReq) error {
+	for attempt := 1; ; attempt++ {
+		node, err := n.nodeRepo.Find(req.NodeId)
 		if err != nil {
-			log.Println(err)
-			continue
+			return err
 		}
-		err = n.administrator.SendRequest(&oortapi.CreateInheritanceRelReq{
-			From: &oortapi.Resource{
-				Id:   req.Org,
-				Kind: "org",
-			},
-			To: &oortapi.Resource{
-				Id:   node.Id.Value,
-				Kind: "node",
-			},
-		}, func(resp *oortapi.AdministrationAsyncResp) {
-			if resp.Error != "" {
-				log.Println(resp.Error)
-			}
-		})
-		if err != nil {
+		if !node.HasRegistrationToken(req.RegistrationToken) {
+			return domain.ErrForbidden
+		}
+		err = n.deregister(ctx, *node)
+		if !errors.Is(err, domain.ErrResourceVersionMismatch) || attempt == deregisterAttempts {
+			return err
+		}
+	}
+}
+
+const deregisterAttempts = 3
+
+// deregister removes the node along with its labels unless it has changed since it was read,
+// claimed nodes are also detached from the org and the org's quotas are shrunk accordingly
+func (n *NodeService) deregister(ctx context.Context, node domain.Node) error {
+	err := n.nodeRepo.Delete(node)
+	if err != nil {
+		return err
+	}
+	err = n.liveness.Forget(node.Id)
+	if err != nil {
+		log.Println(err)
+	}
+	if node.Claimed() {
+		n.deleteOrgNodeRel(node.Org, node.Id)
+		if err := n.syncNamespaceQuotas(ctx, node.Org); err != nil {
 			log.Println(err)
 		}
 	}
-	// upsert ns
-	listNodesResp, err := n.ListOrgOwnedNodes(ctx, domain.ListOrgOwnedNodesReq{
-		Org: req.Org,
-	})
+	return nil
+}
+
+func (n *NodeService) ReleaseNodes(ctx context.Context, req domain.ReleaseNodesReq) (*domain.ReleaseNodesResp, error) {
+	if !n.authorizer.Authorize(ctx, "node.put", "org", req.Org) {
+		return nil, domain.ErrForbidden
+	}
+	// an empty query matches every node of the org
+	if len(req.NodeIds) == 0 && len(req.Query) == 0 {
+		return nil, fmt.Errorf("%w: node ids or a non-empty query are required", domain.ErrInvalidQuery)
+	}
+	nodeIds, err := n.orgOwnedNodeIds(req.Org, req.NodeIds)
 	if err != nil {
 		return nil, err
 	}
-	resources := make(map[string]float64)
-	for _, node := range listNodesResp.Nodes {
-		for resource, quota := range node.Resources {
-			resources[resource] = resources[resource] + quota
+	if len(nodeIds) == 0 {
+		nodes, _, err := n.nodeRepo.QueryOrgOwnedNodes(req.Query, req.Org, domain.Page{})
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			nodeIds = append(nodeIds, node.Id)
 		}
 	}
-	_, err = n.meridian.GetNamespace(ctx, &meridian_api.GetNamespaceReq{
-		OrgId: req.Org,
-		Name:  "default",
-	})
+	operation, err := n.startOperation(domain.OperationRelease, req.Org, domain.ClaimRequirements{}, nodeIds, n.runReleaseOperation)
 	if err != nil {
-		log.Println(err)
-		if strings.Contains(err.Error(), "not found") {
-			_, err = n.meridian.AddNamespace(ctx, &meridian_api.AddNamespaceReq{
-				OrgId:                     req.Org,
-				Name:                      "default",
-				Labels:                    make(map[string]string),
-				Quotas:                    resources,
-				SeccompDefinitionStrategy: "redefine",
-				Profile: &meridian_api.SeccompProfile{
-					Version:       "v1.0.0",
-					DefaultAction: "ALLOW",
-					Syscalls:      make([]*meridian_api.SyscallRule, 0),
-				},
-			})
-			if err != nil {
-				log.Println(err)
+		return nil, err
+	}
+	return &domain.ReleaseNodesResp{
+		Operation: *operation,
+	}, nil
+}
+
+// orgOwnedNodeIds drops duplicate node ids and makes sure the org owns the rest,
+// ErrNodeNotFound is returned for the first one it doesn't own
+func (n *NodeService) orgOwnedNodeIds(org string, nodeIds []domain.NodeId) ([]domain.NodeId, error) {
+	owned := make([]domain.NodeId, 0, len(nodeIds))
+	for _, nodeId := range nodeIds {
+		if slices.Contains(owned, nodeId) {
+			continue
+		}
+		if _, err := n.nodeRepo.Get(nodeId, org); err != nil {
+			if errors.Is(err, domain.ErrNodeNotFound) {
+				return nil, fmt.Errorf("%w: %s is not owned by org %s", err, nodeId.Value, org)
 			}
+			return nil, err
 		}
-	} else {
-		_, err = n.meridian.SetNamespaceResources(ctx, &meridian_api.SetNamespaceResourcesReq{
-			OrgId:  req.Org,
-			Name:   "default",
-			Quotas: resources,
-		})
-		if err != nil {
-			log.Println(err)
+		owned = append(owned, nodeId)
+	}
+	return owned, nil
+}
+
+// deleteOrgNodeRel removes the inheritance relation created when the org claimed the node
+func (n *NodeService) deleteOrgNodeRel(org string, nodeId domain.NodeId) {
+	err := n.administrator.SendRequest(&oortapi.DeleteInheritanceRelReq{
+		From: &oortapi.Resource{
+			Id:   org,
+			Kind: "org",
+		},
+		To: &oortapi.Resource{
+			Id:   nodeId.Value,
+			Kind: "node",
+		},
+	}, func(resp *oortapi.AdministrationAsyncResp) {
+		if resp.Error != "" {
+			log.Println(resp.Error)
+			return
 		}
+		n.updateOrgCluster(org, func(cluster *domain.OrgCluster) {
+			cluster.SetRelated(nodeId, false)
+		})
+	})
+	if err != nil {
+		log.Println(err)
 	}
-	// join cluster
-	if len(nodes) == 0 {
-		return &domain.ClaimOwnershipResp{
-			Nodes: nodes,
-		}, nil
+}
+
+// syncNamespaceQuotas sets the quotas of the org's default namespace to the sum of its nodes' resources,
+// creating the namespace if it doesn't exist
+func (n *NodeService) syncNamespaceQuotas(ctx context.Context, org string) error {
+	nodes, _, err := n.nodeRepo.ListOrgOwnedNodes(org, domain.Page{})
+	if err != nil {
+		return err
 	}
-	joinAddress := nodes[0].BindAddress
-	if len(cluster) > 0 {
-		joinAddress = cluster[0].BindAddress
+	quotas := namespaceQuotas(nodes)
+	_, err = n.getNamespace(ctx, org)
+	if err != nil {
+		if !namespaceNotFound(err) {
+			return err
+		}
+		return n.addNamespace(ctx, org, quotas)
 	}
-	log.Println("join address: " + joinAddress)
+	return n.setNamespaceQuotas(ctx, org, quotas)
+}
+
+func (n *NodeService) getNamespace(ctx context.Context, org string) (*meridian_api.GetNamespaceResp, error) {
+	ctx, cancel := context.WithTimeout(ctx, externalCallTimeout)
+	defer cancel()
+	return n.meridian.GetNamespace(ctx, &meridian_api.GetNamespaceReq{
+		OrgId: org,
+		Name:  "default",
+	})
+}
+
+func namespaceNotFound(err error) bool {
+	return strings.Contains(err.Error(), "not found")
+}
+
+func namespaceQuotas(nodes []domain.Node) map[string]float64 {
+	quotas := make(map[string]float64)
 	for _, node := range nodes {
-		_, err = n.gravity.JoinCluster(ctx, &gravity_api.JoinClusterRequest{
-			NodeId:      node.Id.Value,
-			JoinAddress: joinAddress,
-			ClusterId:   req.Org,
-		})
-		if err != nil {
-			log.Println(err)
+		for resource, quota := range node.Resources {
+			quotas[resource] = quotas[resource] + quota
 		}
 	}
-	return &domain.ClaimOwnershipResp{
-		Nodes: nodes,
-	}, nil
+	return quotas
+}
+
+// joinAddress is the address of a node already in the org's cluster,
+// or of the first claimed node if the cluster is being created
+func joinAddress(cluster, claimed []domain.Node) string {
+	if len(cluster) > 0 {
+		return cluster[0].BindAddress
+	}
+	if len(claimed) > 0 {
+		return claimed[0].BindAddress
+	}
+	return ""
 }
 
 func (n *NodeService) ListNodePool(ctx context.Context, req domain.ListNodePoolReq) (*domain.ListNodePoolResp, error) {
-	nodes, err := n.nodeRepo.ListNodePool()
+	nodes, nextPageToken, err := n.nodeRepo.ListNodePool(req.Page)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err = n.liveness.WithLiveness(nodes)
 	if err != nil {
 		return nil, err
 	}
 	return &domain.ListNodePoolResp{
-		Nodes: nodes,
+		Nodes:         nodes,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
@@ -180,38 +324,88 @@ func (n *NodeService) ListOrgOwnedNodes(ctx context.Context, req domain.ListOrgO
 	// if !n.authorizer.Authorize(ctx, "node.get", "org", req.Org) {
 	// 	return nil, domain.ErrForbidden
 	// }
-	nodes, err := n.nodeRepo.ListOrgOwnedNodes(req.Org)
+	nodes, nextPageToken, err := n.nodeRepo.ListOrgOwnedNodes(req.Org, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err = n.liveness.WithLiveness(nodes)
 	if err != nil {
 		return nil, err
 	}
 	return &domain.ListOrgOwnedNodesResp{
-		Nodes: nodes,
+		Nodes:         nodes,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
-func (n *NodeService) ListAllNodes(ctx context.Context) ([]domain.Node, error) {
-	return n.nodeRepo.ListAllNodes()
+func (n *NodeService) ListAllNodes(ctx context.Context, req domain.ListAllNodesReq) (*domain.ListAllNodesResp, error) {
+	nodes, nextPageToken, err := n.nodeRepo.ListAllNodes(req.Page)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err = n.liveness.WithLiveness(nodes)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ListAllNodesResp{
+		Nodes:         nodes,
+		NextPageToken: nextPageToken,
+	}, nil
 }
 
 func (n *NodeService) QueryNodePool(ctx context.Context, req domain.QueryNodePoolReq) (*domain.QueryNodePoolResp, error) {
-	nodes, err := n.nodeRepo.QueryNodePool(req.Query)
+	nodes, nextPageToken, err := n.nodeRepo.QueryNodePool(req.Query, n.readyPage(req.Page, req.IncludeNotReady))
 	if err != nil {
 		return nil, err
 	}
+	if req.IncludeNotReady {
+		nodes, err = n.liveness.WithLiveness(nodes)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &domain.QueryNodePoolResp{
-		Nodes: nodes,
+		Nodes:         nodes,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
+// readyPage has the repo drop not ready nodes before it fills the page,
+// the liveness of the nodes it keeps is set along the way
+func (n *NodeService) readyPage(page domain.Page, includeNotReady bool) domain.Page {
+	if !includeNotReady {
+		page.Filter = n.liveness.ExcludeNotReady
+	}
+	return page
+}
+
 func (n *NodeService) QueryOrgOwnedNodes(ctx context.Context, req domain.QueryOrgOwnedNodesReq) (*domain.QueryOrgOwnedNodesResp, error) {
 	if !n.authorizer.Authorize(ctx, "node.get", "org", req.Org) {
 		return nil, domain.ErrForbidden
 	}
-	nodes, err := n.nodeRepo.QueryOrgOwnedNodes(req.Query, req.Org)
+	nodes, nextPageToken, err := n.nodeRepo.QueryOrgOwnedNodes(req.Query, req.Org, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err = n.liveness.WithLiveness(nodes)
 	if err != nil {
 		return nil, err
 	}
 	return &domain.QueryOrgOwnedNodesResp{
-		Nodes: nodes,
+		Nodes:         nodes,
+		NextPageToken: nextPageToken,
 	}, nil
 }
+
+func (n *NodeService) WatchNodes(ctx context.Context, req domain.WatchNodesReq, handler func(event domain.NodeEvent) error) error {
+	if req.Org != "" && !n.authorizer.Authorize(ctx, "node.get", "org", req.Org) {
+		return domain.ErrForbidden
+	}
+	return n.nodeRepo.Watch(ctx, req.Org, req.Revision, func(event domain.NodeEvent) error {
+		event, ok := event.Filter(req.Query)
+		if !ok {
+			return nil
+		}
+		return handler(event)
+	})
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/c12s/magnetar/internal/domain"
+	"github.com/c12s/magnetar/internal/marshallers/proto"
+	"github.com/c12s/magnetar/internal/repos"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testTokenKey = "test"
+
+func TestReleaseNodesRejected(t *testing.T) {
+	service, nodeRepo := newTestNodeService(t)
+	for _, node := range []domain.Node{newTestNode("n1"), newTestNode("n2")} {
+		if err := nodeRepo.Put(node); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := nodeRepo.Claim(domain.NodeId{Value: "n1"}, "org1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nodeRepo.Claim(domain.NodeId{Value: "n2"}, "org2"); err != nil {
+		t.Fatal(err)
+	}
+	ctx := authorizedCtx(t, "node.put|org|org1")
+
+	tests := []struct {
+		name string
+		req  domain.ReleaseNodesReq
+		err  error
+	}{
+		{"NoNodeIdsOrQuery", domain.ReleaseNodesReq{Org: "org1"}, domain.ErrInvalidQuery},
+		{"OtherOrgsNode", domain.ReleaseNodesReq{Org: "org1", NodeIds: []domain.NodeId{{Value: "n1"}, {Value: "n2"}}}, domain.ErrNodeNotFound},
+		{"UnknownNode", domain.ReleaseNodesReq{Org: "org1", NodeIds: []domain.NodeId{{Value: "n3"}}}, domain.ErrNodeNotFound},
+		{"Unauthorized", domain.ReleaseNodesReq{Org: "org2", NodeIds: []domain.NodeId{{Value: "n2"}}}, domain.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.ReleaseNodes(ctx, tt.req); !errors.Is(err, tt.err) {
+				t.Errorf("got error %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestDeregister(t *testing.T) {
+	service, nodeRepo := newTestNodeService(t)
+	node := newTestNode("n1")
+	node.RegistrationTokenHash = domain.HashRegistrationToken("token")
+	if err := nodeRepo.Put(node); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		req  domain.DeregistrationReq
+		err  error
+	}{
+		{"WrongToken", domain.DeregistrationReq{NodeId: node.Id, RegistrationToken: "guessed"}, domain.ErrForbidden},
+		{"WithoutToken", domain.DeregistrationReq{NodeId: node.Id}, domain.ErrForbidden},
+		{"UnknownNode", domain.DeregistrationReq{NodeId: domain.NodeId{Value: "n2"}, RegistrationToken: "token"}, domain.ErrNodeNotFound},
+		{"Token", domain.DeregistrationReq{NodeId: node.Id, RegistrationToken: "token"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := service.Deregister(context.Background(), tt.req); !errors.Is(err, tt.err) {
+				t.Fatalf("got error %v, want %v", err, tt.err)
+			}
+			_, err := nodeRepo.Find(node.Id)
+			if deleted := errors.Is(err, domain.ErrNodeNotFound); deleted != (tt.err == nil) {
+				t.Errorf("got node deleted %t, want %t", deleted, tt.err == nil)
+			}
+		})
+	}
+}
+
+func newTestNodeService(t *testing.T) (*NodeService, domain.NodeRepo) {
+	nodeRepo, err := repos.NewNodeInMemRepo(proto.NewProtoNodeMarshaller(), proto.NewProtoLabelMarshaller())
+	if err != nil {
+		t.Fatal(err)
+	}
+	operationRepo, err := repos.NewOperationInMemRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgClusterRepo, err := repos.NewOrgClusterInMemRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	livenessRepo, err := repos.NewNodeLivenessInMemRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	liveness, err := NewLivenessService(nodeRepo, livenessRepo, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewNodeService(nodeRepo, operationRepo, orgClusterRepo, nil, nil, NewAuthZService(testTokenKey), nil, nil, liveness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service, nodeRepo
+}
+
+func newTestNode(id string) domain.Node {
+	return domain.Node{
+		Id:          domain.NodeId{Value: id},
+		BindAddress: id + ":7946",
+		Labels:      make([]domain.Label, 0),
+		Resources:   map[string]float64{"cpu": 4},
+	}
+}
+
+// authorizedCtx carries a token granting the permissions, each formatted as name|kind|id
+func authorizedCtx(t *testing.T, permissions ...string) context.Context {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"permissions": strings.Join(permissions, ","),
+	}).SignedString([]byte(testTokenKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return context.WithValue(context.Background(), "authz-token", token)
+}
@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/c12s/magnetar/internal/domain"
+	oortapi "github.com/c12s/oort/pkg/api"
+	"github.com/google/uuid"
+	"golang.org/x/exp/slices"
+)
+
+// claims and releases return an operation right away and carry out their steps in the background,
+// every step is recorded, so an operation interrupted by a restart is picked up again,
+// the replica running an operation owns it until it stops, and it is only resumed once the ownership has lapsed
+
+const (
+	operationStepAttempts   = 5
+	operationStepBackoff    = 200 * time.Millisecond
+	operationStepMaxBackoff = 5 * time.Second
+	// bounds every call to oort, meridian and gravity, so that a step can't hang
+	externalCallTimeout = 10 * time.Second
+	// operations stopped on shutdown get this long to record where they stopped
+	operationStopTimeout = 5 * time.Second
+	// how often the leader looks for unfinished operations without an owner
+	OperationResumeInterval = 30 * time.Second
+)
+
+var errOortTimeout = errors.New("oort didn't reply in time")
+
+// operationRunner keeps track of the operations running in the background, the copies of the service share it
+type operationRunner struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	mu       sync.Mutex
+	stopping bool
+	wg       sync.WaitGroup
+}
+
+func newOperationRunner() *operationRunner {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &operationRunner{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// add registers an operation about to be run, done has to be called once it stops,
+// ErrShuttingDown is returned once the runner is stopping
+func (r *operationRunner) add() (ctx context.Context, done func(), err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopping {
+		return nil, nil, domain.ErrShuttingDown
+	}
+	r.wg.Add(1)
+	return r.ctx, r.wg.Done, nil
+}
+
+// stop waits for the running operations until ctx is done, and then stops them at their current step
+func (r *operationRunner) stop(ctx context.Context) {
+	r.mu.Lock()
+	r.stopping = true
+	r.mu.Unlock()
+	stopped := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return
+	case <-ctx.Done():
+	}
+	r.cancel()
+	select {
+	case <-stopped:
+	case <-time.After(operationStopTimeout):
+		log.Println("operations didn't record their progress in time")
+	}
+}
+
+// StopOperations lets the operations running in the background finish until ctx is done,
+// the ones still running after that stop at their current step and are resumed by another replica
+func (n *NodeService) StopOperations(ctx context.Context) {
+	n.operations.stop(ctx)
+}
+
+func (n *NodeService) GetOperation(ctx context.Context, req domain.GetOperationReq) (*domain.GetOperationResp, error) {
+	if !n.authorizer.Authorize(ctx, "node.get", "org", req.Org) {
+		return nil, domain.ErrForbidden
+	}
+	operation, err := n.operationRepo.Get(req.Id, req.Org)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.GetOperationResp{
+		Operation: *operation,
+	}, nil
+}
+
+func (n *NodeService) ListOperations(ctx context.Context, req domain.ListOperationsReq) (*domain.ListOperationsResp, error) {
+	if !n.authorizer.Authorize(ctx, "node.get", "org", req.Org) {
+		return nil, domain.ErrForbidden
+	}
+	operations, nextPageToken, err := n.operationRepo.List(req.Org, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ListOperationsResp{
+		Operations:    operations,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// GetClaimSaga returns a claim operation, the sagas claims used to run as are now claim operations
+func (n *NodeService) GetClaimSaga(ctx context.Context, req domain.GetClaimSagaReq) (*domain.GetClaimSagaResp, error) {
+	if !n.authorizer.Authorize(ctx, "node.get", "org", req.Org) {
+		return nil, domain.ErrForbidden
+	}
+	operation, err := n.operationRepo.Get(req.Id, req.Org)
+	if err != nil {
+		return nil, err
+	}
+	if operation.Kind != domain.OperationClaim {
+		return nil, domain.ErrOperationNotFound
+	}
+	return &domain.GetClaimSagaResp{
+		Operation: *operation,
+	}, nil
+}
+
+// CancelOperation stops the operation at its next step,
+// the nodes a cancelled claim has already claimed are compensated, the ones a release has released stay released
+func (n *NodeService) CancelOperation(ctx context.Context, req domain.CancelOperationReq) (*domain.CancelOperationResp, error) {
+	if !n.authorizer.Authorize(ctx, "node.put", "org", req.Org) {
+		return nil, domain.ErrForbidden
+	}
+	operation, err := n.operationRepo.Get(req.Id, req.Org)
+	if err != nil {
+		return nil, err
+	}
+	if operation.Finished() {
+		return nil, domain.ErrOperationFinished
+	}
+	err = n.operationRepo.Cancel(req.Id, req.Org)
+	if err != nil {
+		return nil, err
+	}
+	operation.CancelRequested = true
+	return &domain.CancelOperationResp{
+		Operation: *operation,
+	}, nil
+}
+
+// ResumeOperations finishes the unfinished operations no one owns, since the replica running them has stopped,
+// once ctx is done the operation being resumed stops at its current step and no more operations are resumed
+func (n *NodeService) ResumeOperations(ctx context.Context) error {
+	operations, err := n.operationRepo.ListUnfinished()
+	if err != nil {
+		return err
+	}
+	for _, operation := range operations {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := n.resumeOperation(ctx, operation); err != nil {
+			log.Println(err)
+		}
+	}
+	return nil
+}
+
+// resumeOperation runs the operation from its last recorded step unless someone else owns it
+func (n *NodeService) resumeOperation(ctx context.Context, operation domain.Operation) error {
+	owned, release, err := n.operationRepo.Own(ctx, operation.Id, operation.Org)
+	if errors.Is(err, domain.ErrOperationOwned) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer release()
+	// the previous owner might have recorded more progress after the operation was listed
+	stored, err := n.operationRepo.Get(operation.Id, operation.Org)
+	if err != nil {
+		return err
+	}
+	if stored.Finished() {
+		return nil
+	}
+	log.Printf("resuming %s operation %s of org %s\n", stored.Kind, stored.Id, stored.Org)
+	switch stored.Kind {
+	case domain.OperationClaim:
+		return n.runClaimSaga(owned, stored, nil)
+	case domain.OperationRelease:
+		return n.runReleaseOperation(owned, stored)
+	}
+	return nil
+}
+
+// startOperation records the operation on the nodes and runs it in the background,
+// the operation is owned before it is recorded, so that it isn't resumed while it is running,
+// the returned copy is the operation as it was recorded
+func (n *NodeService) startOperation(kind domain.OperationKind, org string, requirements domain.ClaimRequirements, nodeIds []domain.NodeId, run func(ctx context.Context, operation *domain.Operation) error) (*domain.Operation, error) {
+	// time ordered ids list the most recent operations first
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	runCtx, done, err := n.operations.add()
+	if err != nil {
+		return nil, err
+	}
+	owned, release, err := n.operationRepo.Own(runCtx, id.String(), org)
+	if err != nil {
+		done()
+		return nil, err
+	}
+	now := time.Now().UTC()
+	operation := &domain.Operation{
+		Id:           id.String(),
+		Org:          org,
+		Kind:         kind,
+		State:        domain.OperationRunning,
+		Step:         domain.OperationStepNone,
+		Nodes:        pendingOperationNodes(nodeIds),
+		Requirements: requirements,
+		CreatedAt:    now,
+	}
+	err = n.saveOperation(operation)
+	if err != nil {
+		release()
+		done()
+		return nil, err
+	}
+	recorded := *operation
+	recorded.Nodes = slices.Clone(operation.Nodes)
+	go func() {
+		defer done()
+		defer release()
+		if err := run(owned, operation); err != nil {
+			log.Println(err)
+		}
+	}()
+	return &recorded, nil
+}
+
+func pendingOperationNodes(nodeIds []domain.NodeId) []domain.OperationNode {
+	nodes := make([]domain.OperationNode, 0, len(nodeIds))
+	for _, nodeId := range nodeIds {
+		nodes = append(nodes, domain.OperationNode{
+			NodeId: nodeId,
+			Status: domain.NodeOperationPending,
+		})
+	}
+	return nodes
+}
+
+// saveOperation records the progress, the operation is taken over by someone else if it has been modified
+func (n *NodeService) saveOperation(operation *domain.Operation) error {
+	operation.UpdatedAt = time.Now().UTC()
+	_, err := retry(context.Background(), func() error {
+		saved, err := n.operationRepo.Put(*operation)
+		if err != nil {
+			return err
+		}
+		operation.Revision = saved.Revision
+		return nil
+	})
+	return err
+}
+
+// cancelRequested checks whether the operation has been cancelled since it was read
+func (n *NodeService) cancelRequested(operation *domain.Operation) bool {
+	if operation.CancelRequested {
+		return true
+	}
+	stored, err := n.operationRepo.Get(operation.Id, operation.Org)
+	if err != nil {
+		log.Println(err)
+		return false
+	}
+	operation.CancelRequested = stored.CancelRequested
+	return operation.CancelRequested
+}
+
+// cancelPendingNodes marks the nodes the operation hasn't got to as cancelled
+func cancelPendingNodes(operation *domain.Operation) {
+	for _, nodeId := range operation.NodesWithStatus(domain.NodeOperationPending) {
+		node, _ := operation.Node(nodeId)
+		node.Status = domain.NodeOperationCancelled
+	}
+}
+
+// stopOperation leaves the operation at its last recorded step, since its owner is stopping or has lost it,
+// it is resumed from that step by whoever owns it next
+func stopOperation(ctx context.Context, operation *domain.Operation) error {
+	return fmt.Errorf("%s operation %s of org %s stopped at step %s: %w", operation.Kind, operation.Id, operation.Org, operation.Step, context.Cause(ctx))
+}
+
+// sendOortRequest waits for oort to process the request, so that its failures can be retried
+func (n *NodeService) sendOortRequest(ctx context.Context, req any) error {
+	reply := make(chan string, 1)
+	err := n.administrator.SendRequest(req, func(resp *oortapi.AdministrationAsyncResp) {
+		select {
+		case reply <- resp.Error:
+		default:
+		}
+	})
+	if err != nil {
+		return err
+	}
+	select {
+	case errMsg := <-reply:
+		if errMsg != "" {
+			return errors.New(errMsg)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(externalCallTimeout):
+		return errOortTimeout
+	}
+}
+
+// retry runs the step until it succeeds, fails with an error retrying won't fix, runs out of attempts or ctx is done,
+// the delay between attempts doubles up to operationStepMaxBackoff
+func retry(ctx context.Context, step func() error) (int32, error) {
+	backoff := operationStepBackoff
+	for attempt := int32(1); ; attempt++ {
+		if ctx.Err() != nil {
+			return attempt - 1, ctx.Err()
+		}
+		err := step()
+		if err == nil || !retryable(err) || attempt == operationStepAttempts {
+			return attempt, err
+		}
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, operationStepMaxBackoff)
+	}
+}
+
+func retryable(err error) bool {
+	return !errors.Is(err, domain.ErrNodeClaimed) &&
+		!errors.Is(err, domain.ErrNodeNotFound) &&
+		!errors.Is(err, domain.ErrOperationModified)
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/c12s/magnetar/internal/domain"
+)
+
+func TestGetClaimSaga(t *testing.T) {
+	service, _ := newTestNodeService(t)
+	for _, operation := range []domain.Operation{
+		{Id: "claim", Org: "org1", Kind: domain.OperationClaim, State: domain.OperationSucceeded, Step: domain.OperationStepJoin},
+		{Id: "release", Org: "org1", Kind: domain.OperationRelease, State: domain.OperationSucceeded, Step: domain.OperationStepQuotas},
+	} {
+		if _, err := service.operationRepo.Put(operation); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx := authorizedCtx(t, "node.get|org|org1")
+
+	tests := []struct {
+		name string
+		req  domain.GetClaimSagaReq
+		err  error
+	}{
+		{"Claim", domain.GetClaimSagaReq{Id: "claim", Org: "org1"}, nil},
+		{"Release", domain.GetClaimSagaReq{Id: "release", Org: "org1"}, domain.ErrOperationNotFound},
+		{"Unknown", domain.GetClaimSagaReq{Id: "unknown", Org: "org1"}, domain.ErrOperationNotFound},
+		{"Unauthorized", domain.GetClaimSagaReq{Id: "claim", Org: "org2"}, domain.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.GetClaimSaga(ctx, tt.req)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got error %v, want %v", err, tt.err)
+			}
+			if err == nil && resp.Operation.Id != tt.req.Id {
+				t.Errorf("got operation %s, want %s", resp.Operation.Id, tt.req.Id)
+			}
+		})
+	}
+}
+
+func TestStopOperations(t *testing.T) {
+	service, _ := newTestNodeService(t)
+	stopped := make(chan struct{})
+	operation, err := service.startOperation(domain.OperationRelease, "org1", domain.ClaimRequirements{}, nil, func(ctx context.Context, operation *domain.Operation) error {
+		<-ctx.Done()
+		close(stopped)
+		return stopOperation(ctx, operation)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	service.StopOperations(ctx)
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("expected the running operation to be stopped before StopOperations returns")
+	}
+	_, release, err := service.operationRepo.Own(context.Background(), operation.Id, operation.Org)
+	if err != nil {
+		t.Fatalf("expected a stopped operation to be released, got %v", err)
+	}
+	release()
+	_, err = service.startOperation(domain.OperationRelease, "org1", domain.ClaimRequirements{}, nil, func(ctx context.Context, operation *domain.Operation) error {
+		return nil
+	})
+	if !errors.Is(err, domain.ErrShuttingDown) {
+		t.Errorf("expected no operations to start once stopping, got %v", err)
+	}
+}
+
+func TestResumeOperations(t *testing.T) {
+	service, _ := newTestNodeService(t)
+	// a release without nodes finishes without calling oort, meridian or gravity
+	operation := domain.Operation{Id: "op1", Org: "org1", Kind: domain.OperationRelease, State: domain.OperationRunning}
+	if _, err := service.operationRepo.Put(operation); err != nil {
+		t.Fatal(err)
+	}
+	_, release, err := service.operationRepo.Own(context.Background(), operation.Id, operation.Org)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.ResumeOperations(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if stored, err := service.operationRepo.Get(operation.Id, operation.Org); err != nil || stored.State != domain.OperationRunning {
+		t.Fatalf("expected an owned operation not to be resumed, got %+v, %v", stored, err)
+	}
+	release()
+	if err := service.ResumeOperations(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if stored, err := service.operationRepo.Get(operation.Id, operation.Org); err != nil || stored.State != domain.OperationSucceeded {
+		t.Errorf("expected the operation to be resumed once released, got %+v, %v", stored, err)
+	}
+}
+
+func TestResumedOperationStopsWithCtx(t *testing.T) {
+	service, nodeRepo := newTestNodeService(t)
+	if err := nodeRepo.Put(newTestNode("n1")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nodeRepo.Claim(domain.NodeId{Value: "n1"}, "org1"); err != nil {
+		t.Fatal(err)
+	}
+	operation := domain.Operation{
+		Id:    "op1",
+		Org:   "org1",
+		Kind:  domain.OperationRelease,
+		State: domain.OperationRunning,
+		Nodes: pendingOperationNodes([]domain.NodeId{{Value: "n1"}}),
+	}
+	if _, err := service.operationRepo.Put(operation); err != nil {
+		t.Fatal(err)
+	}
+	// leadership lost before the release step
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := service.resumeOperation(ctx, operation); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the operation to stop, got %v", err)
+	}
+	stored, err := service.operationRepo.Get(operation.Id, operation.Org)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.State != domain.OperationRunning || stored.Nodes[0].Status != domain.NodeOperationPending || stored.Nodes[0].Err != "" {
+		t.Errorf("expected the operation to be left as recorded, got %+v", stored)
+	}
+	if _, err := nodeRepo.Get(domain.NodeId{Value: "n1"}, "org1"); err != nil {
+		t.Errorf("expected the node to stay claimed, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	gravity_api "github.com/c12s/agent_queue/pkg/api"
+	"github.com/c12s/magnetar/internal/domain"
+	meridian_api "github.com/c12s/meridian/pkg/api"
+	oortapi "github.com/c12s/oort/pkg/api"
+	"golang.org/x/exp/maps"
+)
+
+// changes made to oort, meridian and gravity on behalf of an org go through these,
+// so that the org cluster record the reconciler compares against stays up to date,
+// each call is bounded by externalCallTimeout
+
+func (n *NodeService) relateNode(ctx context.Context, org string, nodeId domain.NodeId) error {
+	err := n.sendOortRequest(ctx, &oortapi.CreateInheritanceRelReq{
+		From: &oortapi.Resource{Id: org, Kind: "org"},
+		To:   &oortapi.Resource{Id: nodeId.Value, Kind: "node"},
+	})
+	if err != nil {
+		return err
+	}
+	n.updateOrgCluster(org, func(cluster *domain.OrgCluster) {
+		cluster.SetRelated(nodeId, true)
+	})
+	return nil
+}
+
+func (n *NodeService) unrelateNode(ctx context.Context, org string, nodeId domain.NodeId) error {
+	err := n.sendOortRequest(ctx, &oortapi.DeleteInheritanceRelReq{
+		From: &oortapi.Resource{Id: org, Kind: "org"},
+		To:   &oortapi.Resource{Id: nodeId.Value, Kind: "node"},
+	})
+	if err != nil {
+		return err
+	}
+	n.updateOrgCluster(org, func(cluster *domain.OrgCluster) {
+		cluster.SetRelated(nodeId, false)
+	})
+	return nil
+}
+
+func (n *NodeService) joinCluster(ctx context.Context, org string, nodeId domain.NodeId, joinAddress string) error {
+	ctx, cancel := context.WithTimeout(ctx, externalCallTimeout)
+	defer cancel()
+	_, err := n.gravity.JoinCluster(ctx, &gravity_api.JoinClusterRequest{
+		NodeId:      nodeId.Value,
+		JoinAddress: joinAddress,
+		ClusterId:   org,
+	})
+	if err != nil {
+		return err
+	}
+	n.updateOrgCluster(org, func(cluster *domain.OrgCluster) {
+		cluster.SetJoined(nodeId, true)
+	})
+	return nil
+}
+
+func (n *NodeService) leaveCluster(ctx context.Context, org string, nodeId domain.NodeId) error {
+	ctx, cancel := context.WithTimeout(ctx, externalCallTimeout)
+	defer cancel()
+	_, err := n.gravity.LeaveCluster(ctx, &gravity_api.LeaveClusterRequest{
+		NodeId:    nodeId.Value,
+		ClusterId: org,
+	})
+	if err != nil {
+		return err
+	}
+	n.updateOrgCluster(org, func(cluster *domain.OrgCluster) {
+		cluster.SetJoined(nodeId, false)
+	})
+	return nil
+}
+
+// addNamespace creates the org's default namespace with the given quotas
+func (n *NodeService) addNamespace(ctx context.Context, org string, quotas map[string]float64) error {
+	ctx, cancel := context.WithTimeout(ctx, externalCallTimeout)
+	defer cancel()
+	_, err := n.meridian.AddNamespace(ctx, &meridian_api.AddNamespaceReq{
+		OrgId:                     org,
+		Name:                      "default",
+		Labels:                    make(map[string]string),
+		Quotas:                    quotas,
+		SeccompDefinitionStrategy: "redefine",
+		Profile: &meridian_api.SeccompProfile{
+			Version:       "v1.0.0",
+			DefaultAction: "ALLOW",
+			Syscalls:      make([]*meridian_api.SyscallRule, 0),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	n.recordNamespaceQuotas(org, quotas)
+	return nil
+}
+
+func (n *NodeService) setNamespaceQuotas(ctx context.Context, org string, quotas map[string]float64) error {
+	ctx, cancel := context.WithTimeout(ctx, externalCallTimeout)
+	defer cancel()
+	_, err := n.meridian.SetNamespaceResources(ctx, &meridian_api.SetNamespaceResourcesReq{
+		OrgId:  org,
+		Name:   "default",
+		Quotas: quotas,
+	})
+	if err != nil {
+		return err
+	}
+	n.recordNamespaceQuotas(org, quotas)
+	return nil
+}
+
+func (n *NodeService) recordNamespaceQuotas(org string, quotas map[string]float64) {
+	n.updateOrgCluster(org, func(cluster *domain.OrgCluster) {
+		cluster.Quotas = maps.Clone(quotas)
+		if cluster.Quotas == nil {
+			cluster.Quotas = make(map[string]float64)
+		}
+	})
+}
+
+// updateOrgCluster applies the change to the latest org cluster record,
+// failing to record it is only logged, the reconciler will report the change as drift and redo it
+func (n *NodeService) updateOrgCluster(org string, update func(cluster *domain.OrgCluster)) {
+	_, err := retry(context.Background(), func() error {
+		cluster, err := n.orgClusterRepo.Get(org)
+		if err != nil {
+			return err
+		}
+		prev := cluster.Clone()
+		update(cluster)
+		// the reconciler asserts recorded relations and members again, which leaves the record as it is
+		if cluster.Equal(prev) {
+			return nil
+		}
+		// a concurrent update is retried with the record read again
+		_, err = n.orgClusterRepo.Put(*cluster)
+		return err
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
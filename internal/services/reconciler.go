@@ -0,0 +1,262 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+
+	"github.com/c12s/magnetar/internal/domain"
+	"golang.org/x/exp/maps"
+)
+
+// the reconciler makes sure meridian, oort and gravity have what an org's nodes require,
+// the namespace is compared with what meridian reports, while oort and gravity can't list relations and members,
+// so every relation and membership the org's nodes require is asserted again on each run, the calls being idempotent,
+// and the ones magnetar recorded for nodes the org no longer owns are undone,
+// orgs with a claim or release in progress are left to the operation,
+// orgs that claimed nodes before their clusters were recorded get a record of their nodes first,
+// so that their relations and members aren't reported as drift
+
+// Reconcile fixes the drift of all orgs that own nodes or used to, the drift that couldn't be fixed is reported with its error,
+// once ctx is done the remaining orgs are skipped
+func (n *NodeService) Reconcile(ctx context.Context) (*domain.ReconcileReport, error) {
+	orgs, err := n.reconciledOrgs()
+	if err != nil {
+		return nil, err
+	}
+	operations, err := n.operationRepo.ListUnfinished()
+	if err != nil {
+		return nil, err
+	}
+	busy := make(map[string]bool)
+	for _, operation := range operations {
+		busy[operation.Org] = true
+	}
+	report := &domain.ReconcileReport{
+		Reconciled: make([]string, 0),
+		Backfilled: make([]string, 0),
+		Skipped:    make([]string, 0),
+		Drift:      make([]domain.Drift, 0),
+	}
+	for _, org := range orgs {
+		if busy[org] || ctx.Err() != nil {
+			report.Skipped = append(report.Skipped, org)
+			continue
+		}
+		drift, backfilled, err := n.reconcileOrg(ctx, org)
+		if err != nil {
+			log.Printf("reconciling org %s: %s\n", org, err)
+			report.Skipped = append(report.Skipped, org)
+			continue
+		}
+		if backfilled {
+			report.Backfilled = append(report.Backfilled, org)
+		}
+		report.Reconciled = append(report.Reconciled, org)
+		report.Drift = append(report.Drift, drift...)
+	}
+	return report, nil
+}
+
+// reconciledOrgs returns the orgs owning nodes and the ones with a record of their cluster, which might have released all nodes since
+func (n *NodeService) reconciledOrgs() ([]string, error) {
+	nodes, _, err := n.nodeRepo.ListAllNodes(domain.Page{})
+	if err != nil {
+		return nil, err
+	}
+	recorded, err := n.orgClusterRepo.ListOrgs()
+	if err != nil {
+		return nil, err
+	}
+	orgs := make(map[string]bool)
+	for _, node := range nodes {
+		if node.Claimed() {
+			orgs[node.Org] = true
+		}
+	}
+	for _, org := range recorded {
+		orgs[org] = true
+	}
+	sorted := maps.Keys(orgs)
+	sort.Strings(sorted)
+	return sorted, nil
+}
+
+// reconcileOrg reports whether the org's record had to be backfilled,
+// the record is read before the nodes, so that nodes claimed in between aren't taken for stale ones,
+// and each correction is made only if it is still needed once no operation of the org is running
+func (n *NodeService) reconcileOrg(ctx context.Context, org string) ([]domain.Drift, bool, error) {
+	cluster, err := n.orgClusterRepo.Get(org)
+	if err != nil {
+		return nil, false, err
+	}
+	nodes, _, err := n.nodeRepo.ListOrgOwnedNodes(org, domain.Page{})
+	if err != nil {
+		return nil, false, err
+	}
+	backfilled := !cluster.Recorded()
+	if backfilled {
+		cluster, err = n.backfillOrgCluster(ctx, org, nodes)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	drift := make([]domain.Drift, 0)
+	namespaceDrift, err := n.reconcileNamespace(ctx, *cluster, nodes)
+	if err != nil {
+		return nil, false, err
+	}
+	if namespaceDrift != nil {
+		drift = append(drift, *namespaceDrift)
+	}
+
+	owned := make(map[domain.NodeId]bool)
+	for _, node := range nodes {
+		owned[node.Id] = true
+	}
+	// relations and members are asserted again even if recorded, in case they were removed in oort or gravity directly
+	correct := func(kind domain.DriftKind, nodeId domain.NodeId, recorded bool, fix func() error) {
+		err := n.reconcileNode(org, nodeId, owned[nodeId], fix)
+		if errors.Is(err, errReconcileSkipped) {
+			return
+		}
+		if !recorded || err != nil {
+			drift = append(drift, domain.Drift{
+				Org:    org,
+				NodeId: nodeId,
+				Kind:   kind,
+				Err:    err,
+			})
+		}
+	}
+	for _, node := range nodes {
+		correct(domain.DriftRelationMissing, node.Id, cluster.IsRelated(node.Id), func() error {
+			return n.relateNode(ctx, org, node.Id)
+		})
+	}
+	for _, nodeId := range cluster.Related {
+		if !owned[nodeId] {
+			correct(domain.DriftStaleRelation, nodeId, false, func() error {
+				return n.unrelateNode(ctx, org, nodeId)
+			})
+		}
+	}
+
+	address := reconcileJoinAddress(*cluster, nodes)
+	for _, node := range nodes {
+		correct(domain.DriftNotJoined, node.Id, cluster.IsJoined(node.Id), func() error {
+			return n.joinCluster(ctx, org, node.Id, address)
+		})
+	}
+	for _, nodeId := range cluster.Joined {
+		if !owned[nodeId] {
+			correct(domain.DriftStaleMember, nodeId, false, func() error {
+				return n.leaveCluster(ctx, org, nodeId)
+			})
+		}
+	}
+	return drift, backfilled, nil
+}
+
+var errReconcileSkipped = errors.New("org or node changed since read")
+
+// reconcileNode runs the fix unless an operation of the org has started or the node has been claimed or released since it was read,
+// errReconcileSkipped is returned in that case and the node is reconciled on the next run
+func (n *NodeService) reconcileNode(org string, nodeId domain.NodeId, owned bool, fix func() error) error {
+	busy, err := n.operationRepo.HasUnfinished(org)
+	if err != nil {
+		return err
+	}
+	_, err = n.nodeRepo.Get(nodeId, org)
+	if err != nil && !errors.Is(err, domain.ErrNodeNotFound) {
+		return err
+	}
+	if busy || (err == nil) != owned {
+		return errReconcileSkipped
+	}
+	return fix()
+}
+
+// backfillOrgCluster records the org's nodes as related and joined and the namespace quotas meridian reports,
+// nothing is sent to oort, meridian or gravity
+func (n *NodeService) backfillOrgCluster(ctx context.Context, org string, nodes []domain.Node) (*domain.OrgCluster, error) {
+	cluster := domain.OrgCluster{Org: org}
+	for _, node := range nodes {
+		cluster.SetRelated(node.Id, true)
+		cluster.SetJoined(node.Id, true)
+	}
+	namespace, err := n.getNamespace(ctx, org)
+	if err != nil && !namespaceNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		cluster.Quotas = maps.Clone(namespace.GetQuotas())
+		if cluster.Quotas == nil {
+			cluster.Quotas = make(map[string]float64)
+		}
+	}
+	// fails if a claim has recorded the cluster in the meantime, the org is reconciled on the next run
+	return n.orgClusterRepo.Put(cluster)
+}
+
+// reconcileNamespace makes sure the org's default namespace exists and that the quotas meridian reports
+// add up to the nodes' resources, an error is returned only if the namespace couldn't be checked
+func (n *NodeService) reconcileNamespace(ctx context.Context, cluster domain.OrgCluster, nodes []domain.Node) (*domain.Drift, error) {
+	// the namespace is created with the first claim
+	if len(nodes) == 0 && cluster.Quotas == nil {
+		return nil, nil
+	}
+	namespace, err := n.getNamespace(ctx, cluster.Org)
+	if err != nil && !namespaceNotFound(err) {
+		return nil, err
+	}
+	kind := domain.DriftNamespaceMissing
+	if err == nil {
+		if maps.Equal(namespace.GetQuotas(), namespaceQuotas(nodes)) {
+			return nil, nil
+		}
+		kind = domain.DriftQuotas
+	}
+	err = n.reconcileQuotas(cluster.Org, func(quotas map[string]float64) error {
+		if kind == domain.DriftNamespaceMissing {
+			return n.addNamespace(ctx, cluster.Org, quotas)
+		}
+		return n.setNamespaceQuotas(ctx, cluster.Org, quotas)
+	})
+	if errors.Is(err, errReconcileSkipped) {
+		return nil, nil
+	}
+	return &domain.Drift{
+		Org:  cluster.Org,
+		Kind: kind,
+		Err:  err,
+	}, nil
+}
+
+// reconcileQuotas runs the fix with the quotas of the nodes the org owns now, unless an operation of the org has started
+func (n *NodeService) reconcileQuotas(org string, fix func(quotas map[string]float64) error) error {
+	busy, err := n.operationRepo.HasUnfinished(org)
+	if err != nil {
+		return err
+	}
+	if busy {
+		return errReconcileSkipped
+	}
+	nodes, _, err := n.nodeRepo.ListOrgOwnedNodes(org, domain.Page{})
+	if err != nil {
+		return err
+	}
+	return fix(namespaceQuotas(nodes))
+}
+
+// reconcileJoinAddress prefers nodes that have already joined the org's cluster
+func reconcileJoinAddress(cluster domain.OrgCluster, nodes []domain.Node) string {
+	joined := make([]domain.Node, 0)
+	for _, node := range nodes {
+		if cluster.IsJoined(node.Id) {
+			joined = append(joined, node)
+		}
+	}
+	return joinAddress(joined, nodes)
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gravity_api "github.com/c12s/agent_queue/pkg/api"
+	"github.com/c12s/magnetar/internal/domain"
+	meridian_api "github.com/c12s/meridian/pkg/api"
+	oortapi "github.com/c12s/oort/pkg/api"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc"
+)
+
+// externalCalls records the calls made to the fakes of oort, meridian and gravity in order,
+// during is run on every call, e.g. to change the nodes while the org is reconciled
+type externalCalls struct {
+	calls  []string
+	during func(call string)
+}
+
+func (e *externalCalls) record(call string) {
+	e.calls = append(e.calls, call)
+	if e.during != nil {
+		e.during(call)
+	}
+}
+
+type fakeOort struct {
+	*externalCalls
+}
+
+func (o fakeOort) SendRequest(req interface{}, callback func(resp *oortapi.AdministrationAsyncResp)) error {
+	switch req := req.(type) {
+	case *oortapi.CreateInheritanceRelReq:
+		o.record("CreateInheritanceRel " + req.To.Id)
+	case *oortapi.DeleteInheritanceRelReq:
+		o.record("DeleteInheritanceRel " + req.To.Id)
+	}
+	callback(&oortapi.AdministrationAsyncResp{})
+	return nil
+}
+
+type fakeMeridian struct {
+	meridian_api.MeridianClient
+	*externalCalls
+	// nil if the namespace doesn't exist
+	quotas map[string]float64
+}
+
+func (m *fakeMeridian) GetNamespace(ctx context.Context, in *meridian_api.GetNamespaceReq, opts ...grpc.CallOption) (*meridian_api.GetNamespaceResp, error) {
+	if m.quotas == nil {
+		return nil, errors.New("namespace not found")
+	}
+	return &meridian_api.GetNamespaceResp{Quotas: m.quotas}, nil
+}
+
+func (m *fakeMeridian) AddNamespace(ctx context.Context, in *meridian_api.AddNamespaceReq, opts ...grpc.CallOption) (*meridian_api.AddNamespaceResp, error) {
+	m.record("AddNamespace")
+	m.quotas = maps.Clone(in.Quotas)
+	return &meridian_api.AddNamespaceResp{}, nil
+}
+
+func (m *fakeMeridian) SetNamespaceResources(ctx context.Context, in *meridian_api.SetNamespaceResourcesReq, opts ...grpc.CallOption) (*meridian_api.SetNamespaceResourcesResp, error) {
+	m.record("SetNamespaceResources")
+	m.quotas = maps.Clone(in.Quotas)
+	return &meridian_api.SetNamespaceResourcesResp{}, nil
+}
+
+type fakeGravity struct {
+	gravity_api.AgentQueueClient
+	*externalCalls
+}
+
+func (g fakeGravity) JoinCluster(ctx context.Context, in *gravity_api.JoinClusterRequest, opts ...grpc.CallOption) (*gravity_api.JoinClusterResponse, error) {
+	g.record("JoinCluster " + in.NodeId)
+	return &gravity_api.JoinClusterResponse{}, nil
+}
+
+func (g fakeGravity) LeaveCluster(ctx context.Context, in *gravity_api.LeaveClusterRequest, opts ...grpc.CallOption) (*gravity_api.LeaveClusterResponse, error) {
+	g.record("LeaveCluster " + in.NodeId)
+	return &gravity_api.LeaveClusterResponse{}, nil
+}
+
+func TestReconcile(t *testing.T) {
+	n1, n2 := domain.NodeId{Value: "n1"}, domain.NodeId{Value: "n2"}
+	// relations and members the org's nodes require are asserted on every run
+	asserted := []string{"CreateInheritanceRel n1", "JoinCluster n1"}
+	tests := []struct {
+		name string
+		// nil if the org's cluster isn't recorded
+		cluster *domain.OrgCluster
+		quotas  map[string]float64
+		busy    bool
+		// run on every call to oort, meridian or gravity
+		during     func(t *testing.T, nodeRepo domain.NodeRepo, call string)
+		drift      []domain.DriftKind
+		backfilled bool
+		calls      []string
+	}{
+		{
+			name:       "Backfilled",
+			quotas:     map[string]float64{"cpu": 4},
+			drift:      []domain.DriftKind{},
+			backfilled: true,
+			calls:      asserted,
+		},
+		{
+			name:    "InSync",
+			cluster: &domain.OrgCluster{Related: []domain.NodeId{n1}, Joined: []domain.NodeId{n1}, Quotas: map[string]float64{"cpu": 4}},
+			quotas:  map[string]float64{"cpu": 4},
+			drift:   []domain.DriftKind{},
+			calls:   asserted,
+		},
+		{
+			// the record matches the nodes, but meridian's quotas were changed since
+			name:    "Quotas",
+			cluster: &domain.OrgCluster{Related: []domain.NodeId{n1}, Joined: []domain.NodeId{n1}, Quotas: map[string]float64{"cpu": 4}},
+			quotas:  map[string]float64{"cpu": 2},
+			drift:   []domain.DriftKind{domain.DriftQuotas},
+			calls:   append([]string{"SetNamespaceResources"}, asserted...),
+		},
+		{
+			name:    "NamespaceMissing",
+			cluster: &domain.OrgCluster{Related: []domain.NodeId{n1}, Joined: []domain.NodeId{n1}, Quotas: map[string]float64{"cpu": 4}},
+			drift:   []domain.DriftKind{domain.DriftNamespaceMissing},
+			calls:   append([]string{"AddNamespace"}, asserted...),
+		},
+		{
+			name:    "RelationMissing",
+			cluster: &domain.OrgCluster{Joined: []domain.NodeId{n1}, Quotas: map[string]float64{"cpu": 4}},
+			quotas:  map[string]float64{"cpu": 4},
+			drift:   []domain.DriftKind{domain.DriftRelationMissing},
+			calls:   asserted,
+		},
+		{
+			name:    "StaleRelation",
+			cluster: &domain.OrgCluster{Related: []domain.NodeId{n1, n2}, Joined: []domain.NodeId{n1}, Quotas: map[string]float64{"cpu": 4}},
+			quotas:  map[string]float64{"cpu": 4},
+			drift:   []domain.DriftKind{domain.DriftStaleRelation},
+			calls:   []string{"CreateInheritanceRel n1", "DeleteInheritanceRel n2", "JoinCluster n1"},
+		},
+		{
+			name:    "NotJoined",
+			cluster: &domain.OrgCluster{Related: []domain.NodeId{n1}, Quotas: map[string]float64{"cpu": 4}},
+			quotas:  map[string]float64{"cpu": 4},
+			drift:   []domain.DriftKind{domain.DriftNotJoined},
+			calls:   asserted,
+		},
+		{
+			name:    "StaleMember",
+			cluster: &domain.OrgCluster{Related: []domain.NodeId{n1}, Joined: []domain.NodeId{n1, n2}, Quotas: map[string]float64{"cpu": 4}},
+			quotas:  map[string]float64{"cpu": 4},
+			drift:   []domain.DriftKind{domain.DriftStaleMember},
+			calls:   append(slices.Clone(asserted), "LeaveCluster n2"),
+		},
+		{
+			// n2 has been released with its membership left behind, and claimed again once the nodes were read
+			name:    "ClaimedSinceRead",
+			cluster: &domain.OrgCluster{Related: []domain.NodeId{n1}, Joined: []domain.NodeId{n1, n2}, Quotas: map[string]float64{"cpu": 4}},
+			quotas:  map[string]float64{"cpu": 4},
+			during: func(t *testing.T, nodeRepo domain.NodeRepo, call string) {
+				if call != "CreateInheritanceRel n1" {
+					return
+				}
+				if _, err := nodeRepo.Claim(n2, "org1"); err != nil {
+					t.Fatal(err)
+				}
+			},
+			drift: []domain.DriftKind{},
+			calls: asserted,
+		},
+		{
+			name:    "Busy",
+			cluster: &domain.OrgCluster{Related: []domain.NodeId{n1}, Quotas: map[string]float64{"cpu": 4}},
+			busy:    true,
+			drift:   []domain.DriftKind{},
+			calls:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, nodeRepo := newTestNodeService(t)
+			calls := &externalCalls{calls: make([]string, 0)}
+			if tt.during != nil {
+				calls.during = func(call string) {
+					tt.during(t, nodeRepo, call)
+				}
+			}
+			service.administrator = fakeOort{calls}
+			service.meridian = &fakeMeridian{externalCalls: calls, quotas: maps.Clone(tt.quotas)}
+			service.gravity = fakeGravity{externalCalls: calls}
+			for _, node := range []domain.Node{newTestNode("n1"), newTestNode("n2")} {
+				if err := nodeRepo.Put(node); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := nodeRepo.Claim(n1, "org1"); err != nil {
+				t.Fatal(err)
+			}
+			if tt.cluster != nil {
+				cluster := *tt.cluster
+				cluster.Org = "org1"
+				if _, err := service.orgClusterRepo.Put(cluster); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.busy {
+				operation := domain.Operation{Id: "op1", Org: "org1", Kind: domain.OperationClaim, State: domain.OperationRunning}
+				if _, err := service.operationRepo.Put(operation); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			report, err := service.Reconcile(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			drift := make([]domain.DriftKind, 0)
+			for _, d := range report.Drift {
+				if d.Err != nil {
+					t.Errorf("expected drift %v to be fixed, got %v", d.Kind, d.Err)
+				}
+				drift = append(drift, d.Kind)
+			}
+			if !slices.Equal(drift, tt.drift) {
+				t.Errorf("got drift %v, want %v", drift, tt.drift)
+			}
+			if backfilled := slices.Contains(report.Backfilled, "org1"); backfilled != tt.backfilled {
+				t.Errorf("got backfilled %t, want %t", backfilled, tt.backfilled)
+			}
+			if skipped := slices.Contains(report.Skipped, "org1"); skipped != tt.busy {
+				t.Errorf("got skipped %t, want %t", skipped, tt.busy)
+			}
+			if !slices.Equal(calls.calls, tt.calls) {
+				t.Errorf("got calls %v, want %v", calls.calls, tt.calls)
+			}
+			if tt.busy || tt.during != nil {
+				return
+			}
+			cluster, err := service.orgClusterRepo.Get("org1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(cluster.Related, []domain.NodeId{n1}) || !slices.Equal(cluster.Joined, []domain.NodeId{n1}) || !maps.Equal(cluster.Quotas, map[string]float64{"cpu": 4}) {
+				t.Errorf("expected the record to match the org's nodes, got %+v", cluster)
+			}
+		})
+	}
+}
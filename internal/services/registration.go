@@ -1,6 +1,10 @@
 package services
 
 import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+
 	"github.com/c12s/magnetar/internal/domain"
 	"github.com/google/uuid"
 )
@@ -15,26 +19,101 @@ func NewRegistrationService(nodeRepo domain.NodeRepo) (*RegistrationService, err
 	}, nil
 }
 
+// Register is idempotent for requests carrying a node or machine id,
+// an already registered node is updated in place and stays in its org,
+// but only for agents holding the registration token issued when the node was first registered,
+// a node registered concurrently under the same id is looked up again, so only one of the registrations creates it
 func (r *RegistrationService) Register(req domain.RegistrationReq) (*domain.RegistrationResp, error) {
+	nodeId, err := resolveNodeId(req)
+	if err != nil {
+		return nil, err
+	}
 	node := domain.Node{
 		Id: domain.NodeId{
-			Value: generateNodeId(),
+			Value: nodeId,
 		},
-		Labels:    req.Labels,
-		Resources: req.Resources,
+		Labels:      req.Labels,
+		Resources:   req.Resources,
 		BindAddress: req.BindAddress,
 	}
+	if err := domain.ValidateNode(node); err != nil {
+		return nil, err
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := r.register(req, node)
+		if !errors.Is(err, domain.ErrNodeExists) || attempt == registerAttempts {
+			return resp, err
+		}
+	}
+}
 
-	err := r.nodeRepo.Put(node)
-	if err != nil {
+const registerAttempts = 3
+
+func (r *RegistrationService) register(req domain.RegistrationReq, node domain.Node) (*domain.RegistrationResp, error) {
+	prev, err := r.nodeRepo.Find(node.Id)
+	if err != nil && !errors.Is(err, domain.ErrNodeNotFound) {
 		return nil, err
 	}
+	if prev != nil {
+		if !prev.HasRegistrationToken(req.RegistrationToken) {
+			return nil, domain.ErrForbidden
+		}
+		node.RegistrationTokenHash = prev.RegistrationTokenHash
+		_, err = r.nodeRepo.Update(*prev, node)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.RegistrationResp{
+			NodeId: node.Id.Value,
+		}, nil
+	}
+	// node ids are issued by magnetar, agents can't pick them
+	if req.NodeId != "" {
+		return nil, domain.ErrNodeNotFound
+	}
 
+	token, err := generateRegistrationToken()
+	if err != nil {
+		return nil, err
+	}
+	node.RegistrationTokenHash = domain.HashRegistrationToken(token)
+	err = r.nodeRepo.Create(node)
+	if err != nil {
+		return nil, err
+	}
 	return &domain.RegistrationResp{
-		NodeId: node.Id.Value,
+		NodeId:            node.Id.Value,
+		RegistrationToken: token,
 	}, nil
 }
 
+// machineIdNamespace scopes node ids derived from machine ids to magnetar
+var machineIdNamespace = uuid.MustParse("5b0c2d9e-5c0e-4f8a-9a36-0d7c1f6e8b42")
+
+// resolveNodeId only accepts node ids in the form they are issued in,
+// they are part of the node's keys, so anything else could break the key layout
+func resolveNodeId(req domain.RegistrationReq) (string, error) {
+	if req.NodeId != "" {
+		parsed, err := uuid.Parse(req.NodeId)
+		if err != nil || parsed.String() != req.NodeId {
+			return "", domain.ErrInvalidNodeId
+		}
+		return req.NodeId, nil
+	}
+	if req.MachineId != "" {
+		return uuid.NewSHA1(machineIdNamespace, []byte(req.MachineId)).String(), nil
+	}
+	return generateNodeId(), nil
+}
+
 func generateNodeId() string {
 	return uuid.NewString()
 }
+
+func generateRegistrationToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
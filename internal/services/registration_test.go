@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/c12s/magnetar/internal/domain"
+	"github.com/c12s/magnetar/internal/marshallers/proto"
+	"github.com/c12s/magnetar/internal/repos"
+)
+
+func TestRegister(t *testing.T) {
+	nodeRepo, err := repos.NewNodeInMemRepo(proto.NewProtoNodeMarshaller(), proto.NewProtoLabelMarshaller())
+	if err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewRegistrationService(nodeRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := service.Register(domain.RegistrationReq{MachineId: "m1", BindAddress: "10.0.0.1:7946"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.RegistrationToken == "" {
+		t.Fatal("expected a registration token for a new node")
+	}
+	if _, err := nodeRepo.Claim(domain.NodeId{Value: first.NodeId}, "org1"); err != nil {
+		t.Fatal(err)
+	}
+
+	tooManyResources := make(map[string]float64)
+	for i := 0; i <= domain.MaxNodeIndexKeys; i++ {
+		tooManyResources[fmt.Sprintf("r%d", i)] = 1
+	}
+	tests := []struct {
+		name string
+		req  domain.RegistrationReq
+		err  error
+	}{
+		{"NodeIdWithToken", domain.RegistrationReq{NodeId: first.NodeId, RegistrationToken: first.RegistrationToken}, nil},
+		{"MachineIdWithToken", domain.RegistrationReq{MachineId: "m1", RegistrationToken: first.RegistrationToken}, nil},
+		{"NodeIdWithoutToken", domain.RegistrationReq{NodeId: first.NodeId}, domain.ErrForbidden},
+		{"MachineIdWithoutToken", domain.RegistrationReq{MachineId: "m1"}, domain.ErrForbidden},
+		{"WrongToken", domain.RegistrationReq{NodeId: first.NodeId, RegistrationToken: "guessed"}, domain.ErrForbidden},
+		{"UnknownNodeId", domain.RegistrationReq{NodeId: "8c4e5b6a-0d43-4b59-9f0e-3c1a2b7d9e10"}, domain.ErrNodeNotFound},
+		{"KeySeparator", domain.RegistrationReq{NodeId: "org1/n1"}, domain.ErrInvalidNodeId},
+		{"NonCanonical", domain.RegistrationReq{NodeId: "{" + first.NodeId + "}"}, domain.ErrInvalidNodeId},
+		{"TooLarge", domain.RegistrationReq{NodeId: first.NodeId, RegistrationToken: first.RegistrationToken, Resources: tooManyResources}, domain.ErrNodeTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.BindAddress = "10.0.0.2:7946"
+			resp, err := service.Register(tt.req)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got error %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if resp.NodeId != first.NodeId || resp.RegistrationToken != "" {
+				t.Errorf("expected node %s to be registered again without a new token, got %+v", first.NodeId, resp)
+			}
+		})
+	}
+
+	node, err := nodeRepo.Get(domain.NodeId{Value: first.NodeId}, "org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.BindAddress != "10.0.0.2:7946" || !node.HasRegistrationToken(first.RegistrationToken) {
+		t.Errorf("expected the claimed node to be updated and keep its token, got %+v", node)
+	}
+}
+
+func TestRegisterConcurrently(t *testing.T) {
+	nodeRepo, err := repos.NewNodeInMemRepo(proto.NewProtoNodeMarshaller(), proto.NewProtoLabelMarshaller())
+	if err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewRegistrationService(nodeRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const agents = 8
+	tokens := make(chan string, agents)
+	wg := sync.WaitGroup{}
+	for i := 0; i < agents; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := service.Register(domain.RegistrationReq{MachineId: "m1", BindAddress: "10.0.0.1:7946"})
+			if err == nil {
+				tokens <- resp.RegistrationToken
+			} else if !errors.Is(err, domain.ErrForbidden) {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(tokens)
+	if len(tokens) != 1 {
+		t.Fatalf("expected a single registration to create the node, got %d", len(tokens))
+	}
+	nodes, _, err := nodeRepo.ListAllNodes(domain.Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || !nodes[0].HasRegistrationToken(<-tokens) {
+		t.Errorf("expected the node to keep the token issued to the registration that created it, got %+v", nodes)
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/c12s/magnetar/internal/domain"
+)
+
+// a release runs its steps node by node: release in etcd, delete the oort relation, leave the gravity cluster,
+// and then sets the namespace quotas once, nothing is compensated since releasing is itself the undo of a claim,
+// failures after a node has been released are only recorded
+
+// runReleaseOperation continues the release from its last recorded step until it is finished,
+// an error is returned only if the progress can't be recorded or ctx is done, which stops the release at its current step
+func (n *NodeService) runReleaseOperation(ctx context.Context, operation *domain.Operation) error {
+	// the release step of the operation covers all node steps
+	if operation.Step == domain.OperationStepNone {
+		for _, nodeId := range operation.NodesWithStatus(domain.NodeOperationPending) {
+			if n.cancelRequested(operation) {
+				cancelPendingNodes(operation)
+				break
+			}
+			if err := n.releaseOperationNode(ctx, operation, nodeId); err != nil {
+				return err
+			}
+		}
+		if err := n.completeOperationStep(operation, domain.OperationStepRelease); err != nil {
+			return err
+		}
+	}
+	if operation.Step == domain.OperationStepRelease && len(operation.NodesWithStatus(domain.NodeOperationReleased)) > 0 {
+		_, err := retry(ctx, func() error {
+			return n.syncNamespaceQuotas(ctx, operation.Org)
+		})
+		if ctx.Err() != nil {
+			return stopOperation(ctx, operation)
+		}
+		if err != nil {
+			log.Println(err)
+			operation.Err = fmt.Sprintf("setting namespace quotas: %s", err)
+		}
+		operation.Step = domain.OperationStepQuotas
+	}
+	switch {
+	case operation.CancelRequested:
+		operation.State = domain.OperationCancelled
+	case len(operation.NodesWithStatus(domain.NodeOperationReleased)) == 0 && len(operation.Nodes) > 0:
+		operation.State = domain.OperationFailed
+	default:
+		operation.State = domain.OperationSucceeded
+	}
+	return n.saveOperation(operation)
+}
+
+// releaseOperationNode runs the steps not yet completed for the node,
+// a node no longer owned by the org fails unless this operation recorded its release,
+// that includes a node released right before a restart interrupted the operation,
+// its oort relation and gravity membership are left to the reconciler
+func (n *NodeService) releaseOperationNode(ctx context.Context, operation *domain.Operation, nodeId domain.NodeId) error {
+	node, _ := operation.Node(nodeId)
+	steps := []struct {
+		step domain.OperationStep
+		run  func() error
+	}{
+		{domain.OperationStepRelease, func() error {
+			_, err := n.nodeRepo.Release(nodeId, operation.Org)
+			return err
+		}},
+		{domain.OperationStepUnrelate, func() error {
+			return n.unrelateNode(ctx, operation.Org, nodeId)
+		}},
+		{domain.OperationStepLeave, func() error {
+			return n.leaveCluster(ctx, operation.Org, nodeId)
+		}},
+	}
+	for _, step := range steps {
+		if node.Step >= step.step {
+			continue
+		}
+		attempts, err := retry(ctx, step.run)
+		if err != nil && ctx.Err() != nil {
+			return stopOperation(ctx, operation)
+		}
+		node.Attempts = attempts
+		if err != nil {
+			log.Println(err)
+			if step.step == domain.OperationStepRelease {
+				node.Status = domain.NodeOperationFailed
+				node.Err = err.Error()
+				return n.saveOperation(operation)
+			}
+			node.Err = fmt.Sprintf("%s: %s", step.step, err)
+		}
+		node.Step = step.step
+		if err := n.saveOperation(operation); err != nil {
+			return err
+		}
+	}
+	node.Status = domain.NodeOperationReleased
+	return n.saveOperation(operation)
+}
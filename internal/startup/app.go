@@ -6,6 +6,7 @@ import (
 	"log"
 	"net"
 	"sync"
+	"time"
 
 	gravity_api "github.com/c12s/agent_queue/pkg/api"
 	"github.com/c12s/magnetar/internal/configs"
@@ -31,19 +32,30 @@ type app struct {
 	grpcServer                *grpc.Server
 	magnetarServer            api.MagnetarServer
 	registrationServer        *servers.RegistrationAsyncServer
+	heartbeatServer           *servers.HeartbeatAsyncServer
+	deregistrationServer      *servers.DeregistrationAsyncServer
 	nodeService               *services.NodeService
 	labelService              *services.LabelService
 	authzService              services.AuthZService
 	registrationService       *services.RegistrationService
+	livenessService           *services.LivenessService
 	evaluatorClient           oortapi.OortEvaluatorClient
 	administratorClient       *oortapi.AdministrationAsyncClient
 	meridian                  meridian_api.MeridianClient
 	gravity                   gravity_api.AgentQueueClient
 	publisher                 messaging.Publisher
 	registrationSubscriber    messaging.Subscriber
+	heartbeatSubscriber       messaging.Subscriber
+	deregistrationSubscriber  messaging.Subscriber
 	nodeRepo                  domain.NodeRepo
+	operationRepo             domain.OperationRepo
+	orgClusterRepo            domain.OrgClusterRepo
+	livenessRepo              domain.NodeLivenessRepo
 	nodeMarshaller            domain.NodeMarshaller
 	labelMarshaller           domain.LabelMarshaller
+	operationMarshaller       domain.OperationMarshaller
+	orgClusterMarshaller      domain.OrgClusterMarshaller
+	leaderElection            *leaderElection
 	shutdownProcesses         []func()
 	gracefulShutdownProcesses []func(wg *sync.WaitGroup)
 }
@@ -66,6 +78,16 @@ func (a *app) Start() error {
 	if err != nil {
 		return err
 	}
+	err = a.startHeartbeatServer()
+	if err != nil {
+		return err
+	}
+	err = a.startDeregistrationServer()
+	if err != nil {
+		return err
+	}
+	a.startLeaderElection()
+	a.stopOperationsOnShutdown()
 	return a.startGrpcServer()
 }
 
@@ -121,22 +143,34 @@ func (a *app) init() {
 
 	a.initNatsPublisher(natsConn)
 	a.initRegistrationNatsSubscriber(natsConn)
+	a.initHeartbeatNatsSubscriber(natsConn)
+	a.initDeregistrationNatsSubscriber(natsConn)
 
 	a.initNodeProtoMarshaller()
 	a.initLabelProtoMarshaller()
+	a.initOperationProtoMarshaller()
+	a.initOrgClusterProtoMarshaller()
 	a.initNodeEtcdRepo(etcdClient)
+	a.initOperationEtcdRepo(etcdClient)
+	a.initOrgClusterEtcdRepo(etcdClient)
+	a.initNodeLivenessEtcdRepo(etcdClient)
 
 	a.initAdministratorClient()
 	a.initEvaluatorClient()
 	a.initMeridian()
 	a.initGravity()
 
+	a.initLeaderElection(etcdClient)
+
 	a.initAuthZService()
+	a.initLivenessService()
 	a.initNodeService()
 	a.initLabelService()
 	a.initRegistrationService()
 
 	a.initRegistrationServer()
+	a.initHeartbeatServer()
+	a.initDeregistrationServer()
 	a.initMagnetarServer()
 	a.initGrpcServer()
 }
@@ -145,7 +179,7 @@ func (a *app) initGrpcServer() {
 	if a.magnetarServer == nil {
 		log.Fatalln("magnetar server is nil")
 	}
-	s := grpc.NewServer(grpc.UnaryInterceptor(servers.GetAuthInterceptor()))
+	s := grpc.NewServer(grpc.UnaryInterceptor(servers.GetAuthInterceptor()), grpc.StreamInterceptor(servers.GetAuthStreamInterceptor()))
 	api.RegisterMagnetarServer(s, a.magnetarServer)
 	reflection.Register(s)
 	a.grpcServer = s
@@ -182,6 +216,34 @@ func (a *app) initRegistrationServer() {
 	a.registrationServer = server
 }
 
+func (a *app) initHeartbeatServer() {
+	if a.livenessService == nil {
+		log.Fatalln("liveness service is nil")
+	}
+	if a.heartbeatSubscriber == nil {
+		log.Fatalln("heartbeat subscriber is nil")
+	}
+	server, err := servers.NewHeartbeatAsyncServer(a.heartbeatSubscriber, *a.livenessService)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	a.heartbeatServer = server
+}
+
+func (a *app) initDeregistrationServer() {
+	if a.nodeService == nil {
+		log.Fatalln("node service is nil")
+	}
+	if a.deregistrationSubscriber == nil {
+		log.Fatalln("deregistration subscriber is nil")
+	}
+	server, err := servers.NewDeregistrationAsyncServer(a.deregistrationSubscriber, *a.nodeService)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	a.deregistrationServer = server
+}
+
 func (a *app) initRegistrationService() {
 	if a.nodeRepo == nil {
 		log.Fatalln("node repo is nil")
@@ -193,17 +255,40 @@ func (a *app) initRegistrationService() {
 	a.registrationService = registrationService
 }
 
+func (a *app) initLivenessService() {
+	if a.nodeRepo == nil {
+		log.Fatalln("node repo is nil")
+	}
+	if a.livenessRepo == nil {
+		log.Fatalln("liveness repo is nil")
+	}
+	livenessService, err := services.NewLivenessService(a.nodeRepo, a.livenessRepo, a.config.HeartbeatTTL())
+	if err != nil {
+		log.Fatalln(err)
+	}
+	a.livenessService = livenessService
+}
+
 func (a *app) initNodeService() {
 	if a.nodeRepo == nil {
 		log.Fatalln("node repo is nil")
 	}
+	if a.operationRepo == nil {
+		log.Fatalln("operation repo is nil")
+	}
+	if a.orgClusterRepo == nil {
+		log.Fatalln("org cluster repo is nil")
+	}
 	if a.meridian == nil {
 		log.Fatalln("meridian is nil")
 	}
 	if a.gravity == nil {
 		log.Fatalln("gravity is nil")
 	}
-	nodeService, err := services.NewNodeService(a.nodeRepo, a.evaluatorClient, a.administratorClient, a.authzService, a.meridian, a.gravity)
+	if a.livenessService == nil {
+		log.Fatalln("liveness service is nil")
+	}
+	nodeService, err := services.NewNodeService(a.nodeRepo, a.operationRepo, a.orgClusterRepo, a.evaluatorClient, a.administratorClient, a.authzService, a.meridian, a.gravity, a.livenessService)
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -214,7 +299,10 @@ func (a *app) initLabelService() {
 	if a.nodeRepo == nil {
 		log.Fatalln("node repo is nil")
 	}
-	labelService, err := services.NewLabelService(a.nodeRepo, a.evaluatorClient, a.authzService)
+	if a.livenessService == nil {
+		log.Fatalln("liveness service is nil")
+	}
+	labelService, err := services.NewLabelService(a.nodeRepo, a.evaluatorClient, a.authzService, a.livenessService)
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -274,7 +362,39 @@ func (a *app) initRegistrationNatsSubscriber(conn *natsgo.Conn) {
 	a.registrationSubscriber = registrationSubscriber
 }
 
+func (a *app) initHeartbeatNatsSubscriber(conn *natsgo.Conn) {
+	heartbeatSubscriber, err := nats.NewSubscriber(conn, api.HeartbeatSubject, "magnetar")
+	if err != nil {
+		log.Fatalln(err)
+	}
+	a.heartbeatSubscriber = heartbeatSubscriber
+}
+
+func (a *app) initDeregistrationNatsSubscriber(conn *natsgo.Conn) {
+	deregistrationSubscriber, err := nats.NewSubscriber(conn, api.DeregistrationSubject, "magnetar")
+	if err != nil {
+		log.Fatalln(err)
+	}
+	a.deregistrationSubscriber = deregistrationSubscriber
+}
+
+func (a *app) initNodeLivenessEtcdRepo(client *etcd.Client) {
+	livenessRepo, err := repos.NewNodeLivenessEtcdRepo(client)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	a.livenessRepo = livenessRepo
+}
+
 func (a *app) initNodeEtcdRepo(client *etcd.Client) {
+	err := repos.MigrateNodeEtcdLabelIndex(client, a.nodeMarshaller, a.labelMarshaller, a.config.LegacyIndexCleanup())
+	if err != nil {
+		log.Fatalln(err)
+	}
+	err = repos.MigrateNodeEtcdResourceIndex(client, a.nodeMarshaller, a.labelMarshaller)
+	if err != nil {
+		log.Fatalln(err)
+	}
 	nodeRepo, err := repos.NewNodeEtcdRepo(client, a.nodeMarshaller, a.labelMarshaller)
 	if err != nil {
 		log.Fatalln(err)
@@ -282,6 +402,46 @@ func (a *app) initNodeEtcdRepo(client *etcd.Client) {
 	a.nodeRepo = nodeRepo
 }
 
+func (a *app) initOperationEtcdRepo(client *etcd.Client) {
+	err := repos.MigrateOperationEtcdUnfinishedIndex(client, a.operationMarshaller)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	err = repos.MigrateEtcdClaimSagas(client, proto.NewProtoClaimSagaUnmarshaller(), a.operationMarshaller)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	operationRepo, err := repos.NewOperationEtcdRepo(client, a.operationMarshaller)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	a.operationRepo = operationRepo
+}
+
+func (a *app) initLeaderElection(client *etcd.Client) {
+	leaderElection, err := newLeaderElection(client)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	a.leaderElection = leaderElection
+}
+
+func (a *app) initOrgClusterEtcdRepo(client *etcd.Client) {
+	orgClusterRepo, err := repos.NewOrgClusterEtcdRepo(client, a.orgClusterMarshaller)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	a.orgClusterRepo = orgClusterRepo
+}
+
+func (a *app) initOrgClusterProtoMarshaller() {
+	a.orgClusterMarshaller = proto.NewProtoOrgClusterMarshaller()
+}
+
+func (a *app) initOperationProtoMarshaller() {
+	a.operationMarshaller = proto.NewProtoOperationMarshaller()
+}
+
 func (a *app) initLabelProtoMarshaller() {
 	a.labelMarshaller = proto.NewProtoLabelMarshaller()
 }
@@ -303,6 +463,100 @@ func (a *app) startRegistrationServer() error {
 	return nil
 }
 
+func (a *app) startHeartbeatServer() error {
+	err := a.heartbeatServer.Serve()
+	if err != nil {
+		return err
+	}
+	a.gracefulShutdownProcesses = append(a.gracefulShutdownProcesses, func(wg *sync.WaitGroup) {
+		a.heartbeatServer.GracefulStop()
+		log.Println("heartbeat server gracefully stopped")
+		wg.Done()
+	})
+	return nil
+}
+
+func (a *app) startDeregistrationServer() error {
+	err := a.deregistrationServer.Serve()
+	if err != nil {
+		return err
+	}
+	a.gracefulShutdownProcesses = append(a.gracefulShutdownProcesses, func(wg *sync.WaitGroup) {
+		a.deregistrationServer.GracefulStop()
+		log.Println("deregistration server gracefully stopped")
+		wg.Done()
+	})
+	return nil
+}
+
+// startLeaderElection runs the tasks that must run once per magnetar cluster while the replica is the leader
+func (a *app) startLeaderElection() {
+	a.leaderElection.Register("operation resumer", a.resumeOperations)
+	a.leaderElection.Register("reconciler", a.reconcile)
+	a.leaderElection.Start()
+	a.gracefulShutdownProcesses = append(a.gracefulShutdownProcesses, func(wg *sync.WaitGroup) {
+		a.leaderElection.Stop()
+		log.Println("leader election stopped")
+		wg.Done()
+	})
+}
+
+// operations get this long to finish on shutdown before they are stopped at their current step
+const operationShutdownTimeout = 3 * time.Second
+
+// stopOperationsOnShutdown waits for the claims and releases running in the background
+func (a *app) stopOperationsOnShutdown() {
+	a.gracefulShutdownProcesses = append(a.gracefulShutdownProcesses, func(wg *sync.WaitGroup) {
+		ctx, cancel := context.WithTimeout(context.Background(), operationShutdownTimeout)
+		defer cancel()
+		a.nodeService.StopOperations(ctx)
+		log.Println("operations stopped")
+		wg.Done()
+	})
+}
+
+// resumeOperations periodically resumes operations whose owner has stopped without finishing them
+func (a *app) resumeOperations(ctx context.Context) {
+	runPeriodically(ctx, services.OperationResumeInterval, func() {
+		err := a.nodeService.ResumeOperations(ctx)
+		if err != nil {
+			log.Println(err)
+		}
+	})
+}
+
+// reconcile periodically fixes the drift between the org clusters and oort, meridian and gravity
+func (a *app) reconcile(ctx context.Context) {
+	runPeriodically(ctx, a.config.ReconcileInterval(), func() {
+		report, err := a.nodeService.Reconcile(ctx)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		for _, drift := range report.Drift {
+			if drift.Err != nil {
+				log.Printf("drift %s of org %s node %s not fixed: %s\n", drift.Kind, drift.Org, drift.NodeId.Value, drift.Err)
+			} else {
+				log.Printf("drift %s of org %s node %s fixed\n", drift.Kind, drift.Org, drift.NodeId.Value)
+			}
+		}
+		log.Printf("reconciled %d orgs, backfilled %v, skipped %v, drift found %d\n", len(report.Reconciled), report.Backfilled, report.Skipped, len(report.Drift))
+	})
+}
+
+func runPeriodically(ctx context.Context, interval time.Duration, run func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run()
+		}
+	}
+}
+
 func (a *app) startGrpcServer() error {
 	lis, err := net.Listen("tcp", a.config.ServerAddress())
 	if err != nil {
@@ -0,0 +1,164 @@
+package startup
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	etcd "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+// replicas campaign for leadership in etcd, the leader runs the tasks that must run once per magnetar cluster,
+// leadership is tied to a session lease, so a leader that crashes is replaced once the lease expires
+// and one that stops gracefully resigns right away once its tasks have returned
+
+const (
+	leaderElectionPrefix = "magnetar/leader"
+	leaderSessionTTL     = 10
+	leaderResignTimeout  = 5 * time.Second
+	leaderRetryBackoff   = time.Second
+	// tasks still running this long after leadership ends are left behind without resigning, the session lease is left
+	// to expire instead, so they get another leaderSessionTTL seconds to return before another replica can take over
+	leaderTaskStopTimeout = 5 * time.Second
+)
+
+// leaderTask runs only while the replica is the leader, it has to return once ctx is done
+type leaderTask struct {
+	name string
+	run  func(ctx context.Context)
+}
+
+type leaderElection struct {
+	client *etcd.Client
+	id     string
+	tasks  []leaderTask
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func newLeaderElection(client *etcd.Client) (*leaderElection, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	return &leaderElection{
+		client: client,
+		id:     fmt.Sprintf("%s-%s", hostname, uuid.NewString()),
+		tasks:  make([]leaderTask, 0),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}, nil
+}
+
+// Register adds a task to run on the leader, tasks have to be registered before the election is started
+func (l *leaderElection) Register(name string, run func(ctx context.Context)) {
+	l.tasks = append(l.tasks, leaderTask{name: name, run: run})
+}
+
+func (l *leaderElection) Start() {
+	go func() {
+		defer close(l.done)
+		for {
+			select {
+			case <-l.stop:
+				return
+			default:
+			}
+			if err := l.lead(); err != nil {
+				log.Println(err)
+				select {
+				case <-l.stop:
+					return
+				case <-time.After(leaderRetryBackoff):
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops the tasks and hands leadership over to another replica
+func (l *leaderElection) Stop() {
+	close(l.stop)
+	<-l.done
+}
+
+// lead campaigns for leadership and runs the tasks until leadership is lost or the election is stopped
+func (l *leaderElection) lead() error {
+	session, err := concurrency.NewSession(l.client, concurrency.WithTTL(leaderSessionTTL))
+	if err != nil {
+		return err
+	}
+	// an orphaned session isn't kept alive anymore, but its lease isn't revoked either
+	orphaned := false
+	defer func() {
+		if orphaned {
+			return
+		}
+		if err := session.Close(); err != nil {
+			log.Println(err)
+		}
+	}()
+	election := concurrency.NewElection(session, leaderElectionPrefix)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-l.stop:
+		case <-session.Done():
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+	if err := election.Campaign(ctx, l.id); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	log.Printf("replica %s elected leader\n", l.id)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(len(l.tasks))
+	for _, task := range l.tasks {
+		task := task
+		go func() {
+			defer wg.Done()
+			task.run(ctx)
+			log.Printf("leader task %s stopped\n", task.name)
+		}()
+	}
+	<-ctx.Done()
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(leaderTaskStopTimeout):
+		log.Printf("leader tasks of replica %s didn't stop in time, leadership ends once the session expires\n", l.id)
+		session.Orphan()
+		orphaned = true
+		return nil
+	}
+
+	select {
+	case <-session.Done():
+		log.Printf("replica %s lost leadership\n", l.id)
+		return nil
+	default:
+	}
+	resignCtx, resignCancel := context.WithTimeout(context.Background(), leaderResignTimeout)
+	defer resignCancel()
+	if err := election.Resign(resignCtx); err != nil {
+		return err
+	}
+	log.Printf("replica %s resigned leadership\n", l.id)
+	return nil
+}
@@ -20,6 +20,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type WatchNodesResp_EventType int32
+
+const (
+	WatchNodesResp_Added    WatchNodesResp_EventType = 0
+	WatchNodesResp_Modified WatchNodesResp_EventType = 1
+	WatchNodesResp_Deleted  WatchNodesResp_EventType = 2
+)
+
+// Enum value maps for WatchNodesResp_EventType.
+var (
+	WatchNodesResp_EventType_name = map[int32]string{
+		0: "Added",
+		1: "Modified",
+		2: "Deleted",
+	}
+	WatchNodesResp_EventType_value = map[string]int32{
+		"Added":    0,
+		"Modified": 1,
+		"Deleted":  2,
+	}
+)
+
+func (x WatchNodesResp_EventType) Enum() *WatchNodesResp_EventType {
+	p := new(WatchNodesResp_EventType)
+	*p = x
+	return p
+}
+
+func (x WatchNodesResp_EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchNodesResp_EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_magnetar_proto_enumTypes[0].Descriptor()
+}
+
+func (WatchNodesResp_EventType) Type() protoreflect.EnumType {
+	return &file_magnetar_proto_enumTypes[0]
+}
+
+func (x WatchNodesResp_EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchNodesResp_EventType.Descriptor instead.
+func (WatchNodesResp_EventType) EnumDescriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{43, 0}
+}
+
 type GetFromNodePoolReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -223,6 +272,18 @@ type ClaimOwnershipReq struct {
 
 	Query []*Selector `protobuf:"bytes,1,rep,name=query,proto3" json:"query,omitempty"`
 	Org   string      `protobuf:"bytes,2,opt,name=org,proto3" json:"org,omitempty"`
+	// nodes that stopped sending heartbeats are skipped unless set
+	IncludeNotReady bool `protobuf:"varint,3,opt,name=includeNotReady,proto3" json:"includeNotReady,omitempty"`
+	// textual form of the query, e.g. "os=linux, cpu>4", combined with the structured one
+	QueryString string `protobuf:"bytes,4,opt,name=queryString,proto3" json:"queryString,omitempty"`
+	// if count or resources are set, only as many matching nodes as needed to meet them are claimed,
+	// at least count nodes whose resources add up to at least the given amounts
+	Count     int64              `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	Resources map[string]float64 `protobuf:"bytes,6,rep,name=resources,proto3" json:"resources,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// one of first-fit (default), best-fit
+	Strategy string `protobuf:"bytes,7,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	// returns the nodes that would be claimed without claiming them
+	DryRun bool `protobuf:"varint,8,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
 }
 
 func (x *ClaimOwnershipReq) Reset() {
@@ -271,12 +332,71 @@ func (x *ClaimOwnershipReq) GetOrg() string {
 	return ""
 }
 
+func (x *ClaimOwnershipReq) GetIncludeNotReady() bool {
+	if x != nil {
+		return x.IncludeNotReady
+	}
+	return false
+}
+
+func (x *ClaimOwnershipReq) GetQueryString() string {
+	if x != nil {
+		return x.QueryString
+	}
+	return ""
+}
+
+func (x *ClaimOwnershipReq) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ClaimOwnershipReq) GetResources() map[string]float64 {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *ClaimOwnershipReq) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *ClaimOwnershipReq) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
 type ClaimOwnershipResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// nodes selected for the claim
 	Node []*NodeStringified `protobuf:"bytes,1,rep,name=node,proto3" json:"node,omitempty"`
+	// the outcome per node is reported by the operation
+	//
+	// Deprecated: Do not use.
+	Failed []*ClaimFailure `protobuf:"bytes,2,rep,name=failed,proto3" json:"failed,omitempty"`
+	// set by dry runs only
+	DryRun bool `protobuf:"varint,3,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	// quotas the org's default namespace would be given
+	NamespaceQuotas map[string]float64 `protobuf:"bytes,4,rep,name=namespaceQuotas,proto3" json:"namespaceQuotas,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// address of the cluster node the claimed nodes would join
+	JoinAddress string `protobuf:"bytes,5,opt,name=joinAddress,proto3" json:"joinAddress,omitempty"`
+	// id of the claim operation, also accepted by GetClaimSaga, empty for dry runs
+	SagaId string `protobuf:"bytes,6,opt,name=sagaId,proto3" json:"sagaId,omitempty"`
+	// state of the saga when the claim was accepted, it is reported by GetClaimSaga from then on
+	State string `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	// claims the selected nodes in the background, not set by dry runs
+	Operation *Operation `protobuf:"bytes,8,opt,name=operation,proto3" json:"operation,omitempty"`
 }
 
 func (x *ClaimOwnershipResp) Reset() {
@@ -318,14 +438,67 @@ func (x *ClaimOwnershipResp) GetNode() []*NodeStringified {
 	return nil
 }
 
-type ListAllNodesReq struct {
+// Deprecated: Do not use.
+func (x *ClaimOwnershipResp) GetFailed() []*ClaimFailure {
+	if x != nil {
+		return x.Failed
+	}
+	return nil
+}
+
+func (x *ClaimOwnershipResp) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ClaimOwnershipResp) GetNamespaceQuotas() map[string]float64 {
+	if x != nil {
+		return x.NamespaceQuotas
+	}
+	return nil
+}
+
+func (x *ClaimOwnershipResp) GetJoinAddress() string {
+	if x != nil {
+		return x.JoinAddress
+	}
+	return ""
+}
+
+func (x *ClaimOwnershipResp) GetSagaId() string {
+	if x != nil {
+		return x.SagaId
+	}
+	return ""
+}
+
+func (x *ClaimOwnershipResp) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ClaimOwnershipResp) GetOperation() *Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+type GetClaimSagaReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Id  string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Org string `protobuf:"bytes,2,opt,name=org,proto3" json:"org,omitempty"`
 }
 
-func (x *ListAllNodesReq) Reset() {
-	*x = ListAllNodesReq{}
+func (x *GetClaimSagaReq) Reset() {
+	*x = GetClaimSagaReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_magnetar_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -333,13 +506,13 @@ func (x *ListAllNodesReq) Reset() {
 	}
 }
 
-func (x *ListAllNodesReq) String() string {
+func (x *GetClaimSagaReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAllNodesReq) ProtoMessage() {}
+func (*GetClaimSagaReq) ProtoMessage() {}
 
-func (x *ListAllNodesReq) ProtoReflect() protoreflect.Message {
+func (x *GetClaimSagaReq) ProtoReflect() protoreflect.Message {
 	mi := &file_magnetar_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -351,21 +524,35 @@ func (x *ListAllNodesReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAllNodesReq.ProtoReflect.Descriptor instead.
-func (*ListAllNodesReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetClaimSagaReq.ProtoReflect.Descriptor instead.
+func (*GetClaimSagaReq) Descriptor() ([]byte, []int) {
 	return file_magnetar_proto_rawDescGZIP(), []int{6}
 }
 
-type ListAllNodesResp struct {
+func (x *GetClaimSagaReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetClaimSagaReq) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+type GetClaimSagaResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Nodes []*NodeStringified `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Saga *ClaimSaga `protobuf:"bytes,1,opt,name=saga,proto3" json:"saga,omitempty"`
 }
 
-func (x *ListAllNodesResp) Reset() {
-	*x = ListAllNodesResp{}
+func (x *GetClaimSagaResp) Reset() {
+	*x = GetClaimSagaResp{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_magnetar_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -373,13 +560,13 @@ func (x *ListAllNodesResp) Reset() {
 	}
 }
 
-func (x *ListAllNodesResp) String() string {
+func (x *GetClaimSagaResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAllNodesResp) ProtoMessage() {}
+func (*GetClaimSagaResp) ProtoMessage() {}
 
-func (x *ListAllNodesResp) ProtoReflect() protoreflect.Message {
+func (x *GetClaimSagaResp) ProtoReflect() protoreflect.Message {
 	mi := &file_magnetar_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -391,26 +578,31 @@ func (x *ListAllNodesResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAllNodesResp.ProtoReflect.Descriptor instead.
-func (*ListAllNodesResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetClaimSagaResp.ProtoReflect.Descriptor instead.
+func (*GetClaimSagaResp) Descriptor() ([]byte, []int) {
 	return file_magnetar_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListAllNodesResp) GetNodes() []*NodeStringified {
+func (x *GetClaimSagaResp) GetSaga() *ClaimSaga {
 	if x != nil {
-		return x.Nodes
+		return x.Saga
 	}
 	return nil
 }
 
-type ListNodePoolReq struct {
+type ReleaseNodesReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Query []*Selector `protobuf:"bytes,1,rep,name=query,proto3" json:"query,omitempty"`
+	// takes precedence over the query if not empty
+	NodeIds []string `protobuf:"bytes,2,rep,name=nodeIds,proto3" json:"nodeIds,omitempty"`
+	Org     string   `protobuf:"bytes,3,opt,name=org,proto3" json:"org,omitempty"`
 }
 
-func (x *ListNodePoolReq) Reset() {
-	*x = ListNodePoolReq{}
+func (x *ReleaseNodesReq) Reset() {
+	*x = ReleaseNodesReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_magnetar_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -418,13 +610,13 @@ func (x *ListNodePoolReq) Reset() {
 	}
 }
 
-func (x *ListNodePoolReq) String() string {
+func (x *ReleaseNodesReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNodePoolReq) ProtoMessage() {}
+func (*ReleaseNodesReq) ProtoMessage() {}
 
-func (x *ListNodePoolReq) ProtoReflect() protoreflect.Message {
+func (x *ReleaseNodesReq) ProtoReflect() protoreflect.Message {
 	mi := &file_magnetar_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -436,21 +628,49 @@ func (x *ListNodePoolReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNodePoolReq.ProtoReflect.Descriptor instead.
-func (*ListNodePoolReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReleaseNodesReq.ProtoReflect.Descriptor instead.
+func (*ReleaseNodesReq) Descriptor() ([]byte, []int) {
 	return file_magnetar_proto_rawDescGZIP(), []int{8}
 }
 
-type ListNodePoolResp struct {
+func (x *ReleaseNodesReq) GetQuery() []*Selector {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *ReleaseNodesReq) GetNodeIds() []string {
+	if x != nil {
+		return x.NodeIds
+	}
+	return nil
+}
+
+func (x *ReleaseNodesReq) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+type ReleaseNodesResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Nodes []*NodeStringified `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// the outcome per node is reported by the operation
+	//
+	// Deprecated: Do not use.
+	Node []*NodeStringified `protobuf:"bytes,1,rep,name=node,proto3" json:"node,omitempty"`
+	// Deprecated: Do not use.
+	Failed []*ClaimFailure `protobuf:"bytes,2,rep,name=failed,proto3" json:"failed,omitempty"`
+	// releases the nodes in the background
+	Operation *Operation `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
 }
 
-func (x *ListNodePoolResp) Reset() {
-	*x = ListNodePoolResp{}
+func (x *ReleaseNodesResp) Reset() {
+	*x = ReleaseNodesResp{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_magnetar_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -458,13 +678,13 @@ func (x *ListNodePoolResp) Reset() {
 	}
 }
 
-func (x *ListNodePoolResp) String() string {
+func (x *ReleaseNodesResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNodePoolResp) ProtoMessage() {}
+func (*ReleaseNodesResp) ProtoMessage() {}
 
-func (x *ListNodePoolResp) ProtoReflect() protoreflect.Message {
+func (x *ReleaseNodesResp) ProtoReflect() protoreflect.Message {
 	mi := &file_magnetar_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -476,28 +696,46 @@ func (x *ListNodePoolResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNodePoolResp.ProtoReflect.Descriptor instead.
-func (*ListNodePoolResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReleaseNodesResp.ProtoReflect.Descriptor instead.
+func (*ReleaseNodesResp) Descriptor() ([]byte, []int) {
 	return file_magnetar_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ListNodePoolResp) GetNodes() []*NodeStringified {
+// Deprecated: Do not use.
+func (x *ReleaseNodesResp) GetNode() []*NodeStringified {
 	if x != nil {
-		return x.Nodes
+		return x.Node
 	}
 	return nil
 }
 
-type ListOrgOwnedNodesReq struct {
+// Deprecated: Do not use.
+func (x *ReleaseNodesResp) GetFailed() []*ClaimFailure {
+	if x != nil {
+		return x.Failed
+	}
+	return nil
+}
+
+func (x *ReleaseNodesResp) GetOperation() *Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+type DeregisterNodeReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Org string `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	NodeId string `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	// empty for nodes in the node pool
+	Org string `protobuf:"bytes,2,opt,name=org,proto3" json:"org,omitempty"`
 }
 
-func (x *ListOrgOwnedNodesReq) Reset() {
-	*x = ListOrgOwnedNodesReq{}
+func (x *DeregisterNodeReq) Reset() {
+	*x = DeregisterNodeReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_magnetar_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -505,13 +743,13 @@ func (x *ListOrgOwnedNodesReq) Reset() {
 	}
 }
 
-func (x *ListOrgOwnedNodesReq) String() string {
+func (x *DeregisterNodeReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrgOwnedNodesReq) ProtoMessage() {}
+func (*DeregisterNodeReq) ProtoMessage() {}
 
-func (x *ListOrgOwnedNodesReq) ProtoReflect() protoreflect.Message {
+func (x *DeregisterNodeReq) ProtoReflect() protoreflect.Message {
 	mi := &file_magnetar_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -523,28 +761,35 @@ func (x *ListOrgOwnedNodesReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrgOwnedNodesReq.ProtoReflect.Descriptor instead.
-func (*ListOrgOwnedNodesReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeregisterNodeReq.ProtoReflect.Descriptor instead.
+func (*DeregisterNodeReq) Descriptor() ([]byte, []int) {
 	return file_magnetar_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ListOrgOwnedNodesReq) GetOrg() string {
+func (x *DeregisterNodeReq) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *DeregisterNodeReq) GetOrg() string {
 	if x != nil {
 		return x.Org
 	}
 	return ""
 }
 
-type ListOrgOwnedNodesResp struct {
+type DeregisterNodeResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Nodes []*NodeStringified `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Node *NodeStringified `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
 }
 
-func (x *ListOrgOwnedNodesResp) Reset() {
-	*x = ListOrgOwnedNodesResp{}
+func (x *DeregisterNodeResp) Reset() {
+	*x = DeregisterNodeResp{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_magnetar_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -552,13 +797,13 @@ func (x *ListOrgOwnedNodesResp) Reset() {
 	}
 }
 
-func (x *ListOrgOwnedNodesResp) String() string {
+func (x *DeregisterNodeResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrgOwnedNodesResp) ProtoMessage() {}
+func (*DeregisterNodeResp) ProtoMessage() {}
 
-func (x *ListOrgOwnedNodesResp) ProtoReflect() protoreflect.Message {
+func (x *DeregisterNodeResp) ProtoReflect() protoreflect.Message {
 	mi := &file_magnetar_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -570,30 +815,29 @@ func (x *ListOrgOwnedNodesResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrgOwnedNodesResp.ProtoReflect.Descriptor instead.
-func (*ListOrgOwnedNodesResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeregisterNodeResp.ProtoReflect.Descriptor instead.
+func (*DeregisterNodeResp) Descriptor() ([]byte, []int) {
 	return file_magnetar_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *ListOrgOwnedNodesResp) GetNodes() []*NodeStringified {
+func (x *DeregisterNodeResp) GetNode() *NodeStringified {
 	if x != nil {
-		return x.Nodes
+		return x.Node
 	}
 	return nil
 }
 
-type Selector struct {
+type ClaimFailure struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LabelKey string `protobuf:"bytes,3,opt,name=labelKey,proto3" json:"labelKey,omitempty"`
-	ShouldBe string `protobuf:"bytes,2,opt,name=shouldBe,proto3" json:"shouldBe,omitempty"`
-	Value    string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	NodeId string `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Error  string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (x *Selector) Reset() {
-	*x = Selector{}
+func (x *ClaimFailure) Reset() {
+	*x = ClaimFailure{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_magnetar_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -601,13 +845,13 @@ func (x *Selector) Reset() {
 	}
 }
 
-func (x *Selector) String() string {
+func (x *ClaimFailure) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Selector) ProtoMessage() {}
+func (*ClaimFailure) ProtoMessage() {}
 
-func (x *Selector) ProtoReflect() protoreflect.Message {
+func (x *ClaimFailure) ProtoReflect() protoreflect.Message {
 	mi := &file_magnetar_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -619,42 +863,36 @@ func (x *Selector) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Selector.ProtoReflect.Descriptor instead.
-func (*Selector) Descriptor() ([]byte, []int) {
+// Deprecated: Use ClaimFailure.ProtoReflect.Descriptor instead.
+func (*ClaimFailure) Descriptor() ([]byte, []int) {
 	return file_magnetar_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Selector) GetLabelKey() string {
-	if x != nil {
-		return x.LabelKey
-	}
-	return ""
-}
-
-func (x *Selector) GetShouldBe() string {
+func (x *ClaimFailure) GetNodeId() string {
 	if x != nil {
-		return x.ShouldBe
+		return x.NodeId
 	}
 	return ""
 }
 
-func (x *Selector) GetValue() string {
+func (x *ClaimFailure) GetError() string {
 	if x != nil {
-		return x.Value
+		return x.Error
 	}
 	return ""
 }
 
-type QueryNodePoolReq struct {
+type GetOperationReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Query []*Selector `protobuf:"bytes,1,rep,name=query,proto3" json:"query,omitempty"`
+	Id  string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Org string `protobuf:"bytes,2,opt,name=org,proto3" json:"org,omitempty"`
 }
 
-func (x *QueryNodePoolReq) Reset() {
-	*x = QueryNodePoolReq{}
+func (x *GetOperationReq) Reset() {
+	*x = GetOperationReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_magnetar_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -662,13 +900,13 @@ func (x *QueryNodePoolReq) Reset() {
 	}
 }
 
-func (x *QueryNodePoolReq) String() string {
+func (x *GetOperationReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryNodePoolReq) ProtoMessage() {}
+func (*GetOperationReq) ProtoMessage() {}
 
-func (x *QueryNodePoolReq) ProtoReflect() protoreflect.Message {
+func (x *GetOperationReq) ProtoReflect() protoreflect.Message {
 	mi := &file_magnetar_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -680,28 +918,35 @@ func (x *QueryNodePoolReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryNodePoolReq.ProtoReflect.Descriptor instead.
-func (*QueryNodePoolReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetOperationReq.ProtoReflect.Descriptor instead.
+func (*GetOperationReq) Descriptor() ([]byte, []int) {
 	return file_magnetar_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *QueryNodePoolReq) GetQuery() []*Selector {
+func (x *GetOperationReq) GetId() string {
 	if x != nil {
-		return x.Query
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type QueryNodePoolResp struct {
+func (x *GetOperationReq) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+type GetOperationResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Nodes []*NodeStringified `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Operation *Operation `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
 }
 
-func (x *QueryNodePoolResp) Reset() {
-	*x = QueryNodePoolResp{}
+func (x *GetOperationResp) Reset() {
+	*x = GetOperationResp{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_magnetar_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -709,13 +954,13 @@ func (x *QueryNodePoolResp) Reset() {
 	}
 }
 
-func (x *QueryNodePoolResp) String() string {
+func (x *GetOperationResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryNodePoolResp) ProtoMessage() {}
+func (*GetOperationResp) ProtoMessage() {}
 
-func (x *QueryNodePoolResp) ProtoReflect() protoreflect.Message {
+func (x *GetOperationResp) ProtoReflect() protoreflect.Message {
 	mi := &file_magnetar_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -727,29 +972,30 @@ func (x *QueryNodePoolResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryNodePoolResp.ProtoReflect.Descriptor instead.
-func (*QueryNodePoolResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetOperationResp.ProtoReflect.Descriptor instead.
+func (*GetOperationResp) Descriptor() ([]byte, []int) {
 	return file_magnetar_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *QueryNodePoolResp) GetNodes() []*NodeStringified {
+func (x *GetOperationResp) GetOperation() *Operation {
 	if x != nil {
-		return x.Nodes
+		return x.Operation
 	}
 	return nil
 }
 
-type QueryOrgOwnedNodesReq struct {
+type ListOperationsReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Query []*Selector `protobuf:"bytes,1,rep,name=query,proto3" json:"query,omitempty"`
-	Org   string      `protobuf:"bytes,2,opt,name=org,proto3" json:"org,omitempty"`
+	Org       string `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	PageSize  int64  `protobuf:"varint,2,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
 }
 
-func (x *QueryOrgOwnedNodesReq) Reset() {
-	*x = QueryOrgOwnedNodesReq{}
+func (x *ListOperationsReq) Reset() {
+	*x = ListOperationsReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_magnetar_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -757,13 +1003,13 @@ func (x *QueryOrgOwnedNodesReq) Reset() {
 	}
 }
 
-func (x *QueryOrgOwnedNodesReq) String() string {
+func (x *ListOperationsReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryOrgOwnedNodesReq) ProtoMessage() {}
+func (*ListOperationsReq) ProtoMessage() {}
 
-func (x *QueryOrgOwnedNodesReq) ProtoReflect() protoreflect.Message {
+func (x *ListOperationsReq) ProtoReflect() protoreflect.Message {
 	mi := &file_magnetar_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -775,50 +1021,1292 @@ func (x *QueryOrgOwnedNodesReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryOrgOwnedNodesReq.ProtoReflect.Descriptor instead.
-func (*QueryOrgOwnedNodesReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListOperationsReq.ProtoReflect.Descriptor instead.
+func (*ListOperationsReq) Descriptor() ([]byte, []int) {
 	return file_magnetar_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *QueryOrgOwnedNodesReq) GetQuery() []*Selector {
+func (x *ListOperationsReq) GetOrg() string {
 	if x != nil {
-		return x.Query
+		return x.Org
 	}
-	return nil
+	return ""
+}
+
+func (x *ListOperationsReq) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOperationsReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListOperationsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the most recent first, finished operations are kept for a week
+	Operations    []*Operation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	NextPageToken string       `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+}
+
+func (x *ListOperationsResp) Reset() {
+	*x = ListOperationsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListOperationsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOperationsResp) ProtoMessage() {}
+
+func (x *ListOperationsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOperationsResp.ProtoReflect.Descriptor instead.
+func (*ListOperationsResp) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListOperationsResp) GetOperations() []*Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *ListOperationsResp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CancelOperationReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id  string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Org string `protobuf:"bytes,2,opt,name=org,proto3" json:"org,omitempty"`
+}
+
+func (x *CancelOperationReq) Reset() {
+	*x = CancelOperationReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelOperationReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOperationReq) ProtoMessage() {}
+
+func (x *CancelOperationReq) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOperationReq.ProtoReflect.Descriptor instead.
+func (*CancelOperationReq) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CancelOperationReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CancelOperationReq) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+type CancelOperationResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operation *Operation `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+}
+
+func (x *CancelOperationResp) Reset() {
+	*x = CancelOperationResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelOperationResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOperationResp) ProtoMessage() {}
+
+func (x *CancelOperationResp) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOperationResp.ProtoReflect.Descriptor instead.
+func (*CancelOperationResp) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CancelOperationResp) GetOperation() *Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+type ListAllNodesReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageSize  int64  `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken string `protobuf:"bytes,2,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+}
+
+func (x *ListAllNodesReq) Reset() {
+	*x = ListAllNodesReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAllNodesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAllNodesReq) ProtoMessage() {}
+
+func (x *ListAllNodesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAllNodesReq.ProtoReflect.Descriptor instead.
+func (*ListAllNodesReq) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListAllNodesReq) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAllNodesReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListAllNodesResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes         []*NodeStringified `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	NextPageToken string             `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+}
+
+func (x *ListAllNodesResp) Reset() {
+	*x = ListAllNodesResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAllNodesResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAllNodesResp) ProtoMessage() {}
+
+func (x *ListAllNodesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAllNodesResp.ProtoReflect.Descriptor instead.
+func (*ListAllNodesResp) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListAllNodesResp) GetNodes() []*NodeStringified {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *ListAllNodesResp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListNodePoolReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageSize  int64  `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken string `protobuf:"bytes,2,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+}
+
+func (x *ListNodePoolReq) Reset() {
+	*x = ListNodePoolReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListNodePoolReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNodePoolReq) ProtoMessage() {}
+
+func (x *ListNodePoolReq) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNodePoolReq.ProtoReflect.Descriptor instead.
+func (*ListNodePoolReq) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListNodePoolReq) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListNodePoolReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListNodePoolResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes         []*NodeStringified `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	NextPageToken string             `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+}
+
+func (x *ListNodePoolResp) Reset() {
+	*x = ListNodePoolResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListNodePoolResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNodePoolResp) ProtoMessage() {}
+
+func (x *ListNodePoolResp) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNodePoolResp.ProtoReflect.Descriptor instead.
+func (*ListNodePoolResp) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListNodePoolResp) GetNodes() []*NodeStringified {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *ListNodePoolResp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListOrgOwnedNodesReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Org       string `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	PageSize  int64  `protobuf:"varint,2,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+}
+
+func (x *ListOrgOwnedNodesReq) Reset() {
+	*x = ListOrgOwnedNodesReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListOrgOwnedNodesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrgOwnedNodesReq) ProtoMessage() {}
+
+func (x *ListOrgOwnedNodesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrgOwnedNodesReq.ProtoReflect.Descriptor instead.
+func (*ListOrgOwnedNodesReq) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListOrgOwnedNodesReq) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *ListOrgOwnedNodesReq) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOrgOwnedNodesReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListOrgOwnedNodesResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes         []*NodeStringified `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	NextPageToken string             `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+}
+
+func (x *ListOrgOwnedNodesResp) Reset() {
+	*x = ListOrgOwnedNodesResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListOrgOwnedNodesResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrgOwnedNodesResp) ProtoMessage() {}
+
+func (x *ListOrgOwnedNodesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrgOwnedNodesResp.ProtoReflect.Descriptor instead.
+func (*ListOrgOwnedNodesResp) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListOrgOwnedNodesResp) GetNodes() []*NodeStringified {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *ListOrgOwnedNodesResp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type Selector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LabelKey string `protobuf:"bytes,3,opt,name=labelKey,proto3" json:"labelKey,omitempty"`
+	// targets the node resource instead of a label, resource values compare like numeric labels
+	Resource string `protobuf:"bytes,6,opt,name=resource,proto3" json:"resource,omitempty"`
+	// one of =, !=, >, <, >=, <=, in, notin, exists, doesnotexist, prefix, regex
+	ShouldBe string `protobuf:"bytes,2,opt,name=shouldBe,proto3" json:"shouldBe,omitempty"`
+	Value    string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// used instead of value by in and notin
+	Values []string `protobuf:"bytes,4,rep,name=values,proto3" json:"values,omitempty"`
+	// if set, the selector matches according to the group and the fields above are ignored
+	Group *SelectorGroup `protobuf:"bytes,5,opt,name=group,proto3" json:"group,omitempty"`
+}
+
+func (x *Selector) Reset() {
+	*x = Selector{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Selector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Selector) ProtoMessage() {}
+
+func (x *Selector) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Selector.ProtoReflect.Descriptor instead.
+func (*Selector) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Selector) GetLabelKey() string {
+	if x != nil {
+		return x.LabelKey
+	}
+	return ""
+}
+
+func (x *Selector) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *Selector) GetShouldBe() string {
+	if x != nil {
+		return x.ShouldBe
+	}
+	return ""
+}
+
+func (x *Selector) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Selector) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *Selector) GetGroup() *SelectorGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+// OrderBy sorts by either a label or a resource,
+// labels of different types are ordered bool, number, string and nodes without the label or resource come last
+type OrderBy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LabelKey   string `protobuf:"bytes,1,opt,name=labelKey,proto3" json:"labelKey,omitempty"`
+	Resource   string `protobuf:"bytes,2,opt,name=resource,proto3" json:"resource,omitempty"`
+	Descending bool   `protobuf:"varint,3,opt,name=descending,proto3" json:"descending,omitempty"`
+}
+
+func (x *OrderBy) Reset() {
+	*x = OrderBy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderBy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBy) ProtoMessage() {}
+
+func (x *OrderBy) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBy.ProtoReflect.Descriptor instead.
+func (*OrderBy) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *OrderBy) GetLabelKey() string {
+	if x != nil {
+		return x.LabelKey
+	}
+	return ""
+}
+
+func (x *OrderBy) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *OrderBy) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+type SelectorGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// one of and, or, not (negates the conjunction of the selectors)
+	Op        string      `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Selectors []*Selector `protobuf:"bytes,2,rep,name=selectors,proto3" json:"selectors,omitempty"`
+}
+
+func (x *SelectorGroup) Reset() {
+	*x = SelectorGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SelectorGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectorGroup) ProtoMessage() {}
+
+func (x *SelectorGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectorGroup.ProtoReflect.Descriptor instead.
+func (*SelectorGroup) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SelectorGroup) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *SelectorGroup) GetSelectors() []*Selector {
+	if x != nil {
+		return x.Selectors
+	}
+	return nil
+}
+
+type QueryNodePoolReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query     []*Selector `protobuf:"bytes,1,rep,name=query,proto3" json:"query,omitempty"`
+	PageSize  int64       `protobuf:"varint,2,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken string      `protobuf:"bytes,3,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// nodes that stopped sending heartbeats are skipped unless set
+	IncludeNotReady bool `protobuf:"varint,4,opt,name=includeNotReady,proto3" json:"includeNotReady,omitempty"`
+	// textual form of the query, e.g. "os=linux, cpu>4", combined with the structured one
+	QueryString string `protobuf:"bytes,5,opt,name=queryString,proto3" json:"queryString,omitempty"`
+	// matching nodes are sorted by the first order, ties by the next one and finally by node id
+	OrderBy []*OrderBy `protobuf:"bytes,6,rep,name=orderBy,proto3" json:"orderBy,omitempty"`
+	// caps the number of matching nodes returned over all pages, zero means no limit
+	Limit int64 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *QueryNodePoolReq) Reset() {
+	*x = QueryNodePoolReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryNodePoolReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryNodePoolReq) ProtoMessage() {}
+
+func (x *QueryNodePoolReq) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryNodePoolReq.ProtoReflect.Descriptor instead.
+func (*QueryNodePoolReq) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *QueryNodePoolReq) GetQuery() []*Selector {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *QueryNodePoolReq) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *QueryNodePoolReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *QueryNodePoolReq) GetIncludeNotReady() bool {
+	if x != nil {
+		return x.IncludeNotReady
+	}
+	return false
+}
+
+func (x *QueryNodePoolReq) GetQueryString() string {
+	if x != nil {
+		return x.QueryString
+	}
+	return ""
+}
+
+func (x *QueryNodePoolReq) GetOrderBy() []*OrderBy {
+	if x != nil {
+		return x.OrderBy
+	}
+	return nil
+}
+
+func (x *QueryNodePoolReq) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type QueryNodePoolResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes         []*NodeStringified `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	NextPageToken string             `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+}
+
+func (x *QueryNodePoolResp) Reset() {
+	*x = QueryNodePoolResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryNodePoolResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryNodePoolResp) ProtoMessage() {}
+
+func (x *QueryNodePoolResp) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryNodePoolResp.ProtoReflect.Descriptor instead.
+func (*QueryNodePoolResp) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *QueryNodePoolResp) GetNodes() []*NodeStringified {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *QueryNodePoolResp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type QueryOrgOwnedNodesReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query     []*Selector `protobuf:"bytes,1,rep,name=query,proto3" json:"query,omitempty"`
+	Org       string      `protobuf:"bytes,2,opt,name=org,proto3" json:"org,omitempty"`
+	PageSize  int64       `protobuf:"varint,3,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken string      `protobuf:"bytes,4,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// textual form of the query, e.g. "os=linux, cpu>4", combined with the structured one
+	QueryString string `protobuf:"bytes,5,opt,name=queryString,proto3" json:"queryString,omitempty"`
+	// matching nodes are sorted by the first order, ties by the next one and finally by node id
+	OrderBy []*OrderBy `protobuf:"bytes,6,rep,name=orderBy,proto3" json:"orderBy,omitempty"`
+	// caps the number of matching nodes returned over all pages, zero means no limit
+	Limit int64 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *QueryOrgOwnedNodesReq) Reset() {
+	*x = QueryOrgOwnedNodesReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryOrgOwnedNodesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryOrgOwnedNodesReq) ProtoMessage() {}
+
+func (x *QueryOrgOwnedNodesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryOrgOwnedNodesReq.ProtoReflect.Descriptor instead.
+func (*QueryOrgOwnedNodesReq) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *QueryOrgOwnedNodesReq) GetQuery() []*Selector {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *QueryOrgOwnedNodesReq) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *QueryOrgOwnedNodesReq) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *QueryOrgOwnedNodesReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *QueryOrgOwnedNodesReq) GetQueryString() string {
+	if x != nil {
+		return x.QueryString
+	}
+	return ""
+}
+
+func (x *QueryOrgOwnedNodesReq) GetOrderBy() []*OrderBy {
+	if x != nil {
+		return x.OrderBy
+	}
+	return nil
+}
+
+func (x *QueryOrgOwnedNodesReq) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type QueryOrgOwnedNodesResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes         []*NodeStringified `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	NextPageToken string             `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+}
+
+func (x *QueryOrgOwnedNodesResp) Reset() {
+	*x = QueryOrgOwnedNodesResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryOrgOwnedNodesResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryOrgOwnedNodesResp) ProtoMessage() {}
+
+func (x *QueryOrgOwnedNodesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryOrgOwnedNodesResp.ProtoReflect.Descriptor instead.
+func (*QueryOrgOwnedNodesResp) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *QueryOrgOwnedNodesResp) GetNodes() []*NodeStringified {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *QueryOrgOwnedNodesResp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type PutBoolLabelReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeId string     `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Label  *BoolLabel `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Org    string     `protobuf:"bytes,3,opt,name=org,proto3" json:"org,omitempty"`
+	// optional, if set the label is put only if the node has not been modified since
+	ResourceVersion int64 `protobuf:"varint,4,opt,name=resourceVersion,proto3" json:"resourceVersion,omitempty"`
+}
+
+func (x *PutBoolLabelReq) Reset() {
+	*x = PutBoolLabelReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutBoolLabelReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutBoolLabelReq) ProtoMessage() {}
+
+func (x *PutBoolLabelReq) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutBoolLabelReq.ProtoReflect.Descriptor instead.
+func (*PutBoolLabelReq) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PutBoolLabelReq) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *PutBoolLabelReq) GetLabel() *BoolLabel {
+	if x != nil {
+		return x.Label
+	}
+	return nil
+}
+
+func (x *PutBoolLabelReq) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *PutBoolLabelReq) GetResourceVersion() int64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
+type PutFloat64LabelReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeId string        `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Label  *Float64Label `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Org    string        `protobuf:"bytes,3,opt,name=org,proto3" json:"org,omitempty"`
+	// optional, if set the label is put only if the node has not been modified since
+	ResourceVersion int64 `protobuf:"varint,4,opt,name=resourceVersion,proto3" json:"resourceVersion,omitempty"`
+}
+
+func (x *PutFloat64LabelReq) Reset() {
+	*x = PutFloat64LabelReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutFloat64LabelReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutFloat64LabelReq) ProtoMessage() {}
+
+func (x *PutFloat64LabelReq) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutFloat64LabelReq.ProtoReflect.Descriptor instead.
+func (*PutFloat64LabelReq) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PutFloat64LabelReq) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *PutFloat64LabelReq) GetLabel() *Float64Label {
+	if x != nil {
+		return x.Label
+	}
+	return nil
+}
+
+func (x *PutFloat64LabelReq) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *PutFloat64LabelReq) GetResourceVersion() int64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
 }
 
-func (x *QueryOrgOwnedNodesReq) GetOrg() string {
+type PutStringLabelReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeId string       `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Label  *StringLabel `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Org    string       `protobuf:"bytes,3,opt,name=org,proto3" json:"org,omitempty"`
+	// optional, if set the label is put only if the node has not been modified since
+	ResourceVersion int64 `protobuf:"varint,4,opt,name=resourceVersion,proto3" json:"resourceVersion,omitempty"`
+}
+
+func (x *PutStringLabelReq) Reset() {
+	*x = PutStringLabelReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutStringLabelReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutStringLabelReq) ProtoMessage() {}
+
+func (x *PutStringLabelReq) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutStringLabelReq.ProtoReflect.Descriptor instead.
+func (*PutStringLabelReq) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PutStringLabelReq) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *PutStringLabelReq) GetLabel() *StringLabel {
+	if x != nil {
+		return x.Label
+	}
+	return nil
+}
+
+func (x *PutStringLabelReq) GetOrg() string {
 	if x != nil {
 		return x.Org
 	}
 	return ""
 }
 
-type QueryOrgOwnedNodesResp struct {
+func (x *PutStringLabelReq) GetResourceVersion() int64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
+type PutInt64LabelReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Nodes []*NodeStringified `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	NodeId string      `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Label  *Int64Label `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Org    string      `protobuf:"bytes,3,opt,name=org,proto3" json:"org,omitempty"`
+	// optional, if set the label is put only if the node has not been modified since
+	ResourceVersion int64 `protobuf:"varint,4,opt,name=resourceVersion,proto3" json:"resourceVersion,omitempty"`
 }
 
-func (x *QueryOrgOwnedNodesResp) Reset() {
-	*x = QueryOrgOwnedNodesResp{}
+func (x *PutInt64LabelReq) Reset() {
+	*x = PutInt64LabelReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_magnetar_proto_msgTypes[16]
+		mi := &file_magnetar_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *QueryOrgOwnedNodesResp) String() string {
+func (x *PutInt64LabelReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryOrgOwnedNodesResp) ProtoMessage() {}
+func (*PutInt64LabelReq) ProtoMessage() {}
 
-func (x *QueryOrgOwnedNodesResp) ProtoReflect() protoreflect.Message {
-	mi := &file_magnetar_proto_msgTypes[16]
+func (x *PutInt64LabelReq) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -829,45 +2317,68 @@ func (x *QueryOrgOwnedNodesResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryOrgOwnedNodesResp.ProtoReflect.Descriptor instead.
-func (*QueryOrgOwnedNodesResp) Descriptor() ([]byte, []int) {
-	return file_magnetar_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use PutInt64LabelReq.ProtoReflect.Descriptor instead.
+func (*PutInt64LabelReq) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *QueryOrgOwnedNodesResp) GetNodes() []*NodeStringified {
+func (x *PutInt64LabelReq) GetNodeId() string {
 	if x != nil {
-		return x.Nodes
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *PutInt64LabelReq) GetLabel() *Int64Label {
+	if x != nil {
+		return x.Label
 	}
 	return nil
 }
 
-type PutBoolLabelReq struct {
+func (x *PutInt64LabelReq) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *PutInt64LabelReq) GetResourceVersion() int64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
+type PutTimestampLabelReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NodeId string     `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
-	Label  *BoolLabel `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
-	Org    string     `protobuf:"bytes,3,opt,name=org,proto3" json:"org,omitempty"`
+	NodeId string          `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Label  *TimestampLabel `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Org    string          `protobuf:"bytes,3,opt,name=org,proto3" json:"org,omitempty"`
+	// optional, if set the label is put only if the node has not been modified since
+	ResourceVersion int64 `protobuf:"varint,4,opt,name=resourceVersion,proto3" json:"resourceVersion,omitempty"`
 }
 
-func (x *PutBoolLabelReq) Reset() {
-	*x = PutBoolLabelReq{}
+func (x *PutTimestampLabelReq) Reset() {
+	*x = PutTimestampLabelReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_magnetar_proto_msgTypes[17]
+		mi := &file_magnetar_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PutBoolLabelReq) String() string {
+func (x *PutTimestampLabelReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutBoolLabelReq) ProtoMessage() {}
+func (*PutTimestampLabelReq) ProtoMessage() {}
 
-func (x *PutBoolLabelReq) ProtoReflect() protoreflect.Message {
-	mi := &file_magnetar_proto_msgTypes[17]
+func (x *PutTimestampLabelReq) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -878,59 +2389,68 @@ func (x *PutBoolLabelReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutBoolLabelReq.ProtoReflect.Descriptor instead.
-func (*PutBoolLabelReq) Descriptor() ([]byte, []int) {
-	return file_magnetar_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use PutTimestampLabelReq.ProtoReflect.Descriptor instead.
+func (*PutTimestampLabelReq) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *PutBoolLabelReq) GetNodeId() string {
+func (x *PutTimestampLabelReq) GetNodeId() string {
 	if x != nil {
 		return x.NodeId
 	}
 	return ""
 }
 
-func (x *PutBoolLabelReq) GetLabel() *BoolLabel {
+func (x *PutTimestampLabelReq) GetLabel() *TimestampLabel {
 	if x != nil {
 		return x.Label
 	}
 	return nil
 }
 
-func (x *PutBoolLabelReq) GetOrg() string {
+func (x *PutTimestampLabelReq) GetOrg() string {
 	if x != nil {
 		return x.Org
 	}
 	return ""
 }
 
-type PutFloat64LabelReq struct {
+func (x *PutTimestampLabelReq) GetResourceVersion() int64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
+type PutSemverLabelReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NodeId string        `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
-	Label  *Float64Label `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
-	Org    string        `protobuf:"bytes,3,opt,name=org,proto3" json:"org,omitempty"`
+	NodeId string       `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Label  *SemverLabel `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Org    string       `protobuf:"bytes,3,opt,name=org,proto3" json:"org,omitempty"`
+	// optional, if set the label is put only if the node has not been modified since
+	ResourceVersion int64 `protobuf:"varint,4,opt,name=resourceVersion,proto3" json:"resourceVersion,omitempty"`
 }
 
-func (x *PutFloat64LabelReq) Reset() {
-	*x = PutFloat64LabelReq{}
+func (x *PutSemverLabelReq) Reset() {
+	*x = PutSemverLabelReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_magnetar_proto_msgTypes[18]
+		mi := &file_magnetar_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PutFloat64LabelReq) String() string {
+func (x *PutSemverLabelReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutFloat64LabelReq) ProtoMessage() {}
+func (*PutSemverLabelReq) ProtoMessage() {}
 
-func (x *PutFloat64LabelReq) ProtoReflect() protoreflect.Message {
-	mi := &file_magnetar_proto_msgTypes[18]
+func (x *PutSemverLabelReq) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -941,59 +2461,68 @@ func (x *PutFloat64LabelReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutFloat64LabelReq.ProtoReflect.Descriptor instead.
-func (*PutFloat64LabelReq) Descriptor() ([]byte, []int) {
-	return file_magnetar_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use PutSemverLabelReq.ProtoReflect.Descriptor instead.
+func (*PutSemverLabelReq) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *PutFloat64LabelReq) GetNodeId() string {
+func (x *PutSemverLabelReq) GetNodeId() string {
 	if x != nil {
 		return x.NodeId
 	}
 	return ""
 }
 
-func (x *PutFloat64LabelReq) GetLabel() *Float64Label {
+func (x *PutSemverLabelReq) GetLabel() *SemverLabel {
 	if x != nil {
 		return x.Label
 	}
 	return nil
 }
 
-func (x *PutFloat64LabelReq) GetOrg() string {
+func (x *PutSemverLabelReq) GetOrg() string {
 	if x != nil {
 		return x.Org
 	}
 	return ""
 }
 
-type PutStringLabelReq struct {
+func (x *PutSemverLabelReq) GetResourceVersion() int64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
+type PutStringSetLabelReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NodeId string       `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
-	Label  *StringLabel `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
-	Org    string       `protobuf:"bytes,3,opt,name=org,proto3" json:"org,omitempty"`
+	NodeId string          `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Label  *StringSetLabel `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Org    string          `protobuf:"bytes,3,opt,name=org,proto3" json:"org,omitempty"`
+	// optional, if set the label is put only if the node has not been modified since
+	ResourceVersion int64 `protobuf:"varint,4,opt,name=resourceVersion,proto3" json:"resourceVersion,omitempty"`
 }
 
-func (x *PutStringLabelReq) Reset() {
-	*x = PutStringLabelReq{}
+func (x *PutStringSetLabelReq) Reset() {
+	*x = PutStringSetLabelReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_magnetar_proto_msgTypes[19]
+		mi := &file_magnetar_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PutStringLabelReq) String() string {
+func (x *PutStringSetLabelReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutStringLabelReq) ProtoMessage() {}
+func (*PutStringSetLabelReq) ProtoMessage() {}
 
-func (x *PutStringLabelReq) ProtoReflect() protoreflect.Message {
-	mi := &file_magnetar_proto_msgTypes[19]
+func (x *PutStringSetLabelReq) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1004,32 +2533,39 @@ func (x *PutStringLabelReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutStringLabelReq.ProtoReflect.Descriptor instead.
-func (*PutStringLabelReq) Descriptor() ([]byte, []int) {
-	return file_magnetar_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use PutStringSetLabelReq.ProtoReflect.Descriptor instead.
+func (*PutStringSetLabelReq) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *PutStringLabelReq) GetNodeId() string {
+func (x *PutStringSetLabelReq) GetNodeId() string {
 	if x != nil {
 		return x.NodeId
 	}
 	return ""
 }
 
-func (x *PutStringLabelReq) GetLabel() *StringLabel {
+func (x *PutStringSetLabelReq) GetLabel() *StringSetLabel {
 	if x != nil {
 		return x.Label
 	}
 	return nil
 }
 
-func (x *PutStringLabelReq) GetOrg() string {
+func (x *PutStringSetLabelReq) GetOrg() string {
 	if x != nil {
 		return x.Org
 	}
 	return ""
 }
 
+func (x *PutStringSetLabelReq) GetResourceVersion() int64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
 type PutLabelResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1041,7 +2577,7 @@ type PutLabelResp struct {
 func (x *PutLabelResp) Reset() {
 	*x = PutLabelResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_magnetar_proto_msgTypes[20]
+		mi := &file_magnetar_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1054,7 +2590,7 @@ func (x *PutLabelResp) String() string {
 func (*PutLabelResp) ProtoMessage() {}
 
 func (x *PutLabelResp) ProtoReflect() protoreflect.Message {
-	mi := &file_magnetar_proto_msgTypes[20]
+	mi := &file_magnetar_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1067,7 +2603,7 @@ func (x *PutLabelResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PutLabelResp.ProtoReflect.Descriptor instead.
 func (*PutLabelResp) Descriptor() ([]byte, []int) {
-	return file_magnetar_proto_rawDescGZIP(), []int{20}
+	return file_magnetar_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *PutLabelResp) GetNode() *NodeStringified {
@@ -1085,12 +2621,14 @@ type DeleteLabelReq struct {
 	NodeId   string `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
 	LabelKey string `protobuf:"bytes,2,opt,name=labelKey,proto3" json:"labelKey,omitempty"`
 	Org      string `protobuf:"bytes,3,opt,name=org,proto3" json:"org,omitempty"`
+	// optional, if set the label is deleted only if the node has not been modified since
+	ResourceVersion int64 `protobuf:"varint,4,opt,name=resourceVersion,proto3" json:"resourceVersion,omitempty"`
 }
 
 func (x *DeleteLabelReq) Reset() {
 	*x = DeleteLabelReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_magnetar_proto_msgTypes[21]
+		mi := &file_magnetar_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1103,7 +2641,7 @@ func (x *DeleteLabelReq) String() string {
 func (*DeleteLabelReq) ProtoMessage() {}
 
 func (x *DeleteLabelReq) ProtoReflect() protoreflect.Message {
-	mi := &file_magnetar_proto_msgTypes[21]
+	mi := &file_magnetar_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1116,7 +2654,7 @@ func (x *DeleteLabelReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteLabelReq.ProtoReflect.Descriptor instead.
 func (*DeleteLabelReq) Descriptor() ([]byte, []int) {
-	return file_magnetar_proto_rawDescGZIP(), []int{21}
+	return file_magnetar_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *DeleteLabelReq) GetNodeId() string {
@@ -1140,6 +2678,13 @@ func (x *DeleteLabelReq) GetOrg() string {
 	return ""
 }
 
+func (x *DeleteLabelReq) GetResourceVersion() int64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
 type DeleteLabelResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1151,7 +2696,7 @@ type DeleteLabelResp struct {
 func (x *DeleteLabelResp) Reset() {
 	*x = DeleteLabelResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_magnetar_proto_msgTypes[22]
+		mi := &file_magnetar_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1164,7 +2709,7 @@ func (x *DeleteLabelResp) String() string {
 func (*DeleteLabelResp) ProtoMessage() {}
 
 func (x *DeleteLabelResp) ProtoReflect() protoreflect.Message {
-	mi := &file_magnetar_proto_msgTypes[22]
+	mi := &file_magnetar_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1177,7 +2722,7 @@ func (x *DeleteLabelResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteLabelResp.ProtoReflect.Descriptor instead.
 func (*DeleteLabelResp) Descriptor() ([]byte, []int) {
-	return file_magnetar_proto_rawDescGZIP(), []int{22}
+	return file_magnetar_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *DeleteLabelResp) GetNode() *NodeStringified {
@@ -1187,6 +2732,134 @@ func (x *DeleteLabelResp) GetNode() *NodeStringified {
 	return nil
 }
 
+type WatchNodesReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// empty org watches the node pool
+	Org   string      `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	Query []*Selector `protobuf:"bytes,2,rep,name=query,proto3" json:"query,omitempty"`
+	// resume from the revision, 0 watches only new changes
+	Revision int64 `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (x *WatchNodesReq) Reset() {
+	*x = WatchNodesReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchNodesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchNodesReq) ProtoMessage() {}
+
+func (x *WatchNodesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchNodesReq.ProtoReflect.Descriptor instead.
+func (*WatchNodesReq) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *WatchNodesReq) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *WatchNodesReq) GetQuery() []*Selector {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *WatchNodesReq) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type WatchNodesResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type     WatchNodesResp_EventType `protobuf:"varint,1,opt,name=type,proto3,enum=proto.WatchNodesResp_EventType" json:"type,omitempty"`
+	Node     *NodeStringified         `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	Revision int64                    `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (x *WatchNodesResp) Reset() {
+	*x = WatchNodesResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_magnetar_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchNodesResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchNodesResp) ProtoMessage() {}
+
+func (x *WatchNodesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_magnetar_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchNodesResp.ProtoReflect.Descriptor instead.
+func (*WatchNodesResp) Descriptor() ([]byte, []int) {
+	return file_magnetar_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *WatchNodesResp) GetType() WatchNodesResp_EventType {
+	if x != nil {
+		return x.Type
+	}
+	return WatchNodesResp_Added
+}
+
+func (x *WatchNodesResp) GetNode() *NodeStringified {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *WatchNodesResp) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
 var File_magnetar_proto protoreflect.FileDescriptor
 
 var file_magnetar_proto_rawDesc = []byte{
@@ -1207,146 +2880,427 @@ var file_magnetar_proto_rawDesc = []byte{
 	0x46, 0x72, 0x6f, 0x6d, 0x4f, 0x72, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a, 0x04, 0x6e,
 	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x69, 0x66, 0x69, 0x65,
-	0x64, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x4c, 0x0a, 0x11, 0x43, 0x6c, 0x61, 0x69, 0x6d,
-	0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x12, 0x25, 0x0a, 0x05,
-	0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x05, 0x71, 0x75,
-	0x65, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6f, 0x72, 0x67, 0x22, 0x40, 0x0a, 0x12, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x4f, 0x77,
-	0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a, 0x04, 0x6e,
-	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x69, 0x66, 0x69, 0x65,
-	0x64, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x11, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x41,
-	0x6c, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x22, 0x40, 0x0a, 0x10, 0x4c, 0x69,
-	0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2c,
-	0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
-	0x69, 0x66, 0x69, 0x65, 0x64, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x11, 0x0a, 0x0f,
-	0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x22,
-	0x40, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52,
+	0x64, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0xe7, 0x02, 0x0a, 0x11, 0x43, 0x6c, 0x61, 0x69,
+	0x6d, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x12, 0x25, 0x0a,
+	0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x05, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x28, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x4e, 0x6f, 0x74, 0x52, 0x65, 0x61, 0x64, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4e, 0x6f, 0x74, 0x52, 0x65, 0x61, 0x64, 0x79,
+	0x12, 0x20, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x45, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68,
+	0x69, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79,
+	0x52, 0x75, 0x6e, 0x1a, 0x3c, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0xa7, 0x03, 0x0a, 0x12, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x4f, 0x77, 0x6e, 0x65, 0x72,
+	0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e,
+	0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x69, 0x66, 0x69, 0x65, 0x64, 0x52, 0x04,
+	0x6e, 0x6f, 0x64, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6c, 0x61,
+	0x69, 0x6d, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06, 0x66,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x58, 0x0a,
+	0x0f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x6c, 0x61, 0x69, 0x6d, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73,
+	0x70, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x61,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6a, 0x6f, 0x69, 0x6e, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6a, 0x6f,
+	0x69, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x61, 0x67,
+	0x61, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x61, 0x67, 0x61, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x42, 0x0a, 0x14, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x33, 0x0a, 0x0f, 0x47,
+	0x65, 0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x53, 0x61, 0x67, 0x61, 0x52, 0x65, 0x71, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67,
+	0x22, 0x38, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x53, 0x61, 0x67, 0x61,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x24, 0x0a, 0x04, 0x73, 0x61, 0x67, 0x61, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d,
+	0x53, 0x61, 0x67, 0x61, 0x52, 0x04, 0x73, 0x61, 0x67, 0x61, 0x22, 0x64, 0x0a, 0x0f, 0x52, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x12, 0x25, 0x0a,
+	0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x05, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67,
+	0x22, 0xa3, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2e, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x69, 0x66, 0x69, 0x65, 0x64, 0x42, 0x02, 0x18, 0x01, 0x52,
+	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6c,
+	0x61, 0x69, 0x6d, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x2e, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3d, 0x0a, 0x11, 0x44, 0x65, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x6e,
+	0x6f, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64,
+	0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6f, 0x72, 0x67, 0x22, 0x40, 0x0a, 0x12, 0x44, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a, 0x04, 0x6e,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x69, 0x66, 0x69, 0x65,
+	0x64, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x3c, 0x0a, 0x0c, 0x43, 0x6c, 0x61, 0x69, 0x6d,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x33, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x22, 0x42, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2e,
+	0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x5f,
+	0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22,
+	0x6c, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x30, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50,
+	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x36, 0x0a,
+	0x12, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6f, 0x72, 0x67, 0x22, 0x45, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2e, 0x0a, 0x09,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x4b, 0x0a, 0x0f,
+	0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x66, 0x0a, 0x10, 0x4c, 0x69, 0x73,
+	0x74, 0x41, 0x6c, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2c, 0x0a,
+	0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x69,
+	0x66, 0x69, 0x65, 0x64, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e,
+	0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x4b, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f,
+	0x6c, 0x52, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x66,
+	0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x2c, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x69, 0x66, 0x69, 0x65, 0x64, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x62, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72,
+	0x67, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10,
+	0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x6b, 0x0a, 0x15, 0x4c, 0x69,
+	0x73, 0x74, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
 	0x65, 0x73, 0x70, 0x12, 0x2c, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
 	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53,
 	0x74, 0x72, 0x69, 0x6e, 0x67, 0x69, 0x66, 0x69, 0x65, 0x64, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65,
-	0x73, 0x22, 0x28, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e, 0x65,
-	0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x22, 0x45, 0x0a, 0x15, 0x4c,
-	0x69, 0x73, 0x74, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x12, 0x2c, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
-	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x69, 0x66, 0x69, 0x65, 0x64, 0x52, 0x05, 0x6e, 0x6f, 0x64,
-	0x65, 0x73, 0x22, 0x58, 0x0a, 0x08, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x1a,
-	0x0a, 0x08, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x68,
-	0x6f, 0x75, 0x6c, 0x64, 0x42, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x68,
-	0x6f, 0x75, 0x6c, 0x64, 0x42, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x39, 0x0a, 0x10,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71,
-	0x12, 0x25, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0x41, 0x0a, 0x11, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xb8, 0x01, 0x0a, 0x08, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x42, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x42, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x22, 0x61, 0x0a, 0x07, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x1a, 0x0a,
+	0x08, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x4e, 0x0a, 0x0d, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x2d, 0x0a, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0xff, 0x01, 0x0a, 0x10, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4e,
+	0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x25, 0x0a, 0x05, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x69,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4e, 0x6f, 0x74, 0x52, 0x65, 0x61, 0x64, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4e, 0x6f, 0x74,
+	0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x28, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x42, 0x79, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x42,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x67, 0x0a, 0x11, 0x51, 0x75, 0x65, 0x72, 0x79,
 	0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2c, 0x0a, 0x05,
 	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x69, 0x66,
-	0x69, 0x65, 0x64, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x50, 0x0a, 0x15, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x12, 0x25, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72,
-	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x22, 0x46, 0x0a, 0x16,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64,
-	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2c, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f,
-	0x64, 0x65, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x69, 0x66, 0x69, 0x65, 0x64, 0x52, 0x05, 0x6e,
-	0x6f, 0x64, 0x65, 0x73, 0x22, 0x63, 0x0a, 0x0f, 0x50, 0x75, 0x74, 0x42, 0x6f, 0x6f, 0x6c, 0x4c,
-	0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12,
-	0x26, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x69, 0x65, 0x64, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65,
+	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x22, 0xec, 0x01, 0x0a, 0x15, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e,
+	0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x12, 0x25, 0x0a, 0x05, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6f, 0x72, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x20, 0x0a,
+	0x0b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12,
+	0x28, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79,
+	0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22,
+	0x6c, 0x0a, 0x16, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x64,
+	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2c, 0x0a, 0x05, 0x6e, 0x6f, 0x64,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x69, 0x66, 0x69, 0x65, 0x64,
+	0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50,
+	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x8d, 0x01,
+	0x0a, 0x0f, 0x50, 0x75, 0x74, 0x42, 0x6f, 0x6f, 0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65,
+	0x71, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6f, 0x72, 0x67, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x93, 0x01,
+	0x0a, 0x12, 0x50, 0x75, 0x74, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x05,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x4c, 0x61, 0x62, 0x65, 0x6c,
 	0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x22, 0x69, 0x0a, 0x12, 0x50, 0x75, 0x74,
-	0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x12,
-	0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46,
-	0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x61, 0x62,
-	0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6f, 0x72, 0x67, 0x22, 0x67, 0x0a, 0x11, 0x50, 0x75, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x91, 0x01, 0x0a, 0x11, 0x50, 0x75, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e,
 	0x67, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64,
 	0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49,
 	0x64, 0x12, 0x28, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c,
 	0x61, 0x62, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6f,
-	0x72, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x22, 0x3a, 0x0a,
-	0x0c, 0x50, 0x75, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a,
-	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72,
+	0x72, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x28, 0x0a,
+	0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x8f, 0x01, 0x0a, 0x10, 0x50, 0x75, 0x74, 0x49,
+	0x6e, 0x74, 0x36, 0x34, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06,
+	0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f,
+	0x64, 0x65, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x74, 0x36,
+	0x34, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x10, 0x0a,
+	0x03, 0x6f, 0x72, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12,
+	0x28, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x97, 0x01, 0x0a, 0x14, 0x50, 0x75,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52,
+	0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x91, 0x01, 0x0a, 0x11, 0x50, 0x75, 0x74, 0x53, 0x65, 0x6d, 0x76, 0x65,
+	0x72, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64,
+	0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49,
+	0x64, 0x12, 0x28, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6d, 0x76, 0x65, 0x72, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6f,
+	0x72, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x28, 0x0a,
+	0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x97, 0x01, 0x0a, 0x14, 0x50, 0x75, 0x74, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x05,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x22, 0x3a, 0x0a, 0x0c, 0x50, 0x75, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x2a, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x69, 0x66, 0x69, 0x65, 0x64, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x80, 0x01,
+	0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6f, 0x72, 0x67, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x3d, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x69, 0x66, 0x69, 0x65, 0x64, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22,
+	0x64, 0x0a, 0x0d, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x12, 0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f,
+	0x72, 0x67, 0x12, 0x25, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xc0, 0x01, 0x0a, 0x0e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x33, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a,
+	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x69, 0x66,
-	0x69, 0x65, 0x64, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x56, 0x0a, 0x0e, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x6e,
-	0x6f, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64,
-	0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6f, 0x72, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x72,
-	0x67, 0x22, 0x3d, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c,
-	0x52, 0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53,
-	0x74, 0x72, 0x69, 0x6e, 0x67, 0x69, 0x66, 0x69, 0x65, 0x64, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65,
-	0x32, 0xd6, 0x06, 0x0a, 0x08, 0x4d, 0x61, 0x67, 0x6e, 0x65, 0x74, 0x61, 0x72, 0x12, 0x4a, 0x0a,
-	0x0f, 0x47, 0x65, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c,
-	0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x72, 0x6f, 0x6d,
-	0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x50,
-	0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0a, 0x47, 0x65, 0x74,
-	0x46, 0x72, 0x6f, 0x6d, 0x4f, 0x72, 0x67, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x47, 0x65, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x4f, 0x72, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x4f, 0x72, 0x67,
-	0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x4f,
-	0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52,
-	0x65, 0x71, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d,
-	0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12,
-	0x41, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x12,
-	0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65,
-	0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70,
-	0x22, 0x00, 0x12, 0x50, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e,
-	0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
-	0x73, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4e, 0x6f, 0x64,
-	0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x18,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4e, 0x6f, 0x64, 0x65,
-	0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x12, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73,
-	0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x72,
-	0x67, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1d,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x72, 0x67, 0x4f,
-	0x77, 0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12,
-	0x3d, 0x0a, 0x0c, 0x50, 0x75, 0x74, 0x42, 0x6f, 0x6f, 0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12,
-	0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x74, 0x42, 0x6f, 0x6f, 0x6c, 0x4c,
-	0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x50, 0x75, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x43,
-	0x0a, 0x0f, 0x50, 0x75, 0x74, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x4c, 0x61, 0x62, 0x65,
-	0x6c, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x74, 0x46, 0x6c, 0x6f,
-	0x61, 0x74, 0x36, 0x34, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x73,
-	0x70, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0e, 0x50, 0x75, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
-	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75,
-	0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x1a,
+	0x69, 0x65, 0x64, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x31, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x64, 0x64, 0x65, 0x64, 0x10, 0x00, 0x12, 0x0c, 0x0a,
+	0x08, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x10, 0x02, 0x32, 0xd2, 0x0c, 0x0a, 0x08, 0x4d, 0x61, 0x67,
+	0x6e, 0x65, 0x74, 0x61, 0x72, 0x12, 0x4a, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x46, 0x72, 0x6f, 0x6d,
+	0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x47, 0x65, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c,
+	0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x46,
+	0x72, 0x6f, 0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x22,
+	0x00, 0x12, 0x3b, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x4f, 0x72, 0x67, 0x12,
+	0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x4f,
+	0x72, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65,
+	0x74, 0x46, 0x72, 0x6f, 0x6d, 0x4f, 0x72, 0x67, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x47,
+	0x0a, 0x0e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70,
+	0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x4f, 0x77,
+	0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69,
+	0x70, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x4e,
+	0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x1a,
+	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65,
+	0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x11, 0x4c, 0x69,
+	0x73, 0x74, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12,
+	0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x67, 0x4f,
+	0x77, 0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e, 0x65,
+	0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0d,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x17, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x50,
+	0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x22, 0x00, 0x12, 0x53, 0x0a, 0x12, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x72, 0x67, 0x4f, 0x77,
+	0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4e, 0x6f,
+	0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x4f, 0x72, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0c, 0x50, 0x75, 0x74, 0x42, 0x6f,
+	0x6f, 0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x50, 0x75, 0x74, 0x42, 0x6f, 0x6f, 0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x1a,
 	0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c,
-	0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c,
-	0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c,
-	0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x17,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4e, 0x6f,
-	0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x42, 0x22, 0x5a, 0x20, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x31, 0x32, 0x73, 0x2f, 0x6d, 0x61, 0x67,
-	0x6e, 0x65, 0x74, 0x61, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0f, 0x50, 0x75, 0x74, 0x46, 0x6c, 0x6f,
+	0x61, 0x74, 0x36, 0x34, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x50, 0x75, 0x74, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x74,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0e, 0x50,
+	0x75, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x18, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x50, 0x75, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3f,
+	0x0a, 0x0d, 0x50, 0x75, 0x74, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12,
+	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x74, 0x49, 0x6e, 0x74, 0x36, 0x34,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x50, 0x75, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12,
+	0x47, 0x0a, 0x11, 0x50, 0x75, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65,
+	0x71, 0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x74, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0e, 0x50, 0x75, 0x74, 0x53,
+	0x65, 0x6d, 0x76, 0x65, 0x72, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x50, 0x75, 0x74, 0x53, 0x65, 0x6d, 0x76, 0x65, 0x72, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x74,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x11, 0x50,
+	0x75, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x74, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x53, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x75, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x12, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x41, 0x6c, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x4e, 0x6f, 0x64, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0a, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x22, 0x00, 0x30, 0x01, 0x12, 0x47, 0x0a, 0x0e, 0x44, 0x65, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x44, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x52,
+	0x65, 0x71, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12,
+	0x41, 0x0a, 0x0c, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12,
+	0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x71, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x4a,
+	0x0a, 0x0f, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c, 0x47, 0x65,
+	0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x53, 0x61, 0x67, 0x61, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x53, 0x61, 0x67, 0x61, 0x52,
+	0x65, 0x71, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6c,
+	0x61, 0x69, 0x6d, 0x53, 0x61, 0x67, 0x61, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x42, 0x22, 0x5a,
+	0x20, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x31, 0x32, 0x73,
+	0x2f, 0x6d, 0x61, 0x67, 0x6e, 0x65, 0x74, 0x61, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70,
+	0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1361,82 +3315,159 @@ func file_magnetar_proto_rawDescGZIP() []byte {
 	return file_magnetar_proto_rawDescData
 }
 
-var file_magnetar_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_magnetar_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_magnetar_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
 var file_magnetar_proto_goTypes = []interface{}{
-	(*GetFromNodePoolReq)(nil),     // 0: proto.GetFromNodePoolReq
-	(*GetFromNodePoolResp)(nil),    // 1: proto.GetFromNodePoolResp
-	(*GetFromOrgReq)(nil),          // 2: proto.GetFromOrgReq
-	(*GetFromOrgResp)(nil),         // 3: proto.GetFromOrgResp
-	(*ClaimOwnershipReq)(nil),      // 4: proto.ClaimOwnershipReq
-	(*ClaimOwnershipResp)(nil),     // 5: proto.ClaimOwnershipResp
-	(*ListAllNodesReq)(nil),        // 6: proto.ListAllNodesReq
-	(*ListAllNodesResp)(nil),       // 7: proto.ListAllNodesResp
-	(*ListNodePoolReq)(nil),        // 8: proto.ListNodePoolReq
-	(*ListNodePoolResp)(nil),       // 9: proto.ListNodePoolResp
-	(*ListOrgOwnedNodesReq)(nil),   // 10: proto.ListOrgOwnedNodesReq
-	(*ListOrgOwnedNodesResp)(nil),  // 11: proto.ListOrgOwnedNodesResp
-	(*Selector)(nil),               // 12: proto.Selector
-	(*QueryNodePoolReq)(nil),       // 13: proto.QueryNodePoolReq
-	(*QueryNodePoolResp)(nil),      // 14: proto.QueryNodePoolResp
-	(*QueryOrgOwnedNodesReq)(nil),  // 15: proto.QueryOrgOwnedNodesReq
-	(*QueryOrgOwnedNodesResp)(nil), // 16: proto.QueryOrgOwnedNodesResp
-	(*PutBoolLabelReq)(nil),        // 17: proto.PutBoolLabelReq
-	(*PutFloat64LabelReq)(nil),     // 18: proto.PutFloat64LabelReq
-	(*PutStringLabelReq)(nil),      // 19: proto.PutStringLabelReq
-	(*PutLabelResp)(nil),           // 20: proto.PutLabelResp
-	(*DeleteLabelReq)(nil),         // 21: proto.DeleteLabelReq
-	(*DeleteLabelResp)(nil),        // 22: proto.DeleteLabelResp
-	(*NodeStringified)(nil),        // 23: proto.NodeStringified
-	(*BoolLabel)(nil),              // 24: proto.BoolLabel
-	(*Float64Label)(nil),           // 25: proto.Float64Label
-	(*StringLabel)(nil),            // 26: proto.StringLabel
+	(WatchNodesResp_EventType)(0),  // 0: proto.WatchNodesResp.EventType
+	(*GetFromNodePoolReq)(nil),     // 1: proto.GetFromNodePoolReq
+	(*GetFromNodePoolResp)(nil),    // 2: proto.GetFromNodePoolResp
+	(*GetFromOrgReq)(nil),          // 3: proto.GetFromOrgReq
+	(*GetFromOrgResp)(nil),         // 4: proto.GetFromOrgResp
+	(*ClaimOwnershipReq)(nil),      // 5: proto.ClaimOwnershipReq
+	(*ClaimOwnershipResp)(nil),     // 6: proto.ClaimOwnershipResp
+	(*GetClaimSagaReq)(nil),        // 7: proto.GetClaimSagaReq
+	(*GetClaimSagaResp)(nil),       // 8: proto.GetClaimSagaResp
+	(*ReleaseNodesReq)(nil),        // 9: proto.ReleaseNodesReq
+	(*ReleaseNodesResp)(nil),       // 10: proto.ReleaseNodesResp
+	(*DeregisterNodeReq)(nil),      // 11: proto.DeregisterNodeReq
+	(*DeregisterNodeResp)(nil),     // 12: proto.DeregisterNodeResp
+	(*ClaimFailure)(nil),           // 13: proto.ClaimFailure
+	(*GetOperationReq)(nil),        // 14: proto.GetOperationReq
+	(*GetOperationResp)(nil),       // 15: proto.GetOperationResp
+	(*ListOperationsReq)(nil),      // 16: proto.ListOperationsReq
+	(*ListOperationsResp)(nil),     // 17: proto.ListOperationsResp
+	(*CancelOperationReq)(nil),     // 18: proto.CancelOperationReq
+	(*CancelOperationResp)(nil),    // 19: proto.CancelOperationResp
+	(*ListAllNodesReq)(nil),        // 20: proto.ListAllNodesReq
+	(*ListAllNodesResp)(nil),       // 21: proto.ListAllNodesResp
+	(*ListNodePoolReq)(nil),        // 22: proto.ListNodePoolReq
+	(*ListNodePoolResp)(nil),       // 23: proto.ListNodePoolResp
+	(*ListOrgOwnedNodesReq)(nil),   // 24: proto.ListOrgOwnedNodesReq
+	(*ListOrgOwnedNodesResp)(nil),  // 25: proto.ListOrgOwnedNodesResp
+	(*Selector)(nil),               // 26: proto.Selector
+	(*OrderBy)(nil),                // 27: proto.OrderBy
+	(*SelectorGroup)(nil),          // 28: proto.SelectorGroup
+	(*QueryNodePoolReq)(nil),       // 29: proto.QueryNodePoolReq
+	(*QueryNodePoolResp)(nil),      // 30: proto.QueryNodePoolResp
+	(*QueryOrgOwnedNodesReq)(nil),  // 31: proto.QueryOrgOwnedNodesReq
+	(*QueryOrgOwnedNodesResp)(nil), // 32: proto.QueryOrgOwnedNodesResp
+	(*PutBoolLabelReq)(nil),        // 33: proto.PutBoolLabelReq
+	(*PutFloat64LabelReq)(nil),     // 34: proto.PutFloat64LabelReq
+	(*PutStringLabelReq)(nil),      // 35: proto.PutStringLabelReq
+	(*PutInt64LabelReq)(nil),       // 36: proto.PutInt64LabelReq
+	(*PutTimestampLabelReq)(nil),   // 37: proto.PutTimestampLabelReq
+	(*PutSemverLabelReq)(nil),      // 38: proto.PutSemverLabelReq
+	(*PutStringSetLabelReq)(nil),   // 39: proto.PutStringSetLabelReq
+	(*PutLabelResp)(nil),           // 40: proto.PutLabelResp
+	(*DeleteLabelReq)(nil),         // 41: proto.DeleteLabelReq
+	(*DeleteLabelResp)(nil),        // 42: proto.DeleteLabelResp
+	(*WatchNodesReq)(nil),          // 43: proto.WatchNodesReq
+	(*WatchNodesResp)(nil),         // 44: proto.WatchNodesResp
+	nil,                            // 45: proto.ClaimOwnershipReq.ResourcesEntry
+	nil,                            // 46: proto.ClaimOwnershipResp.NamespaceQuotasEntry
+	(*NodeStringified)(nil),        // 47: proto.NodeStringified
+	(*Operation)(nil),              // 48: proto.Operation
+	(*ClaimSaga)(nil),              // 49: proto.ClaimSaga
+	(*BoolLabel)(nil),              // 50: proto.BoolLabel
+	(*Float64Label)(nil),           // 51: proto.Float64Label
+	(*StringLabel)(nil),            // 52: proto.StringLabel
+	(*Int64Label)(nil),             // 53: proto.Int64Label
+	(*TimestampLabel)(nil),         // 54: proto.TimestampLabel
+	(*SemverLabel)(nil),            // 55: proto.SemverLabel
+	(*StringSetLabel)(nil),         // 56: proto.StringSetLabel
 }
 var file_magnetar_proto_depIdxs = []int32{
-	23, // 0: proto.GetFromNodePoolResp.node:type_name -> proto.NodeStringified
-	23, // 1: proto.GetFromOrgResp.node:type_name -> proto.NodeStringified
-	12, // 2: proto.ClaimOwnershipReq.query:type_name -> proto.Selector
-	23, // 3: proto.ClaimOwnershipResp.node:type_name -> proto.NodeStringified
-	23, // 4: proto.ListAllNodesResp.nodes:type_name -> proto.NodeStringified
-	23, // 5: proto.ListNodePoolResp.nodes:type_name -> proto.NodeStringified
-	23, // 6: proto.ListOrgOwnedNodesResp.nodes:type_name -> proto.NodeStringified
-	12, // 7: proto.QueryNodePoolReq.query:type_name -> proto.Selector
-	23, // 8: proto.QueryNodePoolResp.nodes:type_name -> proto.NodeStringified
-	12, // 9: proto.QueryOrgOwnedNodesReq.query:type_name -> proto.Selector
-	23, // 10: proto.QueryOrgOwnedNodesResp.nodes:type_name -> proto.NodeStringified
-	24, // 11: proto.PutBoolLabelReq.label:type_name -> proto.BoolLabel
-	25, // 12: proto.PutFloat64LabelReq.label:type_name -> proto.Float64Label
-	26, // 13: proto.PutStringLabelReq.label:type_name -> proto.StringLabel
-	23, // 14: proto.PutLabelResp.node:type_name -> proto.NodeStringified
-	23, // 15: proto.DeleteLabelResp.node:type_name -> proto.NodeStringified
-	0,  // 16: proto.Magnetar.GetFromNodePool:input_type -> proto.GetFromNodePoolReq
-	2,  // 17: proto.Magnetar.GetFromOrg:input_type -> proto.GetFromOrgReq
-	4,  // 18: proto.Magnetar.ClaimOwnership:input_type -> proto.ClaimOwnershipReq
-	8,  // 19: proto.Magnetar.ListNodePool:input_type -> proto.ListNodePoolReq
-	10, // 20: proto.Magnetar.ListOrgOwnedNodes:input_type -> proto.ListOrgOwnedNodesReq
-	13, // 21: proto.Magnetar.QueryNodePool:input_type -> proto.QueryNodePoolReq
-	15, // 22: proto.Magnetar.QueryOrgOwnedNodes:input_type -> proto.QueryOrgOwnedNodesReq
-	17, // 23: proto.Magnetar.PutBoolLabel:input_type -> proto.PutBoolLabelReq
-	18, // 24: proto.Magnetar.PutFloat64Label:input_type -> proto.PutFloat64LabelReq
-	19, // 25: proto.Magnetar.PutStringLabel:input_type -> proto.PutStringLabelReq
-	21, // 26: proto.Magnetar.DeleteLabel:input_type -> proto.DeleteLabelReq
-	6,  // 27: proto.Magnetar.ListAllNodes:input_type -> proto.ListAllNodesReq
-	1,  // 28: proto.Magnetar.GetFromNodePool:output_type -> proto.GetFromNodePoolResp
-	3,  // 29: proto.Magnetar.GetFromOrg:output_type -> proto.GetFromOrgResp
-	5,  // 30: proto.Magnetar.ClaimOwnership:output_type -> proto.ClaimOwnershipResp
-	9,  // 31: proto.Magnetar.ListNodePool:output_type -> proto.ListNodePoolResp
-	11, // 32: proto.Magnetar.ListOrgOwnedNodes:output_type -> proto.ListOrgOwnedNodesResp
-	14, // 33: proto.Magnetar.QueryNodePool:output_type -> proto.QueryNodePoolResp
-	16, // 34: proto.Magnetar.QueryOrgOwnedNodes:output_type -> proto.QueryOrgOwnedNodesResp
-	20, // 35: proto.Magnetar.PutBoolLabel:output_type -> proto.PutLabelResp
-	20, // 36: proto.Magnetar.PutFloat64Label:output_type -> proto.PutLabelResp
-	20, // 37: proto.Magnetar.PutStringLabel:output_type -> proto.PutLabelResp
-	22, // 38: proto.Magnetar.DeleteLabel:output_type -> proto.DeleteLabelResp
-	7,  // 39: proto.Magnetar.ListAllNodes:output_type -> proto.ListAllNodesResp
-	28, // [28:40] is the sub-list for method output_type
-	16, // [16:28] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	47, // 0: proto.GetFromNodePoolResp.node:type_name -> proto.NodeStringified
+	47, // 1: proto.GetFromOrgResp.node:type_name -> proto.NodeStringified
+	26, // 2: proto.ClaimOwnershipReq.query:type_name -> proto.Selector
+	45, // 3: proto.ClaimOwnershipReq.resources:type_name -> proto.ClaimOwnershipReq.ResourcesEntry
+	47, // 4: proto.ClaimOwnershipResp.node:type_name -> proto.NodeStringified
+	13, // 5: proto.ClaimOwnershipResp.failed:type_name -> proto.ClaimFailure
+	46, // 6: proto.ClaimOwnershipResp.namespaceQuotas:type_name -> proto.ClaimOwnershipResp.NamespaceQuotasEntry
+	48, // 7: proto.ClaimOwnershipResp.operation:type_name -> proto.Operation
+	49, // 8: proto.GetClaimSagaResp.saga:type_name -> proto.ClaimSaga
+	26, // 9: proto.ReleaseNodesReq.query:type_name -> proto.Selector
+	47, // 10: proto.ReleaseNodesResp.node:type_name -> proto.NodeStringified
+	13, // 11: proto.ReleaseNodesResp.failed:type_name -> proto.ClaimFailure
+	48, // 12: proto.ReleaseNodesResp.operation:type_name -> proto.Operation
+	47, // 13: proto.DeregisterNodeResp.node:type_name -> proto.NodeStringified
+	48, // 14: proto.GetOperationResp.operation:type_name -> proto.Operation
+	48, // 15: proto.ListOperationsResp.operations:type_name -> proto.Operation
+	48, // 16: proto.CancelOperationResp.operation:type_name -> proto.Operation
+	47, // 17: proto.ListAllNodesResp.nodes:type_name -> proto.NodeStringified
+	47, // 18: proto.ListNodePoolResp.nodes:type_name -> proto.NodeStringified
+	47, // 19: proto.ListOrgOwnedNodesResp.nodes:type_name -> proto.NodeStringified
+	28, // 20: proto.Selector.group:type_name -> proto.SelectorGroup
+	26, // 21: proto.SelectorGroup.selectors:type_name -> proto.Selector
+	26, // 22: proto.QueryNodePoolReq.query:type_name -> proto.Selector
+	27, // 23: proto.QueryNodePoolReq.orderBy:type_name -> proto.OrderBy
+	47, // 24: proto.QueryNodePoolResp.nodes:type_name -> proto.NodeStringified
+	26, // 25: proto.QueryOrgOwnedNodesReq.query:type_name -> proto.Selector
+	27, // 26: proto.QueryOrgOwnedNodesReq.orderBy:type_name -> proto.OrderBy
+	47, // 27: proto.QueryOrgOwnedNodesResp.nodes:type_name -> proto.NodeStringified
+	50, // 28: proto.PutBoolLabelReq.label:type_name -> proto.BoolLabel
+	51, // 29: proto.PutFloat64LabelReq.label:type_name -> proto.Float64Label
+	52, // 30: proto.PutStringLabelReq.label:type_name -> proto.StringLabel
+	53, // 31: proto.PutInt64LabelReq.label:type_name -> proto.Int64Label
+	54, // 32: proto.PutTimestampLabelReq.label:type_name -> proto.TimestampLabel
+	55, // 33: proto.PutSemverLabelReq.label:type_name -> proto.SemverLabel
+	56, // 34: proto.PutStringSetLabelReq.label:type_name -> proto.StringSetLabel
+	47, // 35: proto.PutLabelResp.node:type_name -> proto.NodeStringified
+	47, // 36: proto.DeleteLabelResp.node:type_name -> proto.NodeStringified
+	26, // 37: proto.WatchNodesReq.query:type_name -> proto.Selector
+	0,  // 38: proto.WatchNodesResp.type:type_name -> proto.WatchNodesResp.EventType
+	47, // 39: proto.WatchNodesResp.node:type_name -> proto.NodeStringified
+	1,  // 40: proto.Magnetar.GetFromNodePool:input_type -> proto.GetFromNodePoolReq
+	3,  // 41: proto.Magnetar.GetFromOrg:input_type -> proto.GetFromOrgReq
+	5,  // 42: proto.Magnetar.ClaimOwnership:input_type -> proto.ClaimOwnershipReq
+	22, // 43: proto.Magnetar.ListNodePool:input_type -> proto.ListNodePoolReq
+	24, // 44: proto.Magnetar.ListOrgOwnedNodes:input_type -> proto.ListOrgOwnedNodesReq
+	29, // 45: proto.Magnetar.QueryNodePool:input_type -> proto.QueryNodePoolReq
+	31, // 46: proto.Magnetar.QueryOrgOwnedNodes:input_type -> proto.QueryOrgOwnedNodesReq
+	33, // 47: proto.Magnetar.PutBoolLabel:input_type -> proto.PutBoolLabelReq
+	34, // 48: proto.Magnetar.PutFloat64Label:input_type -> proto.PutFloat64LabelReq
+	35, // 49: proto.Magnetar.PutStringLabel:input_type -> proto.PutStringLabelReq
+	36, // 50: proto.Magnetar.PutInt64Label:input_type -> proto.PutInt64LabelReq
+	37, // 51: proto.Magnetar.PutTimestampLabel:input_type -> proto.PutTimestampLabelReq
+	38, // 52: proto.Magnetar.PutSemverLabel:input_type -> proto.PutSemverLabelReq
+	39, // 53: proto.Magnetar.PutStringSetLabel:input_type -> proto.PutStringSetLabelReq
+	41, // 54: proto.Magnetar.DeleteLabel:input_type -> proto.DeleteLabelReq
+	20, // 55: proto.Magnetar.ListAllNodes:input_type -> proto.ListAllNodesReq
+	43, // 56: proto.Magnetar.WatchNodes:input_type -> proto.WatchNodesReq
+	11, // 57: proto.Magnetar.DeregisterNode:input_type -> proto.DeregisterNodeReq
+	9,  // 58: proto.Magnetar.ReleaseNodes:input_type -> proto.ReleaseNodesReq
+	14, // 59: proto.Magnetar.GetOperation:input_type -> proto.GetOperationReq
+	16, // 60: proto.Magnetar.ListOperations:input_type -> proto.ListOperationsReq
+	18, // 61: proto.Magnetar.CancelOperation:input_type -> proto.CancelOperationReq
+	7,  // 62: proto.Magnetar.GetClaimSaga:input_type -> proto.GetClaimSagaReq
+	2,  // 63: proto.Magnetar.GetFromNodePool:output_type -> proto.GetFromNodePoolResp
+	4,  // 64: proto.Magnetar.GetFromOrg:output_type -> proto.GetFromOrgResp
+	6,  // 65: proto.Magnetar.ClaimOwnership:output_type -> proto.ClaimOwnershipResp
+	23, // 66: proto.Magnetar.ListNodePool:output_type -> proto.ListNodePoolResp
+	25, // 67: proto.Magnetar.ListOrgOwnedNodes:output_type -> proto.ListOrgOwnedNodesResp
+	30, // 68: proto.Magnetar.QueryNodePool:output_type -> proto.QueryNodePoolResp
+	32, // 69: proto.Magnetar.QueryOrgOwnedNodes:output_type -> proto.QueryOrgOwnedNodesResp
+	40, // 70: proto.Magnetar.PutBoolLabel:output_type -> proto.PutLabelResp
+	40, // 71: proto.Magnetar.PutFloat64Label:output_type -> proto.PutLabelResp
+	40, // 72: proto.Magnetar.PutStringLabel:output_type -> proto.PutLabelResp
+	40, // 73: proto.Magnetar.PutInt64Label:output_type -> proto.PutLabelResp
+	40, // 74: proto.Magnetar.PutTimestampLabel:output_type -> proto.PutLabelResp
+	40, // 75: proto.Magnetar.PutSemverLabel:output_type -> proto.PutLabelResp
+	40, // 76: proto.Magnetar.PutStringSetLabel:output_type -> proto.PutLabelResp
+	42, // 77: proto.Magnetar.DeleteLabel:output_type -> proto.DeleteLabelResp
+	21, // 78: proto.Magnetar.ListAllNodes:output_type -> proto.ListAllNodesResp
+	44, // 79: proto.Magnetar.WatchNodes:output_type -> proto.WatchNodesResp
+	12, // 80: proto.Magnetar.DeregisterNode:output_type -> proto.DeregisterNodeResp
+	10, // 81: proto.Magnetar.ReleaseNodes:output_type -> proto.ReleaseNodesResp
+	15, // 82: proto.Magnetar.GetOperation:output_type -> proto.GetOperationResp
+	17, // 83: proto.Magnetar.ListOperations:output_type -> proto.ListOperationsResp
+	19, // 84: proto.Magnetar.CancelOperation:output_type -> proto.CancelOperationResp
+	8,  // 85: proto.Magnetar.GetClaimSaga:output_type -> proto.GetClaimSagaResp
+	63, // [63:86] is the sub-list for method output_type
+	40, // [40:63] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_magnetar_proto_init() }
@@ -1519,7 +3550,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListAllNodesReq); i {
+			switch v := v.(*GetClaimSagaReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1531,7 +3562,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListAllNodesResp); i {
+			switch v := v.(*GetClaimSagaResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1543,7 +3574,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListNodePoolReq); i {
+			switch v := v.(*ReleaseNodesReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1555,7 +3586,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListNodePoolResp); i {
+			switch v := v.(*ReleaseNodesResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1567,7 +3598,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListOrgOwnedNodesReq); i {
+			switch v := v.(*DeregisterNodeReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1579,7 +3610,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListOrgOwnedNodesResp); i {
+			switch v := v.(*DeregisterNodeResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1591,7 +3622,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Selector); i {
+			switch v := v.(*ClaimFailure); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1603,7 +3634,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryNodePoolReq); i {
+			switch v := v.(*GetOperationReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1615,7 +3646,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryNodePoolResp); i {
+			switch v := v.(*GetOperationResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1627,7 +3658,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryOrgOwnedNodesReq); i {
+			switch v := v.(*ListOperationsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1639,7 +3670,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryOrgOwnedNodesResp); i {
+			switch v := v.(*ListOperationsResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1651,7 +3682,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PutBoolLabelReq); i {
+			switch v := v.(*CancelOperationReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1663,7 +3694,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PutFloat64LabelReq); i {
+			switch v := v.(*CancelOperationResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1675,7 +3706,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PutStringLabelReq); i {
+			switch v := v.(*ListAllNodesReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1687,7 +3718,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PutLabelResp); i {
+			switch v := v.(*ListAllNodesResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1699,7 +3730,7 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteLabelReq); i {
+			switch v := v.(*ListNodePoolReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1711,6 +3742,234 @@ func file_magnetar_proto_init() {
 			}
 		}
 		file_magnetar_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListNodePoolResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListOrgOwnedNodesReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListOrgOwnedNodesResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Selector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderBy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SelectorGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryNodePoolReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryNodePoolResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryOrgOwnedNodesReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryOrgOwnedNodesResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PutBoolLabelReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PutFloat64LabelReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PutStringLabelReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PutInt64LabelReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PutTimestampLabelReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PutSemverLabelReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PutStringSetLabelReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PutLabelResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteLabelReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteLabelResp); i {
 			case 0:
 				return &v.state
@@ -1722,19 +3981,44 @@ func file_magnetar_proto_init() {
 				return nil
 			}
 		}
+		file_magnetar_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchNodesReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_magnetar_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchNodesResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_magnetar_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   23,
+			NumEnums:      1,
+			NumMessages:   46,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_magnetar_proto_goTypes,
 		DependencyIndexes: file_magnetar_proto_depIdxs,
+		EnumInfos:         file_magnetar_proto_enumTypes,
 		MessageInfos:      file_magnetar_proto_msgTypes,
 	}.Build()
 	File_magnetar_proto = out.File
@@ -32,8 +32,20 @@ type MagnetarClient interface {
 	PutBoolLabel(ctx context.Context, in *PutBoolLabelReq, opts ...grpc.CallOption) (*PutLabelResp, error)
 	PutFloat64Label(ctx context.Context, in *PutFloat64LabelReq, opts ...grpc.CallOption) (*PutLabelResp, error)
 	PutStringLabel(ctx context.Context, in *PutStringLabelReq, opts ...grpc.CallOption) (*PutLabelResp, error)
+	PutInt64Label(ctx context.Context, in *PutInt64LabelReq, opts ...grpc.CallOption) (*PutLabelResp, error)
+	PutTimestampLabel(ctx context.Context, in *PutTimestampLabelReq, opts ...grpc.CallOption) (*PutLabelResp, error)
+	PutSemverLabel(ctx context.Context, in *PutSemverLabelReq, opts ...grpc.CallOption) (*PutLabelResp, error)
+	PutStringSetLabel(ctx context.Context, in *PutStringSetLabelReq, opts ...grpc.CallOption) (*PutLabelResp, error)
 	DeleteLabel(ctx context.Context, in *DeleteLabelReq, opts ...grpc.CallOption) (*DeleteLabelResp, error)
 	ListAllNodes(ctx context.Context, in *ListAllNodesReq, opts ...grpc.CallOption) (*ListAllNodesResp, error)
+	WatchNodes(ctx context.Context, in *WatchNodesReq, opts ...grpc.CallOption) (Magnetar_WatchNodesClient, error)
+	DeregisterNode(ctx context.Context, in *DeregisterNodeReq, opts ...grpc.CallOption) (*DeregisterNodeResp, error)
+	ReleaseNodes(ctx context.Context, in *ReleaseNodesReq, opts ...grpc.CallOption) (*ReleaseNodesResp, error)
+	GetOperation(ctx context.Context, in *GetOperationReq, opts ...grpc.CallOption) (*GetOperationResp, error)
+	ListOperations(ctx context.Context, in *ListOperationsReq, opts ...grpc.CallOption) (*ListOperationsResp, error)
+	CancelOperation(ctx context.Context, in *CancelOperationReq, opts ...grpc.CallOption) (*CancelOperationResp, error)
+	// reports a claim operation the way it was reported before claims became operations
+	GetClaimSaga(ctx context.Context, in *GetClaimSagaReq, opts ...grpc.CallOption) (*GetClaimSagaResp, error)
 }
 
 type magnetarClient struct {
@@ -134,6 +146,42 @@ func (c *magnetarClient) PutStringLabel(ctx context.Context, in *PutStringLabelR
 	return out, nil
 }
 
+func (c *magnetarClient) PutInt64Label(ctx context.Context, in *PutInt64LabelReq, opts ...grpc.CallOption) (*PutLabelResp, error) {
+	out := new(PutLabelResp)
+	err := c.cc.Invoke(ctx, "/proto.Magnetar/PutInt64Label", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *magnetarClient) PutTimestampLabel(ctx context.Context, in *PutTimestampLabelReq, opts ...grpc.CallOption) (*PutLabelResp, error) {
+	out := new(PutLabelResp)
+	err := c.cc.Invoke(ctx, "/proto.Magnetar/PutTimestampLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *magnetarClient) PutSemverLabel(ctx context.Context, in *PutSemverLabelReq, opts ...grpc.CallOption) (*PutLabelResp, error) {
+	out := new(PutLabelResp)
+	err := c.cc.Invoke(ctx, "/proto.Magnetar/PutSemverLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *magnetarClient) PutStringSetLabel(ctx context.Context, in *PutStringSetLabelReq, opts ...grpc.CallOption) (*PutLabelResp, error) {
+	out := new(PutLabelResp)
+	err := c.cc.Invoke(ctx, "/proto.Magnetar/PutStringSetLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *magnetarClient) DeleteLabel(ctx context.Context, in *DeleteLabelReq, opts ...grpc.CallOption) (*DeleteLabelResp, error) {
 	out := new(DeleteLabelResp)
 	err := c.cc.Invoke(ctx, "/proto.Magnetar/DeleteLabel", in, out, opts...)
@@ -152,6 +200,92 @@ func (c *magnetarClient) ListAllNodes(ctx context.Context, in *ListAllNodesReq,
 	return out, nil
 }
 
+func (c *magnetarClient) WatchNodes(ctx context.Context, in *WatchNodesReq, opts ...grpc.CallOption) (Magnetar_WatchNodesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Magnetar_ServiceDesc.Streams[0], "/proto.Magnetar/WatchNodes", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &magnetarWatchNodesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Magnetar_WatchNodesClient interface {
+	Recv() (*WatchNodesResp, error)
+	grpc.ClientStream
+}
+
+type magnetarWatchNodesClient struct {
+	grpc.ClientStream
+}
+
+func (x *magnetarWatchNodesClient) Recv() (*WatchNodesResp, error) {
+	m := new(WatchNodesResp)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *magnetarClient) DeregisterNode(ctx context.Context, in *DeregisterNodeReq, opts ...grpc.CallOption) (*DeregisterNodeResp, error) {
+	out := new(DeregisterNodeResp)
+	err := c.cc.Invoke(ctx, "/proto.Magnetar/DeregisterNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *magnetarClient) ReleaseNodes(ctx context.Context, in *ReleaseNodesReq, opts ...grpc.CallOption) (*ReleaseNodesResp, error) {
+	out := new(ReleaseNodesResp)
+	err := c.cc.Invoke(ctx, "/proto.Magnetar/ReleaseNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *magnetarClient) GetOperation(ctx context.Context, in *GetOperationReq, opts ...grpc.CallOption) (*GetOperationResp, error) {
+	out := new(GetOperationResp)
+	err := c.cc.Invoke(ctx, "/proto.Magnetar/GetOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *magnetarClient) ListOperations(ctx context.Context, in *ListOperationsReq, opts ...grpc.CallOption) (*ListOperationsResp, error) {
+	out := new(ListOperationsResp)
+	err := c.cc.Invoke(ctx, "/proto.Magnetar/ListOperations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *magnetarClient) CancelOperation(ctx context.Context, in *CancelOperationReq, opts ...grpc.CallOption) (*CancelOperationResp, error) {
+	out := new(CancelOperationResp)
+	err := c.cc.Invoke(ctx, "/proto.Magnetar/CancelOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *magnetarClient) GetClaimSaga(ctx context.Context, in *GetClaimSagaReq, opts ...grpc.CallOption) (*GetClaimSagaResp, error) {
+	out := new(GetClaimSagaResp)
+	err := c.cc.Invoke(ctx, "/proto.Magnetar/GetClaimSaga", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MagnetarServer is the server API for Magnetar service.
 // All implementations must embed UnimplementedMagnetarServer
 // for forward compatibility
@@ -166,8 +300,20 @@ type MagnetarServer interface {
 	PutBoolLabel(context.Context, *PutBoolLabelReq) (*PutLabelResp, error)
 	PutFloat64Label(context.Context, *PutFloat64LabelReq) (*PutLabelResp, error)
 	PutStringLabel(context.Context, *PutStringLabelReq) (*PutLabelResp, error)
+	PutInt64Label(context.Context, *PutInt64LabelReq) (*PutLabelResp, error)
+	PutTimestampLabel(context.Context, *PutTimestampLabelReq) (*PutLabelResp, error)
+	PutSemverLabel(context.Context, *PutSemverLabelReq) (*PutLabelResp, error)
+	PutStringSetLabel(context.Context, *PutStringSetLabelReq) (*PutLabelResp, error)
 	DeleteLabel(context.Context, *DeleteLabelReq) (*DeleteLabelResp, error)
 	ListAllNodes(context.Context, *ListAllNodesReq) (*ListAllNodesResp, error)
+	WatchNodes(*WatchNodesReq, Magnetar_WatchNodesServer) error
+	DeregisterNode(context.Context, *DeregisterNodeReq) (*DeregisterNodeResp, error)
+	ReleaseNodes(context.Context, *ReleaseNodesReq) (*ReleaseNodesResp, error)
+	GetOperation(context.Context, *GetOperationReq) (*GetOperationResp, error)
+	ListOperations(context.Context, *ListOperationsReq) (*ListOperationsResp, error)
+	CancelOperation(context.Context, *CancelOperationReq) (*CancelOperationResp, error)
+	// reports a claim operation the way it was reported before claims became operations
+	GetClaimSaga(context.Context, *GetClaimSagaReq) (*GetClaimSagaResp, error)
 	mustEmbedUnimplementedMagnetarServer()
 }
 
@@ -205,12 +351,45 @@ func (UnimplementedMagnetarServer) PutFloat64Label(context.Context, *PutFloat64L
 func (UnimplementedMagnetarServer) PutStringLabel(context.Context, *PutStringLabelReq) (*PutLabelResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PutStringLabel not implemented")
 }
+func (UnimplementedMagnetarServer) PutInt64Label(context.Context, *PutInt64LabelReq) (*PutLabelResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutInt64Label not implemented")
+}
+func (UnimplementedMagnetarServer) PutTimestampLabel(context.Context, *PutTimestampLabelReq) (*PutLabelResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutTimestampLabel not implemented")
+}
+func (UnimplementedMagnetarServer) PutSemverLabel(context.Context, *PutSemverLabelReq) (*PutLabelResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutSemverLabel not implemented")
+}
+func (UnimplementedMagnetarServer) PutStringSetLabel(context.Context, *PutStringSetLabelReq) (*PutLabelResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutStringSetLabel not implemented")
+}
 func (UnimplementedMagnetarServer) DeleteLabel(context.Context, *DeleteLabelReq) (*DeleteLabelResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteLabel not implemented")
 }
 func (UnimplementedMagnetarServer) ListAllNodes(context.Context, *ListAllNodesReq) (*ListAllNodesResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAllNodes not implemented")
 }
+func (UnimplementedMagnetarServer) WatchNodes(*WatchNodesReq, Magnetar_WatchNodesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNodes not implemented")
+}
+func (UnimplementedMagnetarServer) DeregisterNode(context.Context, *DeregisterNodeReq) (*DeregisterNodeResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeregisterNode not implemented")
+}
+func (UnimplementedMagnetarServer) ReleaseNodes(context.Context, *ReleaseNodesReq) (*ReleaseNodesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseNodes not implemented")
+}
+func (UnimplementedMagnetarServer) GetOperation(context.Context, *GetOperationReq) (*GetOperationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperation not implemented")
+}
+func (UnimplementedMagnetarServer) ListOperations(context.Context, *ListOperationsReq) (*ListOperationsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOperations not implemented")
+}
+func (UnimplementedMagnetarServer) CancelOperation(context.Context, *CancelOperationReq) (*CancelOperationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOperation not implemented")
+}
+func (UnimplementedMagnetarServer) GetClaimSaga(context.Context, *GetClaimSagaReq) (*GetClaimSagaResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClaimSaga not implemented")
+}
 func (UnimplementedMagnetarServer) mustEmbedUnimplementedMagnetarServer() {}
 
 // UnsafeMagnetarServer may be embedded to opt out of forward compatibility for this service.
@@ -404,6 +583,78 @@ func _Magnetar_PutStringLabel_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Magnetar_PutInt64Label_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutInt64LabelReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MagnetarServer).PutInt64Label(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Magnetar/PutInt64Label",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MagnetarServer).PutInt64Label(ctx, req.(*PutInt64LabelReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Magnetar_PutTimestampLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutTimestampLabelReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MagnetarServer).PutTimestampLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Magnetar/PutTimestampLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MagnetarServer).PutTimestampLabel(ctx, req.(*PutTimestampLabelReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Magnetar_PutSemverLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutSemverLabelReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MagnetarServer).PutSemverLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Magnetar/PutSemverLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MagnetarServer).PutSemverLabel(ctx, req.(*PutSemverLabelReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Magnetar_PutStringSetLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutStringSetLabelReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MagnetarServer).PutStringSetLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Magnetar/PutStringSetLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MagnetarServer).PutStringSetLabel(ctx, req.(*PutStringSetLabelReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Magnetar_DeleteLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteLabelReq)
 	if err := dec(in); err != nil {
@@ -440,6 +691,135 @@ func _Magnetar_ListAllNodes_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Magnetar_WatchNodes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchNodesReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MagnetarServer).WatchNodes(m, &magnetarWatchNodesServer{stream})
+}
+
+type Magnetar_WatchNodesServer interface {
+	Send(*WatchNodesResp) error
+	grpc.ServerStream
+}
+
+type magnetarWatchNodesServer struct {
+	grpc.ServerStream
+}
+
+func (x *magnetarWatchNodesServer) Send(m *WatchNodesResp) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Magnetar_DeregisterNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterNodeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MagnetarServer).DeregisterNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Magnetar/DeregisterNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MagnetarServer).DeregisterNode(ctx, req.(*DeregisterNodeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Magnetar_ReleaseNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseNodesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MagnetarServer).ReleaseNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Magnetar/ReleaseNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MagnetarServer).ReleaseNodes(ctx, req.(*ReleaseNodesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Magnetar_GetOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MagnetarServer).GetOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Magnetar/GetOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MagnetarServer).GetOperation(ctx, req.(*GetOperationReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Magnetar_ListOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOperationsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MagnetarServer).ListOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Magnetar/ListOperations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MagnetarServer).ListOperations(ctx, req.(*ListOperationsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Magnetar_CancelOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOperationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MagnetarServer).CancelOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Magnetar/CancelOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MagnetarServer).CancelOperation(ctx, req.(*CancelOperationReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Magnetar_GetClaimSaga_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClaimSagaReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MagnetarServer).GetClaimSaga(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Magnetar/GetClaimSaga",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MagnetarServer).GetClaimSaga(ctx, req.(*GetClaimSagaReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Magnetar_ServiceDesc is the grpc.ServiceDesc for Magnetar service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -487,6 +867,22 @@ var Magnetar_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PutStringLabel",
 			Handler:    _Magnetar_PutStringLabel_Handler,
 		},
+		{
+			MethodName: "PutInt64Label",
+			Handler:    _Magnetar_PutInt64Label_Handler,
+		},
+		{
+			MethodName: "PutTimestampLabel",
+			Handler:    _Magnetar_PutTimestampLabel_Handler,
+		},
+		{
+			MethodName: "PutSemverLabel",
+			Handler:    _Magnetar_PutSemverLabel_Handler,
+		},
+		{
+			MethodName: "PutStringSetLabel",
+			Handler:    _Magnetar_PutStringSetLabel_Handler,
+		},
 		{
 			MethodName: "DeleteLabel",
 			Handler:    _Magnetar_DeleteLabel_Handler,
@@ -495,7 +891,37 @@ var Magnetar_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAllNodes",
 			Handler:    _Magnetar_ListAllNodes_Handler,
 		},
+		{
+			MethodName: "DeregisterNode",
+			Handler:    _Magnetar_DeregisterNode_Handler,
+		},
+		{
+			MethodName: "ReleaseNodes",
+			Handler:    _Magnetar_ReleaseNodes_Handler,
+		},
+		{
+			MethodName: "GetOperation",
+			Handler:    _Magnetar_GetOperation_Handler,
+		},
+		{
+			MethodName: "ListOperations",
+			Handler:    _Magnetar_ListOperations_Handler,
+		},
+		{
+			MethodName: "CancelOperation",
+			Handler:    _Magnetar_CancelOperation_Handler,
+		},
+		{
+			MethodName: "GetClaimSaga",
+			Handler:    _Magnetar_GetClaimSaga_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchNodes",
+			Handler:       _Magnetar_WatchNodes_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "magnetar.proto",
 }
@@ -0,0 +1,405 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// query expressions are a comma separated list of selectors that all have to match, e.g.
+//
+//	os=linux, cpu>=4, !gpu, zone in (a,b), hostname prefix web-, kernel regex "^5\.1[0-9]"
+//
+// a bare key selects nodes having the label, !key the ones without it
+// selectors can be grouped with parentheses and combined with || (or), && (and, same as a comma)
+// and negated with !(...), and binds tighter than or, e.g.
+//
+//	(gpu=true, mem>64) || tier=premium
+//
+// keys and values are bare words, which may contain a single & or |, values containing spaces or special characters can be double quoted,
+// within quotes only \" and \\ are escape sequences, so regular expressions can be written as they are
+// keys starting with resources. select node resources instead of labels, e.g. resources.cpu>=8
+
+// SyntaxError points to the byte offset in the expression where parsing failed
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid query at position %d: %s", e.Pos, e.Msg)
+}
+
+// ParseQuery turns a query expression into selectors accepted by the query RPCs,
+// an empty expression matches all nodes
+func ParseQuery(expr string) ([]*Selector, error) {
+	p := &queryParser{
+		lexer: queryLexer{input: expr},
+	}
+	err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokEOF {
+		return make([]*Selector, 0), nil
+	}
+	selector, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("expected ',', '||' or end of query, found %s", p.tok)
+	}
+	// the top level conjunction is the query itself
+	if selector.Group != nil && selector.Group.Op == "and" {
+		return selector.Group.Selectors, nil
+	}
+	return []*Selector{selector}, nil
+}
+
+type queryParser struct {
+	lexer queryLexer
+	tok   queryToken
+}
+
+func (p *queryParser) parseOr() (*Selector, error) {
+	return p.parseGroup("or", p.isOr, p.parseAnd)
+}
+
+func (p *queryParser) parseAnd() (*Selector, error) {
+	return p.parseGroup("and", p.isAnd, p.parseUnary)
+}
+
+// parseGroup parses operands separated by the group operator,
+// a single operand is returned as it is
+func (p *queryParser) parseGroup(op string, isOp func() bool, parseOperand func() (*Selector, error)) (*Selector, error) {
+	selector, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	selectors := []*Selector{selector}
+	for isOp() {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		selector, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+	}
+	if len(selectors) == 1 {
+		return selectors[0], nil
+	}
+	return newGroup(op, selectors), nil
+}
+
+func (p *queryParser) parseUnary() (*Selector, error) {
+	switch {
+	case p.tok.kind == tokNot || p.tok.kind == tokWord && p.tok.text == "not" && p.peek().kind == tokLParen:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokLParen {
+			key, err := p.parseKey()
+			if err != nil {
+				return nil, err
+			}
+			return newSelector(key, "doesnotexist", ""), nil
+		}
+		selector, err := p.parseParens()
+		if err != nil {
+			return nil, err
+		}
+		if selector.Group != nil && selector.Group.Op == "and" {
+			return newGroup("not", selector.Group.Selectors), nil
+		}
+		return newGroup("not", []*Selector{selector}), nil
+	case p.tok.kind == tokLParen:
+		return p.parseParens()
+	default:
+		return p.parseSelector()
+	}
+}
+
+func (p *queryParser) parseParens() (*Selector, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	selector, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokRParen {
+		return nil, p.errorf("expected ')', found %s", p.tok)
+	}
+	return selector, p.next()
+}
+
+func (p *queryParser) parseSelector() (*Selector, error) {
+	key, err := p.parseKey()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.tok.kind == tokOp:
+		op := p.tok.text
+		if op == "==" {
+			op = "="
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return newSelector(key, op, value), nil
+	case p.tok.kind == tokWord && (p.tok.text == "in" || p.tok.text == "notin"):
+		op := p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		values, err := p.parseValueList()
+		if err != nil {
+			return nil, err
+		}
+		selector := newSelector(key, op, "")
+		selector.Values = values
+		return selector, nil
+	case p.tok.kind == tokWord && (p.tok.text == "prefix" || p.tok.text == "regex"):
+		op := p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		valuePos := p.tok.pos
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if op == "regex" {
+			if _, err := regexp.Compile(value); err != nil {
+				return nil, &SyntaxError{Pos: valuePos, Msg: err.Error()}
+			}
+		}
+		return newSelector(key, op, value), nil
+	case p.tok.kind == tokEOF || p.tok.kind == tokRParen || p.isAnd() || p.isOr():
+		return newSelector(key, "exists", ""), nil
+	default:
+		return nil, p.errorf("expected an operator after %q, found %s", key, p.tok)
+	}
+}
+
+func (p *queryParser) isAnd() bool {
+	return p.tok.kind == tokComma || p.tok.kind == tokAnd || p.tok.kind == tokWord && p.tok.text == "and"
+}
+
+func (p *queryParser) isOr() bool {
+	return p.tok.kind == tokOr || p.tok.kind == tokWord && p.tok.text == "or"
+}
+
+// keys with the resource prefix select resources
+const resourceKeyPrefix = "resources."
+
+func newSelector(key, op, value string) *Selector {
+	selector := &Selector{ShouldBe: op, Value: value}
+	if resource, ok := strings.CutPrefix(key, resourceKeyPrefix); ok {
+		selector.Resource = resource
+	} else {
+		selector.LabelKey = key
+	}
+	return selector
+}
+
+func newGroup(op string, selectors []*Selector) *Selector {
+	return &Selector{
+		Group: &SelectorGroup{
+			Op:        op,
+			Selectors: selectors,
+		},
+	}
+}
+
+func (p *queryParser) parseKey() (string, error) {
+	if p.tok.kind != tokWord {
+		return "", p.errorf("expected a label key, found %s", p.tok)
+	}
+	key := p.tok.text
+	return key, p.next()
+}
+
+func (p *queryParser) parseValue() (string, error) {
+	if p.tok.kind != tokWord && p.tok.kind != tokString {
+		return "", p.errorf("expected a value, found %s", p.tok)
+	}
+	value := p.tok.text
+	return value, p.next()
+}
+
+func (p *queryParser) parseValueList() ([]string, error) {
+	if p.tok.kind != tokLParen {
+		return nil, p.errorf("expected '(', found %s", p.tok)
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	values := make([]string, 0)
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		switch p.tok.kind {
+		case tokRParen:
+			return values, p.next()
+		case tokComma:
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, p.errorf("expected ',' or ')', found %s", p.tok)
+		}
+	}
+}
+
+// peek returns the token following the current one without consuming it
+func (p *queryParser) peek() queryToken {
+	lexer := p.lexer
+	tok, _ := lexer.next()
+	return tok
+}
+
+func (p *queryParser) next() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *queryParser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+type queryTokenKind int8
+
+const (
+	tokEOF queryTokenKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokNot
+	tokComma
+	tokAnd
+	tokOr
+	tokLParen
+	tokRParen
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int
+}
+
+func (t queryToken) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of query"
+	case tokString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+type queryLexer struct {
+	input string
+	pos   int
+}
+
+func (l *queryLexer) next() (queryToken, error) {
+	for l.pos < len(l.input) && unicode.IsSpace(rune(l.input[l.pos])) {
+		l.pos++
+	}
+	start := l.pos
+	if start == len(l.input) {
+		return queryToken{kind: tokEOF, pos: start}, nil
+	}
+	switch c := l.input[start]; c {
+	case ',':
+		l.pos++
+		return queryToken{kind: tokComma, text: ",", pos: start}, nil
+	case '(':
+		l.pos++
+		return queryToken{kind: tokLParen, text: "(", pos: start}, nil
+	case ')':
+		l.pos++
+		return queryToken{kind: tokRParen, text: ")", pos: start}, nil
+	case '=', '>', '<':
+		l.pos++
+		if l.pos < len(l.input) && l.input[l.pos] == '=' {
+			l.pos++
+		}
+		return queryToken{kind: tokOp, text: l.input[start:l.pos], pos: start}, nil
+	case '!':
+		l.pos++
+		if l.pos < len(l.input) && l.input[l.pos] == '=' {
+			l.pos++
+			return queryToken{kind: tokOp, text: "!=", pos: start}, nil
+		}
+		return queryToken{kind: tokNot, text: "!", pos: start}, nil
+	case '&', '|':
+		if l.pos+1 < len(l.input) && l.input[l.pos+1] == c {
+			l.pos += 2
+			kind := tokAnd
+			if c == '|' {
+				kind = tokOr
+			}
+			return queryToken{kind: kind, text: l.input[start:l.pos], pos: start}, nil
+		}
+	case '"':
+		return l.string()
+	}
+	for l.pos < len(l.input) && isWordChar(l.input[l.pos]) && !l.atLogicalOp() {
+		l.pos++
+	}
+	if l.pos == start {
+		return queryToken{}, &SyntaxError{Pos: start, Msg: fmt.Sprintf("unexpected character %q", l.input[start])}
+	}
+	return queryToken{kind: tokWord, text: l.input[start:l.pos], pos: start}, nil
+}
+
+func (l *queryLexer) string() (queryToken, error) {
+	start := l.pos
+	value := strings.Builder{}
+	for l.pos++; l.pos < len(l.input); l.pos++ {
+		c := l.input[l.pos]
+		switch {
+		case c == '\\' && l.pos+1 < len(l.input) && (l.input[l.pos+1] == '"' || l.input[l.pos+1] == '\\'):
+			l.pos++
+			value.WriteByte(l.input[l.pos])
+		case c == '"':
+			l.pos++
+			return queryToken{kind: tokString, text: value.String(), pos: start}, nil
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return queryToken{}, &SyntaxError{Pos: start, Msg: "unterminated quoted value"}
+}
+
+// atLogicalOp reports whether the input continues with && or ||,
+// a single & or | is part of a word
+func (l *queryLexer) atLogicalOp() bool {
+	rest := l.input[l.pos:]
+	return strings.HasPrefix(rest, "&&") || strings.HasPrefix(rest, "||")
+}
+
+func isWordChar(c byte) bool {
+	return !unicode.IsSpace(rune(c)) && !strings.ContainsRune(",()=!<>\"", rune(c))
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"", "[]"},
+		{"os=linux", "[os = linux]"},
+		{"os=linux, cpu>4, !gpu, zone in (a,b)", "[os = linux cpu > 4 gpu doesnotexist  zone in [a b]]"},
+		{" os == linux ,cpu>4, zone != eu-west-1 ", "[os = linux cpu > 4 zone != eu-west-1]"},
+		{`name="a, b (c)"`, "[name = a, b (c)]"},
+		{`version<"1.5"`, "[version < 1.5]"},
+		{"cpu>=4, mem<=16", "[cpu >= 4 mem <= 16]"},
+		{"gpu, !spot", "[gpu exists  spot doesnotexist ]"},
+		{"zone in (a, b), tier notin (dev)", "[zone in [a b] tier notin [dev]]"},
+		{"zone=a || zone=b", "[or(zone = a, zone = b)]"},
+		{"zone=a||zone=b&&tier=x", "[or(zone = a, and(zone = b, tier = x))]"},
+		{"team=r&d, path=a|b, x=&", "[team = r&d path = a|b x = &]"},
+		{"(gpu=true && mem>64) or tier=premium", "[or(and(gpu = true, mem > 64), tier = premium)]"},
+		{"os=linux, gpu=true || tier=premium", "[or(and(os = linux, gpu = true), tier = premium)]"},
+		{"os=linux, (zone=a || zone=b)", "[os = linux or(zone = a, zone = b)]"},
+		{"!(zone=a, tier=dev), not (spot)", "[not(zone = a, tier = dev) not(spot exists )]"},
+		{"not=x, or", "[not = x or exists ]"},
+		{"resources.cpu>=8, !resources.gpu, resources.memory in (16, 32)", "[resource:cpu >= 8 resource:gpu doesnotexist  resource:memory in [16 32]]"},
+		{`hostname prefix web-, kernel regex "^5\.1[0-9]+ (lts)$"`, `[hostname prefix web- kernel regex ^5\.1[0-9]+ (lts)$]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			query, err := ParseQuery(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := selectorsString(query); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQuerySyntaxErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		pos  int
+	}{
+		{"os=", 3},
+		{"os=linux,", 9},
+		{"os linux", 3},
+		{"=linux", 0},
+		{"os=linux cpu>4", 9},
+		{`name="linux`, 5},
+		{"!", 1},
+		{"!gpu=true", 4},
+		{"zone in a", 8},
+		{"zone in (a,b", 12},
+		{"zone in ()", 9},
+		{"kernel regex [", 13},
+		{"(zone=a || zone=b", 17},
+		{"zone=a)", 6},
+		{"zone=a | zone=b", 7},
+		{"zone=a ||", 9},
+		{"!()", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseQuery(tt.expr)
+			syntaxErr := &SyntaxError{}
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected a syntax error, got %v", err)
+			}
+			if syntaxErr.Pos != tt.pos {
+				t.Errorf("got error at position %d, want %d (%s)", syntaxErr.Pos, tt.pos, syntaxErr)
+			}
+		})
+	}
+}
+
+func selectorsString(query []*Selector) string {
+	strs := make([]string, len(query))
+	for i, selector := range query {
+		strs[i] = selectorString(selector)
+	}
+	return fmt.Sprint(strs)
+}
+
+func selectorString(selector *Selector) string {
+	if selector.Group != nil {
+		strs := make([]string, len(selector.Group.Selectors))
+		for i, selector := range selector.Group.Selectors {
+			strs[i] = selectorString(selector)
+		}
+		return fmt.Sprintf("%s(%s)", selector.Group.Op, strings.Join(strs, ", "))
+	}
+	value := selector.Value
+	if len(selector.Values) > 0 {
+		value = fmt.Sprint(selector.Values)
+	}
+	key := selector.LabelKey
+	if selector.Resource != "" {
+		key = "resource:" + selector.Resource
+	}
+	return fmt.Sprintf("%s %s %s", key, selector.ShouldBe, value)
+}
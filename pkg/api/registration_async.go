@@ -3,11 +3,13 @@ package api
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/c12s/magnetar/pkg/messaging"
 	"github.com/c12s/magnetar/pkg/messaging/nats"
 	"github.com/golang/protobuf/proto"
 	natsgo "github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type RegistrationAsyncClient struct {
@@ -64,6 +66,25 @@ func (n *RegistrationAsyncClient) Register(req *RegistrationReq, callback Regist
 	return nil
 }
 
+// Heartbeat reports that the node is alive, agents are expected to send it periodically,
+// more often than the liveness TTL configured in magnetar
+func (n *RegistrationAsyncClient) Heartbeat(req *HeartbeatReq) error {
+	reqMarshalled, err := req.Marshal()
+	if err != nil {
+		return err
+	}
+	return n.publisher.Publish(reqMarshalled, HeartbeatSubject)
+}
+
+// Deregister removes the node from magnetar, agents are expected to send it on shutdown
+func (n *RegistrationAsyncClient) Deregister(req *DeregistrationReq) error {
+	reqMarshalled, err := req.Marshal()
+	if err != nil {
+		return err
+	}
+	return n.publisher.Publish(reqMarshalled, DeregistrationSubject)
+}
+
 type RegistrationCallback func(resp *RegistrationResp)
 
 type RegistrationReqBuilder struct {
@@ -103,6 +124,58 @@ func (r RegistrationReqBuilder) AddStringLabel(key string, value string) Registr
 	return r.addLabel(key, Value_String, valueMarshalled)
 }
 
+func (r RegistrationReqBuilder) AddInt64Label(key string, value int64) RegistrationReqBuilder {
+	valueMarshalled, err := proto.Marshal(&Int64Value{Value: value})
+	if err != nil {
+		return r
+	}
+	return r.addLabel(key, Value_Int64, valueMarshalled)
+}
+
+func (r RegistrationReqBuilder) AddTimestampLabel(key string, value time.Time) RegistrationReqBuilder {
+	valueMarshalled, err := proto.Marshal(&TimestampValue{Value: timestamppb.New(value)})
+	if err != nil {
+		return r
+	}
+	return r.addLabel(key, Value_Timestamp, valueMarshalled)
+}
+
+// AddSemverLabel adds a semantic version, such as 1.2.3 or v1.2.3-rc.1, registration fails if it isn't valid
+func (r RegistrationReqBuilder) AddSemverLabel(key string, value string) RegistrationReqBuilder {
+	valueMarshalled, err := proto.Marshal(&SemverValue{Value: value})
+	if err != nil {
+		return r
+	}
+	return r.addLabel(key, Value_Semver, valueMarshalled)
+}
+
+func (r RegistrationReqBuilder) AddStringSetLabel(key string, values []string) RegistrationReqBuilder {
+	valueMarshalled, err := proto.Marshal(&StringSetValue{Values: values})
+	if err != nil {
+		return r
+	}
+	return r.addLabel(key, Value_StringSet, valueMarshalled)
+}
+
+// WithNodeId makes the registration update the node issued by an earlier registration
+func (r RegistrationReqBuilder) WithNodeId(nodeId string) RegistrationReqBuilder {
+	r.req.NodeId = nodeId
+	return r
+}
+
+// WithMachineId makes every registration from the same machine resolve to the same node
+func (r RegistrationReqBuilder) WithMachineId(machineId string) RegistrationReqBuilder {
+	r.req.MachineId = machineId
+	return r
+}
+
+// WithRegistrationToken passes the token returned by the first registration of the node,
+// registering as an existing node is rejected without it
+func (r RegistrationReqBuilder) WithRegistrationToken(token string) RegistrationReqBuilder {
+	r.req.RegistrationToken = token
+	return r
+}
+
 func (r RegistrationReqBuilder) addLabel(key string, valueType Value_ValueTYpe, valueMarshalled []byte) RegistrationReqBuilder {
 	label := &Label{
 		Key: key,
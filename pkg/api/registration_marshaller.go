@@ -17,3 +17,19 @@ func (x *RegistrationResp) Marshal() ([]byte, error) {
 func (x *RegistrationResp) Unmarshal(marshalled []byte) error {
 	return proto.Unmarshal(marshalled, x)
 }
+
+func (x *HeartbeatReq) Marshal() ([]byte, error) {
+	return proto.Marshal(x)
+}
+
+func (x *HeartbeatReq) Unmarshal(marshalled []byte) error {
+	return proto.Unmarshal(marshalled, x)
+}
+
+func (x *DeregistrationReq) Marshal() ([]byte, error) {
+	return proto.Marshal(x)
+}
+
+func (x *DeregistrationReq) Unmarshal(marshalled []byte) error {
+	return proto.Unmarshal(marshalled, x)
+}
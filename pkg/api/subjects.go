@@ -1,5 +1,7 @@
 package api
 
 const (
-	RegistrationSubject = "magnetar.registration"
+	RegistrationSubject   = "magnetar.registration"
+	HeartbeatSubject      = "magnetar.heartbeat"
+	DeregistrationSubject = "magnetar.deregistration"
 )